@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/kiaplayer/clean-architecture-example/db/migrations"
+	"github.com/kiaplayer/clean-architecture-example/internal/adapters/repositories/document/counter"
+	"github.com/kiaplayer/clean-architecture-example/internal/adapters/repositories/document/sale_order"
+	"github.com/kiaplayer/clean-architecture-example/internal/adapters/repositories/idempotency"
+	"github.com/kiaplayer/clean-architecture-example/internal/adapters/repositories/outbox"
+	"github.com/kiaplayer/clean-architecture-example/internal/adapters/repositories/reference/company"
+	"github.com/kiaplayer/clean-architecture-example/internal/adapters/repositories/reference/customer"
+	"github.com/kiaplayer/clean-architecture-example/internal/adapters/repositories/reference/product"
+	"github.com/kiaplayer/clean-architecture-example/internal/adapters/repositories/reference/user"
+	"github.com/kiaplayer/clean-architecture-example/internal/adapters/repositories/register/stock"
+	webhookrepository "github.com/kiaplayer/clean-architecture-example/internal/adapters/repositories/webhook"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+	outboxservice "github.com/kiaplayer/clean-architecture-example/internal/domain/service/outbox"
+	saleorderservice "github.com/kiaplayer/clean-architecture-example/internal/domain/service/sale_order"
+	stockservice "github.com/kiaplayer/clean-architecture-example/internal/domain/service/stock"
+	webhookservice "github.com/kiaplayer/clean-architecture-example/internal/domain/service/webhook"
+	createsaleorderusecase "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/create_sale_order"
+	deletesaleorderusecase "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/delete_sale_order"
+	getsaleorderusecase "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/get_sale_order"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/idempotent"
+	listsaleordersusecase "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/list_sale_orders"
+	listwebhookdeliveriesusecase "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/list_webhook_deliveries"
+	postsaleorderusecase "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/post_sale_order"
+	replaywebhookdeliveryusecase "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/replay_webhook_delivery"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/transactional"
+	unpostsaleorderusecase "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/unpost_sale_order"
+	updatesaleorderusecase "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/update_sale_order"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/middleware"
+	"github.com/kiaplayer/clean-architecture-example/pkg/generators"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/migrator"
+)
+
+// app holds the dependencies shared by the commands, use cases are run in transactions
+// and sale orders are created idempotently.
+type app struct {
+	config             config
+	db                 *sql.DB
+	migrator           *migrator.Migrator
+	transactor         *db.Transactor
+	timeGenerator      *generators.TimeGenerator
+	userRepository     *user.Repository
+	customerRepository *customer.Repository
+	outboxRepository   *outbox.Repository
+	webhookRepository  *webhookrepository.Repository
+	webhookService     *webhookservice.Service
+
+	createSaleOrder       *idempotent.UseCase[*document.SaleOrder, *document.SaleOrder]
+	updateSaleOrder       *transactional.UseCase[*document.SaleOrder, *document.SaleOrder]
+	getSaleOrder          *transactional.UseCase[uint64, *document.SaleOrder]
+	listSaleOrders        *transactional.UseCase[document.SaleOrderListQuery, *document.SaleOrderList]
+	postSaleOrder         *transactional.UseCase2[uint64, uint64, *document.SaleOrder]
+	unpostSaleOrder       *transactional.UseCase2[uint64, uint64, *document.SaleOrder]
+	deleteSaleOrder       *transactional.UseCase2[uint64, uint64, *document.SaleOrder]
+	listWebhookDeliveries *transactional.UseCase[webhook.DeliveryStatus, []*webhook.Delivery]
+	replayWebhookDelivery *transactional.UseCase[uint64, *webhook.Delivery]
+}
+
+// newApp opens the database and wires the dependencies, the schema is not migrated.
+func newApp(cfg config) (*app, error) {
+	dbConn, err := sql.Open("sqlite3", cfg.SQLiteDBFile)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaMigrator, err := migrator.NewMigrator(dbConn, migrations.FS)
+	if err != nil {
+		_ = dbConn.Close()
+		return nil, fmt.Errorf("cannot init db migrator: %w", err)
+	}
+
+	transactorConfig := db.DefaultTransactorConfig
+	transactorConfig.MaxAttempts = cfg.TxMaxAttempts
+	transactorConfig.OnAttempt = func(ctx context.Context, attempt int, err error) {
+		if err != nil && db.IsRetryableError(err) {
+			log.Printf("Transaction attempt %d failed: %s", attempt, err)
+		}
+	}
+	transactor := db.NewTransactor(dbConn, transactorConfig)
+	timeGenerator := generators.NewTimeGenerator()
+	numberGenerator, err := generators.NewNumberGenerator(
+		counter.NewRepository(dbConn),
+		"sale_order",
+		cfg.SaleOrderNumberFormat,
+	)
+	if err != nil {
+		_ = dbConn.Close()
+		return nil, err
+	}
+	customerRepo := customer.NewRepository(dbConn)
+	stockService := stockservice.NewService(stock.NewRepository(dbConn))
+	outboxRepo := outbox.NewRepository(dbConn)
+	outboxService := outboxservice.NewService(outboxRepo, timeGenerator)
+	webhookRepo := webhookrepository.NewRepository(dbConn)
+	webhookService := webhookservice.NewService(webhookRepo, timeGenerator)
+	saleOrderService := saleorderservice.NewService(
+		sale_order.NewRepository(dbConn),
+		product.NewRepository(dbConn),
+		customerRepo,
+		company.NewRepository(dbConn),
+		stockService,
+		outboxService,
+		cfg.SaleOrderPricePolicy,
+	)
+	accessPolicy := access.NewPolicy(access.DefaultRolePermissions)
+
+	return &app{
+		config:             cfg,
+		db:                 dbConn,
+		migrator:           schemaMigrator,
+		transactor:         transactor,
+		timeGenerator:      timeGenerator,
+		userRepository:     user.NewRepository(dbConn),
+		customerRepository: customerRepo,
+		outboxRepository:   outboxRepo,
+		webhookRepository:  webhookRepo,
+		webhookService:     webhookService,
+
+		createSaleOrder: idempotent.New(
+			transactor,
+			idempotency.NewRepository(dbConn),
+			timeGenerator,
+			transactional.New(
+				transactor,
+				createsaleorderusecase.NewUseCase(timeGenerator, numberGenerator, saleOrderService, accessPolicy).Handle,
+			).Handle,
+			func(saleOrder *document.SaleOrder) uint64 { return saleOrder.ID },
+			saleOrderService.GetOrderByID,
+		),
+		updateSaleOrder: transactional.New(
+			transactor,
+			updatesaleorderusecase.NewUseCase(timeGenerator, saleOrderService, accessPolicy).Handle,
+		),
+		getSaleOrder: transactional.NewReadOnly(
+			transactor,
+			getsaleorderusecase.NewUseCase(saleOrderService, accessPolicy).Handle,
+		),
+		listSaleOrders: transactional.NewReadOnly(
+			transactor,
+			listsaleordersusecase.NewUseCase(saleOrderService, accessPolicy).Handle,
+		),
+		postSaleOrder: transactional.New2(
+			transactor,
+			postsaleorderusecase.NewUseCase(timeGenerator, saleOrderService, accessPolicy).Handle,
+		),
+		unpostSaleOrder: transactional.New2(
+			transactor,
+			unpostsaleorderusecase.NewUseCase(timeGenerator, saleOrderService, accessPolicy).Handle,
+		),
+		deleteSaleOrder: transactional.New2(
+			transactor,
+			deletesaleorderusecase.NewUseCase(timeGenerator, saleOrderService, accessPolicy).Handle,
+		),
+		listWebhookDeliveries: transactional.NewReadOnly(
+			transactor,
+			listwebhookdeliveriesusecase.NewUseCase(webhookService, accessPolicy).Handle,
+		),
+		replayWebhookDelivery: transactional.New(
+			transactor,
+			replaywebhookdeliveryusecase.NewUseCase(webhookService, accessPolicy).Handle,
+		),
+	}, nil
+}
+
+func (a *app) close() error {
+	return a.db.Close()
+}
+
+// checkSchema returns the schema version, a dirty schema is an error.
+func (a *app) checkSchema() (uint, error) {
+	version, dirty, err := a.migrator.Version()
+	if err != nil {
+		return 0, fmt.Errorf("cannot read db schema version: %w", err)
+	}
+	if dirty {
+		return version, fmt.Errorf("db schema version %d is dirty, fix the failed migration and force the version", version)
+	}
+	return version, nil
+}
+
+// authenticate puts the user owning the API key into the context, like the HTTP API does for bearer tokens.
+func (a *app) authenticate(ctx context.Context, apiKey string) (context.Context, error) {
+	if apiKey == "" {
+		return nil, errors.New("API key is required, set -api-key or API_KEY")
+	}
+
+	u, err := a.userRepository.GetByAPIKeyHash(ctx, middleware.HashAPIKey(apiKey))
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, errors.New("invalid API key")
+	}
+
+	return access.WithUser(ctx, u), nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// check validates the configuration, opens the database and compares its schema with the embedded migrations.
+func check(cfg config, cfgErr error, out io.Writer) error {
+	if cfgErr != nil {
+		return fmt.Errorf("bad configuration:\n%w", cfgErr)
+	}
+	_, _ = fmt.Fprintln(out, "Configuration: ok")
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = a.close()
+	}()
+
+	version, err := a.checkSchema()
+	if err != nil {
+		return err
+	}
+	latestVersion, err := a.migrator.LatestVersion()
+	if err != nil {
+		return err
+	}
+	if version < latestVersion && !cfg.MigrateOnStart {
+		return fmt.Errorf("db schema version %d is behind the latest migration %d", version, latestVersion)
+	}
+
+	_, err = fmt.Fprintf(out, "Db schema version: %d, latest migration: %d\n", version, latestVersion)
+	return err
+}
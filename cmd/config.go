@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	notificationservice "github.com/kiaplayer/clean-architecture-example/internal/domain/service/notification"
+	outboxservice "github.com/kiaplayer/clean-architecture-example/internal/domain/service/outbox"
+	saleorderservice "github.com/kiaplayer/clean-architecture-example/internal/domain/service/sale_order"
+	"github.com/kiaplayer/clean-architecture-example/pkg/generators"
+	"github.com/kiaplayer/clean-architecture-example/pkg/mail"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+// config is the service configuration read from the environment.
+type config struct {
+	ServiceAddr           string
+	SQLiteDBFile          string
+	MigrateOnStart        bool
+	TxMaxAttempts         int
+	SaleOrderNumberFormat string
+	SaleOrderPricePolicy  saleorderservice.PricePolicy
+	OutboxPollInterval    time.Duration
+	// SMTP.Addr is empty when email notifications are disabled.
+	SMTP         mail.Config
+	Notification notificationservice.Config
+}
+
+// loadConfig reads the configuration from the environment, every bad value is reported in the error.
+func loadConfig() (config, error) {
+	cfg := config{
+		ServiceAddr:           os.Getenv("SERVICE_ADDR"),
+		SQLiteDBFile:          os.Getenv("SQLITE_DB_FILE"),
+		MigrateOnStart:        true,
+		TxMaxAttempts:         db.DefaultTransactorConfig.MaxAttempts,
+		SaleOrderNumberFormat: os.Getenv("SALE_ORDER_NUMBER_FORMAT"),
+		SaleOrderPricePolicy:  saleorderservice.PricePolicy(os.Getenv("SALE_ORDER_PRICE_POLICY")),
+		OutboxPollInterval:    outboxservice.DefaultDispatcherConfig.PollInterval,
+		SMTP: mail.Config{
+			Addr:     os.Getenv("SMTP_ADDR"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+		},
+		Notification: notificationservice.Config{
+			From: os.Getenv("NOTIFICATION_FROM"),
+		},
+	}
+
+	var errs []error
+
+	if cfg.SQLiteDBFile == "" {
+		errs = append(errs, errors.New("SQLITE_DB_FILE is required"))
+	}
+	if value := os.Getenv("MIGRATE_ON_START"); value != "" {
+		var err error
+		cfg.MigrateOnStart, err = strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("bad migrate on start flag: %w", err))
+		}
+	}
+	if value := os.Getenv("TX_MAX_ATTEMPTS"); value != "" {
+		var err error
+		cfg.TxMaxAttempts, err = strconv.Atoi(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("bad transaction max attempts: %w", err))
+		} else if cfg.TxMaxAttempts < 1 {
+			errs = append(errs, fmt.Errorf("bad transaction max attempts: %d, must be at least 1", cfg.TxMaxAttempts))
+		}
+	}
+	if cfg.SaleOrderNumberFormat == "" {
+		cfg.SaleOrderNumberFormat = generators.DefaultNumberFormat
+	}
+	_, err := generators.NewNumberGenerator(nil, "sale_order", cfg.SaleOrderNumberFormat)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if !slices.Contains(saleorderservice.ValidPricePolicies, cfg.SaleOrderPricePolicy) {
+		errs = append(errs, fmt.Errorf("bad sale order price policy: %q", cfg.SaleOrderPricePolicy))
+	}
+	if value := os.Getenv("OUTBOX_POLL_INTERVAL"); value != "" {
+		cfg.OutboxPollInterval, err = time.ParseDuration(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("bad outbox poll interval: %w", err))
+		} else if cfg.OutboxPollInterval <= 0 {
+			errs = append(errs, fmt.Errorf("bad outbox poll interval: %s, must be positive", value))
+		}
+	}
+	if value := os.Getenv("SMTP_TIMEOUT"); value != "" {
+		cfg.SMTP.Timeout, err = time.ParseDuration(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("bad SMTP timeout: %w", err))
+		}
+	}
+	if value := os.Getenv("NOTIFICATION_MANAGER_EMAILS"); value != "" {
+		cfg.Notification.ManagerEmails = strings.Split(value, ",")
+	}
+
+	return cfg, errors.Join(errs...)
+}
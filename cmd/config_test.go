@@ -0,0 +1,119 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	saleorderservice "github.com/kiaplayer/clean-architecture-example/internal/domain/service/sale_order"
+	"github.com/kiaplayer/clean-architecture-example/pkg/generators"
+)
+
+func setConfigEnv(t *testing.T, env map[string]string) {
+	for _, name := range []string{
+		"SERVICE_ADDR",
+		"SQLITE_DB_FILE",
+		"MIGRATE_ON_START",
+		"TX_MAX_ATTEMPTS",
+		"SALE_ORDER_NUMBER_FORMAT",
+		"SALE_ORDER_PRICE_POLICY",
+		"OUTBOX_POLL_INTERVAL",
+		"SMTP_ADDR",
+		"SMTP_TIMEOUT",
+		"NOTIFICATION_MANAGER_EMAILS",
+	} {
+		t.Setenv(name, env[name])
+	}
+}
+
+func TestLoadConfig_Success(t *testing.T) {
+	// arrange
+	setConfigEnv(t, map[string]string{
+		"SERVICE_ADDR":                ":3000",
+		"SQLITE_DB_FILE":              "sqlite.db",
+		"MIGRATE_ON_START":            "false",
+		"TX_MAX_ATTEMPTS":             "3",
+		"SALE_ORDER_PRICE_POLICY":     "reject",
+		"OUTBOX_POLL_INTERVAL":        "2s",
+		"SMTP_TIMEOUT":                "5s",
+		"NOTIFICATION_MANAGER_EMAILS": "a@example.com,b@example.com",
+	})
+
+	// act
+	cfg, err := loadConfig()
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, ":3000", cfg.ServiceAddr)
+	assert.Equal(t, "sqlite.db", cfg.SQLiteDBFile)
+	assert.False(t, cfg.MigrateOnStart)
+	assert.Equal(t, 3, cfg.TxMaxAttempts)
+	assert.Equal(t, generators.DefaultNumberFormat, cfg.SaleOrderNumberFormat)
+	assert.Equal(t, saleorderservice.PricePolicy("reject"), cfg.SaleOrderPricePolicy)
+	assert.Equal(t, 2*time.Second, cfg.OutboxPollInterval)
+	assert.Equal(t, 5*time.Second, cfg.SMTP.Timeout)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, cfg.Notification.ManagerEmails)
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	// arrange
+	setConfigEnv(t, map[string]string{
+		"MIGRATE_ON_START":         "maybe",
+		"TX_MAX_ATTEMPTS":          "many",
+		"SALE_ORDER_NUMBER_FORMAT": "{{.Unknown}}",
+		"SALE_ORDER_PRICE_POLICY":  "bad",
+		"OUTBOX_POLL_INTERVAL":     "often",
+		"SMTP_TIMEOUT":             "soon",
+	})
+
+	// act
+	_, err := loadConfig()
+
+	// assert
+	assert.ErrorContains(t, err, "SQLITE_DB_FILE is required")
+	assert.ErrorContains(t, err, "bad migrate on start flag")
+	assert.ErrorContains(t, err, "bad transaction max attempts")
+	assert.ErrorContains(t, err, "bad number format")
+	assert.ErrorContains(t, err, `bad sale order price policy: "bad"`)
+	assert.ErrorContains(t, err, "bad outbox poll interval")
+	assert.ErrorContains(t, err, "bad SMTP timeout")
+}
+
+func TestLoadConfig_NonPositiveOutboxPollInterval(t *testing.T) {
+	for _, value := range []string{"0s", "-1s"} {
+		t.Run(value, func(t *testing.T) {
+			// arrange
+			setConfigEnv(t, map[string]string{
+				"SQLITE_DB_FILE":          "sqlite.db",
+				"SALE_ORDER_PRICE_POLICY": "reject",
+				"OUTBOX_POLL_INTERVAL":    value,
+			})
+
+			// act
+			_, err := loadConfig()
+
+			// assert
+			assert.EqualError(t, err, "bad outbox poll interval: "+value+", must be positive")
+		})
+	}
+}
+
+func TestLoadConfig_TxMaxAttemptsBelowOne(t *testing.T) {
+	for _, value := range []string{"0", "-1"} {
+		t.Run(value, func(t *testing.T) {
+			// arrange
+			setConfigEnv(t, map[string]string{
+				"SQLITE_DB_FILE":          "sqlite.db",
+				"SALE_ORDER_PRICE_POLICY": "reject",
+				"TX_MAX_ATTEMPTS":         value,
+			})
+
+			// act
+			_, err := loadConfig()
+
+			// assert
+			assert.EqualError(t, err, "bad transaction max attempts: "+value+", must be at least 1")
+		})
+	}
+}
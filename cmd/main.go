@@ -2,84 +2,82 @@ package main
 
 import (
 	"context"
-	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"log"
-	"net/http"
 	"os"
-	"os/signal"
 
 	"github.com/joho/godotenv"
 	_ "github.com/mattn/go-sqlite3"
-
-	"github.com/kiaplayer/clean-architecture-example/internal/adapters/repositories/document/sale_order"
-	"github.com/kiaplayer/clean-architecture-example/internal/adapters/repositories/reference/product"
-	saleorderservice "github.com/kiaplayer/clean-architecture-example/internal/domain/service/sale_order"
-	createsaleorderusecase "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/create_sale_order"
-	getsaleorderusecase "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/get_sale_order"
-	"github.com/kiaplayer/clean-architecture-example/internal/handlers/create_sale_order"
-	"github.com/kiaplayer/clean-architecture-example/internal/handlers/get_sale_order"
-	"github.com/kiaplayer/clean-architecture-example/pkg/generators"
-	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
 )
 
+const usage = `Usage: %s <command> [arguments]
+
+Commands:
+  serve                        run the HTTP service, the default command
+  migrate up|down [N]|version  apply, roll back or show the db migrations
+  seed                         add demo products, customers and stock
+  order get|create|list        run sale order use cases against the local db
+  check                        validate the configuration and the db schema
+`
+
 func main() {
 	err := godotenv.Load(".env")
 	if err != nil {
 		log.Fatalf("Error loading .env file: %s", err)
 	}
 
-	dbConn, err := sql.Open("sqlite3", os.Getenv("SQLITE_DB_FILE"))
+	command, args := "serve", []string(nil)
+	if len(os.Args) > 1 {
+		command, args = os.Args[1], os.Args[2:]
+	}
+
+	err = run(context.Background(), command, args, os.Stdout)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer func(dbConn *sql.DB) {
-		closeErr := dbConn.Close()
-		if closeErr != nil {
-			log.Fatal(closeErr)
-		}
-	}(dbConn)
-
-	transactor := db.NewTransactor(dbConn)
-	timeGenerator := generators.NewTimeGenerator()
-	numberGenerator := generators.NewNumberGenerator()
-	saleOrderRepo := sale_order.NewRepository(dbConn)
-	productRepo := product.NewRepository(dbConn)
-	saleOrderService := saleorderservice.NewService(saleOrderRepo, productRepo)
-
-	createSaleOrderHandler := create_sale_order.NewHandler(
-		createsaleorderusecase.NewUseCase(timeGenerator, numberGenerator, saleOrderService),
-		transactor,
-	)
-	getSaleOrderHandler := get_sale_order.NewHandler(getsaleorderusecase.NewUseCase(saleOrderService))
-
-	srvMux := http.NewServeMux()
-	srvMux.HandleFunc("POST /sale-order", createSaleOrderHandler.Handle)
-	srvMux.HandleFunc("GET /sale-order", getSaleOrderHandler.Handle)
+}
 
-	srv := http.Server{
-		Addr:    os.Getenv("SERVICE_ADDR"),
-		Handler: srvMux,
+// run runs the command, the service configuration is read from the environment.
+func run(ctx context.Context, command string, args []string, out io.Writer) error {
+	switch command {
+	case "help", "-h", "-help", "--help":
+		_, err := fmt.Fprintf(out, usage, os.Args[0])
+		return err
+	case "serve", "migrate", "seed", "order", "check":
+	default:
+		return fmt.Errorf("unknown command %q\n\n"+usage, command, os.Args[0])
 	}
 
-	idleConnsClosed := make(chan struct{})
-	go func() {
-		sigint := make(chan os.Signal, 1)
-		signal.Notify(sigint, os.Interrupt)
-		<-sigint
-
-		log.Println("Service shutting down...")
+	cfg, err := loadConfig()
+	if command == "check" {
+		return check(cfg, err, out)
+	}
+	if err != nil {
+		return fmt.Errorf("bad configuration:\n%w", err)
+	}
 
-		if err := srv.Shutdown(context.Background()); err != nil {
-			log.Printf("HTTP server Shutdown: %v", err)
-		}
-		close(idleConnsClosed)
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = a.close()
 	}()
 
-	log.Printf("Service started at: %s", srv.Addr)
-
-	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
-		log.Fatalf("HTTP server ListenAndServe: %v", err)
+	switch command {
+	case "migrate":
+		return runMigrate(a, args, out)
+	case "seed":
+		return seed(ctx, a, out)
+	case "order":
+		return runOrder(ctx, a, args, out)
+	default:
+		return serve(a)
 	}
-
-	<-idleConnsClosed
 }
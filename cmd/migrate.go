@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const migrateUsage = `Usage: migrate <command>
+
+Commands:
+  up         apply all pending migrations
+  down [N]   roll back the last N migrations, 1 by default
+  version    print the schema version
+`
+
+// runMigrate manages the embedded migrations like the migrate CLI does for the migrations dir.
+func runMigrate(a *app, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		err := a.migrator.Up()
+		if err != nil {
+			return err
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return fmt.Errorf("bad number of migrations to roll back: %q", args[1])
+			}
+		}
+		err := a.migrator.Steps(-steps)
+		if err != nil {
+			return err
+		}
+	case "version":
+	default:
+		return fmt.Errorf("unknown migrate command %q\n\n%s", args[0], migrateUsage)
+	}
+
+	version, dirty, err := a.migrator.Version()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		_, err = fmt.Fprintf(out, "%d (dirty)\n", version)
+		return err
+	}
+	_, err = fmt.Fprintln(out, version)
+	return err
+}
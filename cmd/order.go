@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	createdto "github.com/kiaplayer/clean-architecture-example/internal/handlers/create_sale_order/dto"
+	getdto "github.com/kiaplayer/clean-architecture-example/internal/handlers/get_sale_order/dto"
+	listdto "github.com/kiaplayer/clean-architecture-example/internal/handlers/list_sale_orders/dto"
+)
+
+const orderUsage = `Usage: order <command> [flags]
+
+Commands:
+  get      print the sale order
+  create   create a sale order
+  list     print sale orders
+
+Every command runs on behalf of the user owning the API key from the -api-key flag or API_KEY,
+run order <command> -h for the flags of the command.
+`
+
+// runOrder runs the sale order use cases against the local database and prints the results as JSON.
+func runOrder(ctx context.Context, a *app, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(orderUsage)
+	}
+
+	flags := flag.NewFlagSet("order "+args[0], flag.ContinueOnError)
+	// the key from the environment is not the flag default, so it is not printed in the usage
+	apiKey := flags.String("api-key", "", "API key of the user, API_KEY if empty")
+
+	var run func(ctx context.Context) (any, error)
+	switch args[0] {
+	case "get":
+		run = orderGetCommand(a, flags)
+	case "create":
+		run = orderCreateCommand(a, flags)
+	case "list":
+		run = orderListCommand(a, flags)
+	default:
+		return fmt.Errorf("unknown order command %q\n\n%s", args[0], orderUsage)
+	}
+
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+	if *apiKey == "" {
+		*apiKey = os.Getenv("API_KEY")
+	}
+
+	_, err = a.checkSchema()
+	if err != nil {
+		return err
+	}
+	ctx, err = a.authenticate(ctx, *apiKey)
+	if err != nil {
+		return err
+	}
+
+	result, err := run(ctx)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+func orderGetCommand(a *app, flags *flag.FlagSet) func(ctx context.Context) (any, error) {
+	id := flags.Uint64("id", 0, "sale order ID")
+
+	return func(ctx context.Context) (any, error) {
+		if *id == 0 {
+			return nil, errors.New("-id is required")
+		}
+
+		saleOrder, err := a.getSaleOrder.Handle(ctx, *id)
+		if err != nil {
+			return nil, err
+		}
+
+		return getdto.SaleOrderToSaleOrderDto(saleOrder), nil
+	}
+}
+
+func orderCreateCommand(a *app, flags *flag.FlagSet) func(ctx context.Context) (any, error) {
+	var saleOrderDTO createdto.SaleOrder
+	flags.Uint64Var(&saleOrderDTO.CustomerID, "customer", 0, "customer ID")
+	flags.StringVar(&saleOrderDTO.Currency, "currency", "", "currency, the default one if empty")
+	idempotencyKey := flags.String("idempotency-key", "", "create the order once, repeats with the key print the same order")
+	flags.Var(
+		(*productsFlag)(&saleOrderDTO.Products),
+		"product",
+		"product as ID:QUANTITY or ID:QUANTITY:PRICE, repeat the flag for every product",
+	)
+
+	return func(ctx context.Context) (any, error) {
+		if saleOrderDTO.CustomerID == 0 {
+			return nil, errors.New("-customer is required")
+		}
+		if len(saleOrderDTO.Products) == 0 {
+			return nil, errors.New("-product is required")
+		}
+
+		saleOrder, err := createdto.SaleOrderDtoToSaleOrder(saleOrderDTO)
+		if err != nil {
+			return nil, err
+		}
+
+		saleOrder, _, err = a.createSaleOrder.Handle(ctx, *idempotencyKey, saleOrder)
+		if err != nil {
+			return nil, err
+		}
+
+		return getdto.SaleOrderToSaleOrderDto(saleOrder), nil
+	}
+}
+
+func orderListCommand(a *app, flags *flag.FlagSet) func(ctx context.Context) (any, error) {
+	var query document.SaleOrderListQuery
+	flags.Uint64Var(&query.Filter.CustomerID, "customer", 0, "customer ID")
+	flags.StringVar(&query.Filter.NumberPrefix, "number", "", "number prefix")
+	flags.IntVar(&query.Limit, "limit", 0, "page size, the default one if 0")
+	flags.StringVar(&query.Cursor, "cursor", "", "next_cursor of the previous page")
+	status := flags.String("status", "", "status: draft, posted or deleted")
+	dateFrom := flags.String("date-from", "", "first date as YYYY-MM-DD")
+	dateTo := flags.String("date-to", "", "last date as YYYY-MM-DD")
+	sort := flags.String("sort", "", "sort field: date or number, prefixed with - for descending order")
+
+	return func(ctx context.Context) (any, error) {
+		if *status != "" {
+			value, ok := document.ParseStatus(*status)
+			if !ok {
+				return nil, fmt.Errorf("bad status: %q", *status)
+			}
+			query.Filter.Status = &value
+		}
+
+		var err error
+		if *dateFrom != "" {
+			query.Filter.DateFrom, err = time.ParseInLocation(time.DateOnly, *dateFrom, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("bad date from: %w", err)
+			}
+		}
+		if *dateTo != "" {
+			query.Filter.DateTo, err = time.ParseInLocation(time.DateOnly, *dateTo, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("bad date to: %w", err)
+			}
+			query.Filter.DateTo = query.Filter.DateTo.Add(24*time.Hour - time.Second)
+		}
+
+		if *sort != "" {
+			field, desc := strings.CutPrefix(*sort, "-")
+			query.Sort = document.SaleOrderSort{
+				Field: document.SaleOrderSortField(field),
+				Desc:  desc,
+			}
+		}
+
+		saleOrders, err := a.listSaleOrders.Handle(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		return listdto.SaleOrderListToSaleOrderListDto(saleOrders), nil
+	}
+}
+
+// productsFlag collects -product flags.
+type productsFlag []createdto.SaleOrderProduct
+
+func (f *productsFlag) String() string {
+	if f == nil {
+		return ""
+	}
+
+	values := make([]string, 0, len(*f))
+	for _, product := range *f {
+		value := fmt.Sprintf("%d:%d", product.ProductID, product.Quantity)
+		if product.Price != nil {
+			value += ":" + product.Price.String()
+		}
+		values = append(values, value)
+	}
+
+	return strings.Join(values, ",")
+}
+
+func (f *productsFlag) Set(value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return errors.New("must be ID:QUANTITY or ID:QUANTITY:PRICE")
+	}
+
+	var product createdto.SaleOrderProduct
+	var err error
+
+	product.ProductID, err = strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || product.ProductID == 0 {
+		return fmt.Errorf("bad product ID: %q", parts[0])
+	}
+	product.Quantity, err = strconv.ParseUint(parts[1], 10, 64)
+	if err != nil || product.Quantity == 0 || product.Quantity > document.MaxLineQuantity {
+		return fmt.Errorf("bad quantity: %q, must be from 1 to %d", parts[1], document.MaxLineQuantity)
+	}
+	if len(parts) == 3 {
+		price := json.Number(parts[2])
+		product.Price = &price
+	}
+
+	*f = append(*f, product)
+
+	return nil
+}
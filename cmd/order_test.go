@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	createdto "github.com/kiaplayer/clean-architecture-example/internal/handlers/create_sale_order/dto"
+)
+
+func TestProductsFlag_Set(t *testing.T) {
+	// arrange
+	var products productsFlag
+	price := json.Number("10.50")
+
+	// act
+	firstErr := products.Set("1:2")
+	secondErr := products.Set("3:1:10.50")
+
+	// assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, productsFlag{
+		{ProductID: 1, Quantity: 2},
+		{ProductID: 3, Quantity: 1, Price: &price},
+	}, products)
+	assert.Equal(t, "1:2,3:1:10.50", products.String())
+}
+
+func TestProductsFlag_SetError(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "no quantity", value: "1"},
+		{name: "too many parts", value: "1:2:3:4"},
+		{name: "bad product ID", value: "x:1"},
+		{name: "zero product ID", value: "0:1"},
+		{name: "bad quantity", value: "1:-1"},
+		{name: "zero quantity", value: "1:0"},
+		{name: "too large quantity", value: "1:1000001"},
+		{name: "overflowing quantity", value: "1:18446744073709551615"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			var products productsFlag
+
+			// act
+			err := products.Set(tt.value)
+
+			// assert
+			assert.Error(t, err)
+			assert.Equal(t, []createdto.SaleOrderProduct(nil), []createdto.SaleOrderProduct(products))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/kiaplayer/clean-architecture-example/db/seeds"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+// seed adds demo products, customers and their stock to the default company in one transaction.
+// Existing rows are kept, so it can be run repeatedly.
+func seed(ctx context.Context, a *app, out io.Writer) error {
+	_, err := a.checkSchema()
+	if err != nil {
+		return err
+	}
+
+	names, err := fs.Glob(seeds.FS, "*.sql")
+	if err != nil {
+		return err
+	}
+
+	executor := db.NewTransactionalRepository(a.db)
+	_, err = a.transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		for _, name := range names {
+			script, err := fs.ReadFile(seeds.FS, name)
+			if err != nil {
+				return nil, err
+			}
+			_, err = executor.DB(ctx).ExecContext(ctx, string(script))
+			if err != nil {
+				return nil, fmt.Errorf("seed %s: %w", name, err)
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "Applied seeds: %d\n", len(names))
+	return err
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	notificationrepository "github.com/kiaplayer/clean-architecture-example/internal/adapters/repositories/notification"
+	"github.com/kiaplayer/clean-architecture-example/internal/adapters/sinks/logger"
+	notificationservice "github.com/kiaplayer/clean-architecture-example/internal/domain/service/notification"
+	outboxservice "github.com/kiaplayer/clean-architecture-example/internal/domain/service/outbox"
+	webhookservice "github.com/kiaplayer/clean-architecture-example/internal/domain/service/webhook"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/change_sale_order_status"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/create_sale_order"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/get_sale_order"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/list_sale_orders"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/list_webhook_deliveries"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/middleware"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/replay_webhook_delivery"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/status"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/update_sale_order"
+	"github.com/kiaplayer/clean-architecture-example/pkg/mail"
+	"github.com/kiaplayer/clean-architecture-example/pkg/webhook"
+)
+
+// serve runs the HTTP service and the outbox and webhook dispatchers until an interrupt signal.
+func serve(a *app) error {
+	err := prepareSchema(a)
+	if err != nil {
+		return err
+	}
+
+	createSaleOrderHandler := create_sale_order.NewHandler(a.createSaleOrder)
+	updateSaleOrderHandler := update_sale_order.NewHandler(a.updateSaleOrder)
+	getSaleOrderHandler := get_sale_order.NewHandler(a.getSaleOrder)
+	listSaleOrdersHandler := list_sale_orders.NewHandler(a.listSaleOrders)
+	postSaleOrderHandler := change_sale_order_status.NewHandler(a.postSaleOrder)
+	unpostSaleOrderHandler := change_sale_order_status.NewHandler(a.unpostSaleOrder)
+	deleteSaleOrderHandler := change_sale_order_status.NewHandler(a.deleteSaleOrder)
+	listWebhookDeliveriesHandler := list_webhook_deliveries.NewHandler(a.listWebhookDeliveries)
+	replayWebhookDeliveryHandler := replay_webhook_delivery.NewHandler(a.replayWebhookDelivery)
+	authenticator := middleware.NewAuthenticator(a.userRepository)
+
+	srvMux := http.NewServeMux()
+	srvMux.HandleFunc("POST /sale-order", createSaleOrderHandler.Handle)
+	srvMux.HandleFunc("PUT /sale-order", updateSaleOrderHandler.Handle)
+	srvMux.HandleFunc("GET /sale-order", getSaleOrderHandler.Handle)
+	srvMux.HandleFunc("GET /sale-orders", listSaleOrdersHandler.Handle)
+	srvMux.HandleFunc("POST /sale-order/post", postSaleOrderHandler.Handle)
+	srvMux.HandleFunc("POST /sale-order/unpost", unpostSaleOrderHandler.Handle)
+	srvMux.HandleFunc("POST /sale-order/mark-for-deletion", deleteSaleOrderHandler.Handle)
+	srvMux.HandleFunc("GET /webhook-deliveries", listWebhookDeliveriesHandler.Handle)
+	srvMux.HandleFunc("POST /webhook-deliveries/replay", replayWebhookDeliveryHandler.Handle)
+
+	dispatcherConfig := outboxservice.DefaultDispatcherConfig
+	dispatcherConfig.PollInterval = a.config.OutboxPollInterval
+	sinks := []outboxservice.Sink{logger.NewSink(), a.webhookService}
+	if a.config.SMTP.Addr != "" {
+		notificationService, err := notificationservice.NewService(
+			a.customerRepository,
+			notificationrepository.NewRepository(a.db),
+			mail.NewClient(a.config.SMTP),
+			a.timeGenerator,
+			a.config.Notification,
+		)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, notificationService)
+	}
+	dispatcher := outboxservice.NewDispatcher(a.outboxRepository, a.timeGenerator, dispatcherConfig, sinks...)
+	webhookDispatcherConfig := webhookservice.DefaultDispatcherConfig
+	webhookDispatcherConfig.PollInterval = dispatcherConfig.PollInterval
+	webhookDispatcher := webhookservice.NewDispatcher(
+		a.webhookRepository,
+		webhook.NewClient(&http.Client{Timeout: 10 * time.Second}),
+		a.timeGenerator,
+		webhookDispatcherConfig,
+	)
+
+	dispatcherCtx, stopDispatchers := context.WithCancel(context.Background())
+	var dispatchers sync.WaitGroup
+	dispatchers.Add(2)
+	go func() {
+		defer dispatchers.Done()
+		dispatcher.Run(dispatcherCtx)
+	}()
+	go func() {
+		defer dispatchers.Done()
+		webhookDispatcher.Run(dispatcherCtx)
+	}()
+
+	rootMux := http.NewServeMux()
+	rootMux.HandleFunc("GET /status", status.NewHandler(a.migrator).Handle)
+	rootMux.Handle("/", authenticator.Authenticate(srvMux))
+
+	srv := http.Server{
+		Addr:    a.config.ServiceAddr,
+		Handler: rootMux,
+	}
+
+	idleConnsClosed := make(chan struct{})
+	go func() {
+		sigint := make(chan os.Signal, 1)
+		signal.Notify(sigint, os.Interrupt)
+		<-sigint
+
+		log.Println("Service shutting down...")
+
+		if err := srv.Shutdown(context.Background()); err != nil {
+			log.Printf("HTTP server Shutdown: %v", err)
+		}
+		stopDispatchers()
+		dispatchers.Wait()
+		close(idleConnsClosed)
+	}()
+
+	log.Printf("Service started at: %s", srv.Addr)
+
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		stopDispatchers()
+		dispatchers.Wait()
+		return err
+	}
+
+	<-idleConnsClosed
+
+	return nil
+}
+
+// prepareSchema applies pending migrations if MIGRATE_ON_START is set,
+// it refuses to run on a dirty schema or a schema behind the latest migration.
+func prepareSchema(a *app) error {
+	version, err := a.checkSchema()
+	if err != nil {
+		return err
+	}
+
+	if a.config.MigrateOnStart {
+		err = a.migrator.Up()
+		if err != nil {
+			return fmt.Errorf("cannot apply db migrations: %w", err)
+		}
+		version, err = a.checkSchema()
+		if err != nil {
+			return err
+		}
+	}
+
+	latestVersion, err := a.migrator.LatestVersion()
+	if err != nil {
+		return fmt.Errorf("cannot read db migrations: %w", err)
+	}
+	if version < latestVersion {
+		return fmt.Errorf(
+			"db schema version %d is behind the latest migration %d, run the migrate command or set MIGRATE_ON_START",
+			version,
+			latestVersion,
+		)
+	}
+	log.Printf("Db schema version: %d", version)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiaplayer/clean-architecture-example/pkg/generators"
+)
+
+func TestPrepareSchema(t *testing.T) {
+	tests := []struct {
+		name           string
+		migrateOnStart bool
+		expectedErr    string
+	}{
+		{name: "migrate on start", migrateOnStart: true},
+		{name: "schema is behind", migrateOnStart: false, expectedErr: "db schema version 0 is behind the latest migration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			a, appErr := newApp(config{
+				SQLiteDBFile:          filepath.Join(t.TempDir(), "sqlite.db"),
+				MigrateOnStart:        tt.migrateOnStart,
+				SaleOrderNumberFormat: generators.DefaultNumberFormat,
+			})
+			require.NoError(t, appErr)
+			defer func() {
+				_ = a.close()
+			}()
+
+			// act
+			err := prepareSchema(a)
+
+			// assert
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,3 @@
+ALTER TABLE sale_order DROP COLUMN customer_id;
+
+DROP TABLE IF EXISTS customer;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS customer
+(
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    status INTEGER NOT NULL DEFAULT 0
+);
+
+ALTER TABLE sale_order ADD COLUMN customer_id INTEGER REFERENCES customer(id);
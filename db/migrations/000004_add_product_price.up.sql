@@ -0,0 +1 @@
+ALTER TABLE product ADD COLUMN price DECIMAL NOT NULL DEFAULT 0;
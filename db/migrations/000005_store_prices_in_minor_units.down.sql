@@ -0,0 +1,12 @@
+ALTER TABLE sale_order_product ADD COLUMN price_decimal DECIMAL NOT NULL DEFAULT 0;
+UPDATE sale_order_product SET price_decimal = price / 100.0;
+ALTER TABLE sale_order_product DROP COLUMN price;
+ALTER TABLE sale_order_product RENAME COLUMN price_decimal TO price;
+
+ALTER TABLE sale_order DROP COLUMN currency;
+
+ALTER TABLE product DROP COLUMN currency;
+ALTER TABLE product ADD COLUMN price_decimal DECIMAL NOT NULL DEFAULT 0;
+UPDATE product SET price_decimal = price / 100.0;
+ALTER TABLE product DROP COLUMN price;
+ALTER TABLE product RENAME COLUMN price_decimal TO price;
@@ -0,0 +1,12 @@
+ALTER TABLE product ADD COLUMN price_minor INTEGER NOT NULL DEFAULT 0;
+UPDATE product SET price_minor = CAST(ROUND(price * 100) AS INTEGER);
+ALTER TABLE product DROP COLUMN price;
+ALTER TABLE product RENAME COLUMN price_minor TO price;
+ALTER TABLE product ADD COLUMN currency TEXT NOT NULL DEFAULT 'RUB';
+
+ALTER TABLE sale_order ADD COLUMN currency TEXT NOT NULL DEFAULT 'RUB';
+
+ALTER TABLE sale_order_product ADD COLUMN price_minor INTEGER NOT NULL DEFAULT 0;
+UPDATE sale_order_product SET price_minor = CAST(ROUND(price * 100) AS INTEGER);
+ALTER TABLE sale_order_product DROP COLUMN price;
+ALTER TABLE sale_order_product RENAME COLUMN price_minor TO price;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS document_counter
+(
+    document_type TEXT NOT NULL,
+    company_id INTEGER NOT NULL,
+    year INTEGER NOT NULL,
+    value INTEGER NOT NULL,
+    PRIMARY KEY (document_type, company_id, year)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_api_key;
+DROP TABLE IF EXISTS user;
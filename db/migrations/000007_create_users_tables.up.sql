@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS user
+(
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    status INTEGER NOT NULL DEFAULT 0,
+    role TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_api_key
+(
+    key_hash TEXT PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES user(id)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE sale_order DROP COLUMN updated_at;
+ALTER TABLE sale_order DROP COLUMN created_at;
+ALTER TABLE sale_order DROP COLUMN change_user_id;
+ALTER TABLE sale_order DROP COLUMN append_user_id;
@@ -0,0 +1,4 @@
+ALTER TABLE sale_order ADD COLUMN append_user_id INTEGER REFERENCES user(id);
+ALTER TABLE sale_order ADD COLUMN change_user_id INTEGER REFERENCES user(id);
+ALTER TABLE sale_order ADD COLUMN created_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE sale_order ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS sale_order_company_id_idx;
+ALTER TABLE sale_order DROP COLUMN company_id;
+ALTER TABLE user DROP COLUMN company_id;
+DROP TABLE IF EXISTS company;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS company
+(
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    status INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT INTO company (id, name) VALUES (1, 'Default company');
+
+ALTER TABLE user ADD COLUMN company_id INTEGER REFERENCES company(id);
+UPDATE user SET company_id = 1;
+
+ALTER TABLE sale_order ADD COLUMN company_id INTEGER REFERENCES company(id);
+UPDATE sale_order SET company_id = 1;
+
+CREATE INDEX IF NOT EXISTS sale_order_company_id_idx ON sale_order (company_id);
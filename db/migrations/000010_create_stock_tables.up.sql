@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS stock
+(
+    company_id INTEGER NOT NULL REFERENCES company(id),
+    product_id INTEGER NOT NULL REFERENCES product(id),
+    quantity INTEGER NOT NULL DEFAULT 0,
+    reserved INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (company_id, product_id),
+    CHECK (reserved >= 0 AND reserved <= quantity)
+);
+
+CREATE TABLE IF NOT EXISTS stock_reservation
+(
+    sale_order_id INTEGER NOT NULL REFERENCES sale_order(id),
+    product_id INTEGER NOT NULL REFERENCES product(id),
+    quantity INTEGER NOT NULL,
+    PRIMARY KEY (sale_order_id, product_id)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS idempotency_key
+(
+    user_id INTEGER NOT NULL REFERENCES user(id),
+    key TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    response_status INTEGER NOT NULL,
+    response_body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
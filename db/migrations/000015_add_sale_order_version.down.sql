@@ -0,0 +1 @@
+ALTER TABLE sale_order DROP COLUMN version;
@@ -0,0 +1 @@
+ALTER TABLE sale_order ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,23 @@
+CREATE TABLE sale_order_new
+(
+    id INTEGER PRIMARY KEY,
+    number TEXT UNIQUE NOT NULL,
+    date TIMESTAMP WITH TIME ZONE NOT NULL,
+    status INTEGER NOT NULL DEFAULT 0,
+    customer_id INTEGER REFERENCES customer(id),
+    currency TEXT NOT NULL DEFAULT 'RUB',
+    append_user_id INTEGER REFERENCES user(id),
+    change_user_id INTEGER REFERENCES user(id),
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    company_id INTEGER REFERENCES company(id),
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+INSERT INTO sale_order_new (id, number, date, status, customer_id, currency, append_user_id, change_user_id, created_at, updated_at, company_id, version)
+SELECT id, number, date, status, customer_id, currency, append_user_id, change_user_id, created_at, updated_at, company_id, version FROM sale_order;
+
+DROP TABLE sale_order;
+ALTER TABLE sale_order_new RENAME TO sale_order;
+
+CREATE INDEX IF NOT EXISTS sale_order_company_id_idx ON sale_order (company_id);
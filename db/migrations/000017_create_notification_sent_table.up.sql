@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS notification_sent
+(
+    event_id INTEGER NOT NULL REFERENCES outbox_event(id),
+    recipient TEXT NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (event_id, recipient)
+);
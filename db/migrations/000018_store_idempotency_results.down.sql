@@ -0,0 +1,6 @@
+ALTER TABLE idempotency_key ADD COLUMN response_status INTEGER NOT NULL DEFAULT 200;
+
+UPDATE idempotency_key
+SET result = 'SaleOrder ID = ' || json_extract(result, '$.id');
+
+ALTER TABLE idempotency_key RENAME COLUMN result TO response_body;
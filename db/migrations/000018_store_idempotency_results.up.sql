@@ -0,0 +1,7 @@
+ALTER TABLE idempotency_key RENAME COLUMN response_body TO result;
+
+UPDATE idempotency_key
+SET result = '{"id":' || substr(result, length('SaleOrder ID = ') + 1) || '}'
+WHERE result LIKE 'SaleOrder ID = %';
+
+ALTER TABLE idempotency_key DROP COLUMN response_status;
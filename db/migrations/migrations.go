@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS holds the migrations, so the service binary applies them without the source tree.
+//
+//go:embed *.sql
+var FS embed.FS
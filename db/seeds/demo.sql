@@ -0,0 +1,16 @@
+INSERT INTO product (id, name, price, currency) VALUES
+    (1, 'Notebook', 35000, 'RUB'),
+    (2, 'Pen', 5000, 'RUB'),
+    (3, 'Backpack', 249000, 'RUB')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO customer (id, name, email) VALUES
+    (1, 'Demo customer', 'customer@example.com'),
+    (2, 'Demo wholesale customer', NULL)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO stock (company_id, product_id, quantity) VALUES
+    (1, 1, 100),
+    (1, 2, 1000),
+    (1, 3, 10)
+ON CONFLICT (company_id, product_id) DO NOTHING;
@@ -0,0 +1,8 @@
+package seeds
+
+import "embed"
+
+// FS holds SQL files with demo data, they can be applied repeatedly.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,56 @@
+package counter
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+type Repository struct {
+	*db.TransactionalRepository
+}
+
+func NewRepository(qe db.QueryExecutor) *Repository {
+	return &Repository{
+		TransactionalRepository: db.NewTransactionalRepository(qe),
+	}
+}
+
+// Next increments the counter and returns its new value.
+// It must be called in the transaction that saves the document, so a rollback leaves no gaps.
+func (r *Repository) Next(ctx context.Context, documentType string, companyID uint64, year int) (uint64, error) {
+	queryResult, err := r.DB(ctx).QueryContext(
+		ctx,
+		`
+			INSERT INTO document_counter (document_type, company_id, year, value) VALUES (?, ?, ?, 1)
+			ON CONFLICT (document_type, company_id, year) DO UPDATE SET value = value + 1
+			RETURNING value
+		`,
+		documentType,
+		companyID,
+		year,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func(queryResult *sql.Rows) {
+		_ = queryResult.Close()
+	}(queryResult)
+
+	if !queryResult.Next() {
+		if queryResult.Err() != nil {
+			return 0, queryResult.Err()
+		}
+		return 0, sql.ErrNoRows
+	}
+
+	var value uint64
+	err = queryResult.Scan(&value)
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
@@ -0,0 +1,110 @@
+//go:build integration
+
+package counter
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+)
+
+const testDBFilePath = "sqlite_test.db"
+
+type TestRepositorySuite struct {
+	suite.Suite
+	db *sql.DB
+}
+
+func TestRepositoryByTestSuite(t *testing.T) {
+	suite.Run(t, new(TestRepositorySuite))
+}
+
+func (rts *TestRepositorySuite) SetupSuite() {
+	_ = os.Remove(testDBFilePath)
+
+	dbConn, err := sql.Open("sqlite3", testDBFilePath)
+	if err != nil {
+		rts.Failf("cannot open db connection before tests: %s", err.Error())
+	}
+
+	driver, err := sqlite3.WithInstance(dbConn, &sqlite3.Config{})
+	if err != nil {
+		rts.Failf("cannot init db driver before tests: %s", err.Error())
+	}
+
+	m, err := migrate.NewWithDatabaseInstance(
+		"file://./../../../../../db/migrations",
+		"sqlite3",
+		driver,
+	)
+	if err != nil {
+		rts.Failf("cannot init db migrator before tests: %s", err.Error())
+	}
+
+	err = m.Up()
+	if err != nil {
+		rts.Failf("cannot apply db migrations before tests: %s", err.Error())
+	}
+
+	rts.db = dbConn
+}
+
+func (rts *TestRepositorySuite) TearDownSuite() {
+	err := rts.db.Close()
+	if err != nil {
+		rts.Failf("tear down suite: %s", err.Error())
+	}
+	_ = os.Remove(testDBFilePath)
+}
+
+func (rts *TestRepositorySuite) TestNext_Sequence() {
+	// arrange
+	ctx := context.Background()
+
+	repository := NewRepository(rts.db)
+
+	// act
+	first, firstErr := repository.Next(ctx, "sequence", 1, 2026)
+	second, secondErr := repository.Next(ctx, "sequence", 1, 2026)
+	otherCompany, otherCompanyErr := repository.Next(ctx, "sequence", 2, 2026)
+	otherYear, otherYearErr := repository.Next(ctx, "sequence", 1, 2027)
+
+	// assert
+	rts.NoError(firstErr)
+	rts.NoError(secondErr)
+	rts.NoError(otherCompanyErr)
+	rts.NoError(otherYearErr)
+	rts.Equal(uint64(1), first)
+	rts.Equal(uint64(2), second)
+	rts.Equal(uint64(1), otherCompany)
+	rts.Equal(uint64(1), otherYear)
+}
+
+func (rts *TestRepositorySuite) TestNext_RollbackLeavesNoGap() {
+	// arrange
+	ctx := context.Background()
+
+	repository := NewRepository(rts.db)
+
+	_, err := repository.Next(ctx, "rollback", 1, 2026)
+	rts.NoError(err)
+
+	tx, _ := rts.db.BeginTx(ctx, nil)
+	_, err = NewRepository(tx).Next(ctx, "rollback", 1, 2026)
+	rts.NoError(err)
+	rts.NoError(tx.Rollback())
+
+	// act
+	actual, err := repository.Next(ctx, "rollback", 1, 2026)
+
+	// assert
+	rts.NoError(err)
+	rts.Equal(uint64(2), actual)
+}
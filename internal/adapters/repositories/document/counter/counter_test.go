@@ -0,0 +1,76 @@
+package counter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNext_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	mock.
+		ExpectQuery("INSERT INTO document_counter (.+) ON CONFLICT (.+) DO UPDATE SET value = value \\+ 1 RETURNING value").
+		WithArgs("sale_order", uint64(1), 2026).
+		WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow(123))
+
+	// act
+	actual, err := repository.Next(ctx, "sale_order", 1, 2026)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(123), actual)
+}
+
+func TestNext_NoRows(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	mock.
+		ExpectQuery("INSERT INTO document_counter").
+		WithArgs("sale_order", uint64(1), 2026).
+		WillReturnRows(sqlmock.NewRows([]string{"value"}))
+
+	// act
+	actual, err := repository.Next(ctx, "sale_order", 1, 2026)
+
+	// assert
+	assert.Zero(t, actual)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestNext_Error(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	queryErr := errors.New("query error")
+
+	mock.
+		ExpectQuery("INSERT INTO document_counter").
+		WithArgs("sale_order", uint64(1), 2026).
+		WillReturnError(queryErr)
+
+	// act
+	actual, err := repository.Next(ctx, "sale_order", 1, 2026)
+
+	// assert
+	assert.Zero(t, actual)
+	assert.ErrorIs(t, err, queryErr)
+}
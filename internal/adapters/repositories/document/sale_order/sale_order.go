@@ -5,13 +5,52 @@ import (
 	"database/sql"
 	"fmt"
 	"slices"
+	"strings"
+	"time"
 
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
 	"github.com/kiaplayer/clean-architecture-example/pkg/helpers"
 	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
 )
 
+const selectSaleOrderQuery = `
+	SELECT
+		so.id,
+		so.date,
+		so.number,
+		so.status,
+		so.currency,
+		so.customer_id,
+		c.name,
+		c.status,
+		so.append_user_id,
+		au.name,
+		so.change_user_id,
+		cu.name,
+		so.created_at,
+		so.updated_at,
+		so.company_id,
+		co.name,
+		co.status,
+		so.version
+	FROM sale_order AS so
+	LEFT JOIN customer AS c ON c.id = so.customer_id
+	LEFT JOIN company AS co ON co.id = so.company_id
+	LEFT JOIN user AS au ON au.id = so.append_user_id
+	LEFT JOIN user AS cu ON cu.id = so.change_user_id
+`
+
+var sortColumns = map[document.SaleOrderSortField]string{
+	document.SaleOrderSortByDate:   "so.date",
+	document.SaleOrderSortByNumber: "so.number",
+}
+
+var likePrefixReplacer = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type Repository struct {
 	*db.TransactionalRepository
 }
@@ -23,12 +62,32 @@ func NewRepository(qe db.QueryExecutor) *Repository {
 }
 
 func (r *Repository) CreateOrder(ctx context.Context, order *document.SaleOrder) (*document.SaleOrder, error) {
+	companyID, err := access.CompanyID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if order.Company.ID != companyID {
+		return nil, fmt.Errorf("sale order company %d differs from user company %d", order.Company.ID, companyID)
+	}
+
 	insertResult, err := r.DB(ctx).ExecContext(
 		ctx,
-		"INSERT INTO sale_order (number, date, status) VALUES (?, ?, ?)",
+		`
+			INSERT INTO sale_order (
+				number, date, status, currency, customer_id, append_user_id, change_user_id, created_at, updated_at, company_id
+			)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`,
 		order.Number,
 		helpers.TimeToString(order.Date),
 		order.Status,
+		order.Currency,
+		order.Customer.ID,
+		userID(order.AppendUser),
+		userID(order.ChangeUser),
+		nullTime(order.CreatedAt),
+		nullTime(order.UpdatedAt),
+		companyID,
 	)
 	if err != nil {
 		return nil, err
@@ -39,43 +98,127 @@ func (r *Repository) CreateOrder(ctx context.Context, order *document.SaleOrder)
 		return nil, err
 	}
 	order.ID = uint64(lastID)
+	order.Version = 1
 
 	for i, product := range order.Products {
-		insertResult, err := r.DB(ctx).ExecContext(
+		order.Products[i].ID, err = r.insertProduct(ctx, order.ID, product)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// UpdateOrder saves the order if it still has the version the order was read with and increments the version.
+// ErrVersionConflict is returned when the order has been changed since then.
+func (r *Repository) UpdateOrder(ctx context.Context, order *document.SaleOrder) (*document.SaleOrder, error) {
+	companyID, err := access.CompanyID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updateResult, err := r.DB(ctx).ExecContext(
+		ctx,
+		`
+			UPDATE sale_order
+			SET currency = ?, customer_id = ?, change_user_id = ?, updated_at = ?, version = version + 1
+			WHERE id = ? AND company_id = ? AND version = ?
+		`,
+		order.Currency,
+		order.Customer.ID,
+		userID(order.ChangeUser),
+		nullTime(order.UpdatedAt),
+		order.ID,
+		companyID,
+		order.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkVersionUpdated(updateResult, order.ID, order.Version)
+	if err != nil {
+		return nil, err
+	}
+	order.Version++
+
+	savedLineIDs, err := r.getProductIDs(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	lineIDs := make([]uint64, 0, len(order.Products))
+	for _, product := range order.Products {
+		lineIDs = append(lineIDs, product.ID)
+	}
+
+	for _, savedLineID := range savedLineIDs {
+		if slices.Contains(lineIDs, savedLineID) {
+			continue
+		}
+		_, err = r.DB(ctx).ExecContext(
 			ctx,
-			"INSERT INTO sale_order_product (parent_id, product_id, quantity, price) VALUES (?, ?, ?, ?)",
+			"DELETE FROM sale_order_product WHERE id = ? AND parent_id = ?",
+			savedLineID,
 			order.ID,
-			product.Product.ID,
-			product.Quantity,
-			product.Price,
 		)
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		lastID, err := insertResult.LastInsertId()
+	for i, product := range order.Products {
+		if product.ID == 0 {
+			order.Products[i].ID, err = r.insertProduct(ctx, order.ID, product)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		_, err = r.DB(ctx).ExecContext(
+			ctx,
+			"UPDATE sale_order_product SET product_id = ?, quantity = ?, price = ? WHERE id = ? AND parent_id = ?",
+			product.Product.ID,
+			product.Quantity,
+			product.Price.Amount,
+			product.ID,
+			order.ID,
+		)
 		if err != nil {
 			return nil, err
 		}
-
-		order.Products[i].ID = uint64(lastID)
 	}
 
 	return order, nil
 }
 
-func (r *Repository) GetByID(ctx context.Context, id uint64) (*document.SaleOrder, error) {
-	saleOrderDTO := struct {
-		ID     uint64
-		Number string
-		Date   string
-		Status int
-	}{}
+func (r *Repository) insertProduct(ctx context.Context, orderID uint64, product document.SaleOrderProduct) (uint64, error) {
+	insertResult, err := r.DB(ctx).ExecContext(
+		ctx,
+		"INSERT INTO sale_order_product (parent_id, product_id, quantity, price) VALUES (?, ?, ?, ?)",
+		orderID,
+		product.Product.ID,
+		product.Quantity,
+		product.Price.Amount,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	lastID, err := insertResult.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(lastID), nil
+}
 
+func (r *Repository) getProductIDs(ctx context.Context, orderID uint64) ([]uint64, error) {
 	queryResult, err := r.DB(ctx).QueryContext(
 		ctx,
-		"SELECT id, date, number, status FROM sale_order WHERE id = ?",
-		id,
+		"SELECT id FROM sale_order_product WHERE parent_id = ?",
+		orderID,
 	)
 	if err != nil {
 		return nil, err
@@ -85,35 +228,46 @@ func (r *Repository) GetByID(ctx context.Context, id uint64) (*document.SaleOrde
 		_ = queryResult.Close()
 	}(queryResult)
 
-	if queryResult.Next() {
-		err = queryResult.Scan(&saleOrderDTO.ID, &saleOrderDTO.Date, &saleOrderDTO.Number, &saleOrderDTO.Status)
+	var ids []uint64
+	for queryResult.Next() {
+		var id uint64
+		err = queryResult.Scan(&id)
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		if queryResult.Err() != nil {
-			return nil, queryResult.Err()
-		}
-		return nil, nil
+		ids = append(ids, id)
 	}
 
-	date, err := helpers.StringToTime(saleOrderDTO.Date)
+	return ids, queryResult.Err()
+}
+
+func (r *Repository) GetByID(ctx context.Context, id uint64) (*document.SaleOrder, error) {
+	companyID, err := access.CompanyID(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("bad date: %s", saleOrderDTO.Date)
+		return nil, err
 	}
 
-	status := document.Status(saleOrderDTO.Status)
-	if !slices.Contains(document.ValidStatuses, status) {
-		return nil, fmt.Errorf("bad status: %d", status)
+	queryResult, err := r.DB(ctx).QueryContext(
+		ctx,
+		selectSaleOrderQuery+"WHERE so.id = ? AND so.company_id = ?",
+		id,
+		companyID,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	result := &document.SaleOrder{
-		Document: document.Document{
-			ID:     saleOrderDTO.ID,
-			Number: saleOrderDTO.Number,
-			Date:   date,
-			Status: status,
-		},
+	defer func(queryResult *sql.Rows) {
+		_ = queryResult.Close()
+	}(queryResult)
+
+	if !queryResult.Next() {
+		return nil, queryResult.Err()
+	}
+
+	result, err := scanSaleOrder(queryResult)
+	if err != nil {
+		return nil, err
 	}
 
 	saleOrderProductDTO := struct {
@@ -122,7 +276,7 @@ func (r *Repository) GetByID(ctx context.Context, id uint64) (*document.SaleOrde
 		ProductName   string
 		ProductStatus int
 		Quantity      int
-		Price         float32
+		Price         int64
 	}{}
 
 	queryResult, err = r.DB(ctx).QueryContext(
@@ -177,7 +331,7 @@ func (r *Repository) GetByID(ctx context.Context, id uint64) (*document.SaleOrde
 				},
 			},
 			Quantity: saleOrderProductDTO.Quantity,
-			Price:    saleOrderProductDTO.Price,
+			Price:    money.New(saleOrderProductDTO.Price, result.Currency),
 		})
 	}
 
@@ -187,3 +341,266 @@ func (r *Repository) GetByID(ctx context.Context, id uint64) (*document.SaleOrde
 
 	return result, nil
 }
+
+// UpdateStatus changes the status and change_user/updated_at of the order if it still has the version
+// and increments the version. ErrVersionConflict is returned when the order has been changed since it was read.
+func (r *Repository) UpdateStatus(ctx context.Context, order *document.SaleOrder, status document.Status) error {
+	companyID, err := access.CompanyID(ctx)
+	if err != nil {
+		return err
+	}
+
+	updateResult, err := r.DB(ctx).ExecContext(
+		ctx,
+		`
+			UPDATE sale_order
+			SET status = ?, change_user_id = ?, updated_at = ?, version = version + 1
+			WHERE id = ? AND company_id = ? AND version = ?
+		`,
+		status,
+		userID(order.ChangeUser),
+		nullTime(order.UpdatedAt),
+		order.ID,
+		companyID,
+		order.Version,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkVersionUpdated(updateResult, order.ID, order.Version)
+}
+
+// checkVersionUpdated returns ErrVersionConflict when the conditional update has not found the order version.
+func checkVersionUpdated(updateResult sql.Result, id uint64, version uint64) error {
+	affected, err := updateResult.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domainerrors.NewErrVersionConflict(fmt.Sprintf("sale order %d version %d is outdated", id, version), nil)
+	}
+	return nil
+}
+
+func (r *Repository) List(
+	ctx context.Context,
+	filter document.SaleOrderFilter,
+	sort document.SaleOrderSort,
+	after *document.SaleOrderListCursor,
+	limit int,
+) ([]*document.SaleOrder, error) {
+	sortColumn, ok := sortColumns[sort.Field]
+	if !ok {
+		return nil, fmt.Errorf("bad sort field: %s", sort.Field)
+	}
+
+	companyID, err := access.CompanyID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := []string{"so.company_id = ?"}
+	args := []any{companyID}
+
+	if !filter.DateFrom.IsZero() {
+		conditions = append(conditions, "so.date >= ?")
+		args = append(args, helpers.TimeToString(filter.DateFrom))
+	}
+	if !filter.DateTo.IsZero() {
+		conditions = append(conditions, "so.date <= ?")
+		args = append(args, helpers.TimeToString(filter.DateTo))
+	}
+	if filter.Status != nil {
+		conditions = append(conditions, "so.status = ?")
+		args = append(args, *filter.Status)
+	}
+	if filter.CustomerID != 0 {
+		conditions = append(conditions, "so.customer_id = ?")
+		args = append(args, filter.CustomerID)
+	}
+	if filter.NumberPrefix != "" {
+		conditions = append(conditions, `so.number LIKE ? ESCAPE '\'`)
+		args = append(args, likePrefixReplacer.Replace(filter.NumberPrefix)+"%")
+	}
+
+	direction, comparison := "ASC", ">"
+	if sort.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if after != nil {
+		conditions = append(conditions, fmt.Sprintf("(%s, so.id) %s (?, ?)", sortColumn, comparison))
+		if sort.Field == document.SaleOrderSortByNumber {
+			args = append(args, after.Number, after.ID)
+		} else {
+			args = append(args, helpers.TimeToString(after.Date), after.ID)
+		}
+	}
+
+	query := selectSaleOrderQuery + "WHERE " + strings.Join(conditions, " AND ")
+	query += fmt.Sprintf(" ORDER BY %s %s, so.id %s LIMIT ?", sortColumn, direction, direction)
+	args = append(args, limit)
+
+	queryResult, err := r.DB(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(queryResult *sql.Rows) {
+		_ = queryResult.Close()
+	}(queryResult)
+
+	result := make([]*document.SaleOrder, 0, limit)
+	for queryResult.Next() {
+		order, err := scanSaleOrder(queryResult)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, order)
+	}
+
+	if queryResult.Err() != nil {
+		return nil, queryResult.Err()
+	}
+
+	return result, nil
+}
+
+func scanSaleOrder(queryResult *sql.Rows) (*document.SaleOrder, error) {
+	saleOrderDTO := struct {
+		ID             uint64
+		Number         string
+		Date           string
+		Status         int
+		Currency       string
+		CustomerID     sql.NullInt64
+		CustomerName   sql.NullString
+		CustomerStatus sql.NullInt64
+		AppendUserID   sql.NullInt64
+		AppendUserName sql.NullString
+		ChangeUserID   sql.NullInt64
+		ChangeUserName sql.NullString
+		CreatedAt      sql.NullString
+		UpdatedAt      sql.NullString
+		CompanyID      sql.NullInt64
+		CompanyName    sql.NullString
+		CompanyStatus  sql.NullInt64
+		Version        uint64
+	}{}
+
+	err := queryResult.Scan(
+		&saleOrderDTO.ID,
+		&saleOrderDTO.Date,
+		&saleOrderDTO.Number,
+		&saleOrderDTO.Status,
+		&saleOrderDTO.Currency,
+		&saleOrderDTO.CustomerID,
+		&saleOrderDTO.CustomerName,
+		&saleOrderDTO.CustomerStatus,
+		&saleOrderDTO.AppendUserID,
+		&saleOrderDTO.AppendUserName,
+		&saleOrderDTO.ChangeUserID,
+		&saleOrderDTO.ChangeUserName,
+		&saleOrderDTO.CreatedAt,
+		&saleOrderDTO.UpdatedAt,
+		&saleOrderDTO.CompanyID,
+		&saleOrderDTO.CompanyName,
+		&saleOrderDTO.CompanyStatus,
+		&saleOrderDTO.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := helpers.StringToTime(saleOrderDTO.Date)
+	if err != nil {
+		return nil, fmt.Errorf("bad date: %s", saleOrderDTO.Date)
+	}
+
+	status := document.Status(saleOrderDTO.Status)
+	if !slices.Contains(document.ValidStatuses, status) {
+		return nil, fmt.Errorf("bad status: %d", status)
+	}
+
+	customerStatus := reference.Status(saleOrderDTO.CustomerStatus.Int64)
+	if !slices.Contains(reference.ValidStatuses, customerStatus) {
+		return nil, fmt.Errorf("bad customer status: %d", customerStatus)
+	}
+
+	companyStatus := reference.Status(saleOrderDTO.CompanyStatus.Int64)
+	if !slices.Contains(reference.ValidStatuses, companyStatus) {
+		return nil, fmt.Errorf("bad company status: %d", companyStatus)
+	}
+
+	var createdAt, updatedAt time.Time
+	if saleOrderDTO.CreatedAt.Valid {
+		createdAt, err = helpers.StringToTime(saleOrderDTO.CreatedAt.String)
+		if err != nil {
+			return nil, fmt.Errorf("bad created at: %s", saleOrderDTO.CreatedAt.String)
+		}
+	}
+	if saleOrderDTO.UpdatedAt.Valid {
+		updatedAt, err = helpers.StringToTime(saleOrderDTO.UpdatedAt.String)
+		if err != nil {
+			return nil, fmt.Errorf("bad updated at: %s", saleOrderDTO.UpdatedAt.String)
+		}
+	}
+
+	return &document.SaleOrder{
+		Document: document.Document{
+			ID:     saleOrderDTO.ID,
+			Number: saleOrderDTO.Number,
+			Date:   date,
+			Status: status,
+			Company: reference.Company{
+				Reference: reference.Reference{
+					ID:     uint64(saleOrderDTO.CompanyID.Int64),
+					Name:   saleOrderDTO.CompanyName.String,
+					Status: companyStatus,
+				},
+			},
+			AppendUser: scanUser(saleOrderDTO.AppendUserID, saleOrderDTO.AppendUserName),
+			ChangeUser: scanUser(saleOrderDTO.ChangeUserID, saleOrderDTO.ChangeUserName),
+			CreatedAt:  createdAt,
+			UpdatedAt:  updatedAt,
+			Version:    saleOrderDTO.Version,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID:     uint64(saleOrderDTO.CustomerID.Int64),
+				Name:   saleOrderDTO.CustomerName.String,
+				Status: customerStatus,
+			},
+		},
+		Currency: money.Currency(saleOrderDTO.Currency),
+	}, nil
+}
+
+// userID returns the user id for a nullable column.
+func userID(user *reference.User) any {
+	if user == nil {
+		return nil
+	}
+	return user.ID
+}
+
+// nullTime returns the time for a nullable column.
+func nullTime(value time.Time) any {
+	if value.IsZero() {
+		return nil
+	}
+	return helpers.TimeToString(value)
+}
+
+func scanUser(id sql.NullInt64, name sql.NullString) *reference.User {
+	if !id.Valid {
+		return nil
+	}
+	return &reference.User{
+		Reference: reference.Reference{
+			ID:   uint64(id.Int64),
+			Name: name.String,
+		},
+	}
+}
@@ -15,7 +15,11 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
 )
 
 const testDBFilePath = "sqlite_test.db"
@@ -69,7 +73,7 @@ func (rts *TestRepositorySuite) TearDownSuite() {
 
 func (rts *TestRepositorySuite) TestCreateOrder_Success() {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	tx, _ := rts.db.BeginTx(ctx, nil)
 	defer func(tx *sql.Tx) {
@@ -80,9 +84,10 @@ func (rts *TestRepositorySuite) TestCreateOrder_Success() {
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
-			Number: "1",
-			Date:   time.Now(),
-			Status: document.StatusDraft,
+			Number:  "1",
+			Date:    time.Now(),
+			Status:  document.StatusDraft,
+			Company: testUser.Company,
 		},
 	}
 
@@ -97,7 +102,7 @@ func (rts *TestRepositorySuite) TestCreateOrder_Success() {
 
 func (rts *TestRepositorySuite) TestCreateOrder_InsertError() {
 	// arrange
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(access.WithUser(context.Background(), testUser))
 
 	tx, _ := rts.db.BeginTx(ctx, nil)
 	defer func(tx *sql.Tx) {
@@ -108,9 +113,10 @@ func (rts *TestRepositorySuite) TestCreateOrder_InsertError() {
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
-			Number: "2",
-			Date:   time.Now(),
-			Status: document.StatusDraft,
+			Number:  "2",
+			Date:    time.Now(),
+			Status:  document.StatusDraft,
+			Company: testUser.Company,
 		},
 	}
 
@@ -125,7 +131,7 @@ func (rts *TestRepositorySuite) TestCreateOrder_InsertError() {
 
 func (rts *TestRepositorySuite) TestGetByID_Success() {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	tx, _ := rts.db.BeginTx(ctx, nil)
 	defer func(tx *sql.Tx) {
@@ -134,16 +140,25 @@ func (rts *TestRepositorySuite) TestGetByID_Success() {
 
 	repository := NewRepository(tx)
 
+	_, err := tx.ExecContext(ctx, "INSERT INTO customer (id, name) VALUES (?, ?)", 1, "Customer")
+	rts.NoError(err)
+
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
-			Number: "3",
-			Date:   time.Now(),
-			Status: document.StatusDraft,
+			Number:  "3",
+			Date:    time.Now(),
+			Status:  document.StatusDraft,
+			Company: testUser.Company,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID: 1,
+			},
 		},
 	}
 
 	// act & assert
-	saleOrder, err := repository.CreateOrder(ctx, saleOrder)
+	saleOrder, err = repository.CreateOrder(ctx, saleOrder)
 	rts.NoError(err)
 	rts.NotNil(saleOrder)
 	rts.NotEqual(0, saleOrder.ID)
@@ -151,11 +166,12 @@ func (rts *TestRepositorySuite) TestGetByID_Success() {
 	saleOrder, err = repository.GetByID(ctx, saleOrder.ID)
 	rts.NoError(err)
 	rts.NotNil(saleOrder)
+	rts.Equal("Customer", saleOrder.Customer.Name)
 }
 
 func (rts *TestRepositorySuite) TestGetByID_NotFound() {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	repository := NewRepository(rts.db)
 
@@ -169,7 +185,7 @@ func (rts *TestRepositorySuite) TestGetByID_NotFound() {
 
 func (rts *TestRepositorySuite) TestGetByID_BadStatusError() {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	tx, _ := rts.db.BeginTx(ctx, nil)
 	defer func(tx *sql.Tx) {
@@ -180,9 +196,10 @@ func (rts *TestRepositorySuite) TestGetByID_BadStatusError() {
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
-			Number: "4",
-			Date:   time.Now(),
-			Status: -999,
+			Number:  "4",
+			Date:    time.Now(),
+			Status:  -999,
+			Company: testUser.Company,
 		},
 	}
 
@@ -199,15 +216,16 @@ func (rts *TestRepositorySuite) TestGetByID_BadStatusError() {
 
 func (rts *TestRepositorySuite) TestGetByID_QueryError() {
 	// arrange
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(access.WithUser(context.Background(), testUser))
 
 	repository := NewRepository(rts.db)
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
-			Number: "5",
-			Date:   time.Now(),
-			Status: document.StatusDraft,
+			Number:  "5",
+			Date:    time.Now(),
+			Status:  document.StatusDraft,
+			Company: testUser.Company,
 		},
 	}
 
@@ -219,3 +237,378 @@ func (rts *TestRepositorySuite) TestGetByID_QueryError() {
 	rts.ErrorContains(err, "context canceled")
 	rts.Nil(actual)
 }
+
+func (rts *TestRepositorySuite) TestUpdateOrder_Success() {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	tx, _ := rts.db.BeginTx(ctx, nil)
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	repository := NewRepository(tx)
+
+	_, err := tx.ExecContext(ctx, "INSERT INTO product (id, name) VALUES (1, 'Keyboard'), (2, 'Mouse'), (3, 'Monitor')")
+	rts.NoError(err)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			Number:  "6",
+			Date:    time.Now(),
+			Status:  document.StatusDraft,
+			Company: testUser.Company,
+		},
+		Currency: money.DefaultCurrency,
+		Products: []document.SaleOrderProduct{
+			{Product: reference.Product{Reference: reference.Reference{ID: 1}}, Quantity: 1},
+			{Product: reference.Product{Reference: reference.Reference{ID: 2}}, Quantity: 1},
+		},
+	}
+
+	saleOrder, err = repository.CreateOrder(ctx, saleOrder)
+	rts.NoError(err)
+
+	saleOrder.Products = []document.SaleOrderProduct{
+		{ID: saleOrder.Products[0].ID, Product: reference.Product{Reference: reference.Reference{ID: 1}}, Quantity: 5},
+		{Product: reference.Product{Reference: reference.Reference{ID: 3}}, Quantity: 2},
+	}
+
+	// act
+	_, err = repository.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	rts.NoError(err)
+
+	actual, err := repository.GetByID(ctx, saleOrder.ID)
+	rts.NoError(err)
+	rts.Len(actual.Products, 2)
+	rts.Equal("Keyboard", actual.Products[0].Product.Name)
+	rts.Equal(5, actual.Products[0].Quantity)
+	rts.Equal("Monitor", actual.Products[1].Product.Name)
+	rts.Equal(2, actual.Products[1].Quantity)
+}
+
+func (rts *TestRepositorySuite) TestVersion() {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	tx, _ := rts.db.BeginTx(ctx, nil)
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	repository := NewRepository(tx)
+
+	_, err := tx.ExecContext(ctx, "INSERT INTO product (id, name) VALUES (1, 'Keyboard')")
+	rts.NoError(err)
+
+	saleOrder, err := repository.CreateOrder(ctx, &document.SaleOrder{
+		Document: document.Document{
+			Number:  "V-1",
+			Date:    time.Now(),
+			Status:  document.StatusDraft,
+			Company: testUser.Company,
+		},
+		Currency: money.DefaultCurrency,
+		Products: []document.SaleOrderProduct{
+			{Product: reference.Product{Reference: reference.Reference{ID: 1}}, Quantity: 1},
+		},
+	})
+	rts.NoError(err)
+	rts.Equal(uint64(1), saleOrder.Version)
+
+	staleSaleOrder := *saleOrder
+
+	// act & assert
+	saleOrder, err = repository.UpdateOrder(ctx, saleOrder)
+	rts.NoError(err)
+	rts.Equal(uint64(2), saleOrder.Version)
+
+	_, err = repository.UpdateOrder(ctx, &staleSaleOrder)
+	var errVersionConflict *domainerrors.ErrVersionConflict
+	rts.ErrorAs(err, &errVersionConflict)
+
+	err = repository.UpdateStatus(ctx, &staleSaleOrder, document.StatusPosted)
+	rts.ErrorAs(err, &errVersionConflict)
+
+	err = repository.UpdateStatus(ctx, saleOrder, document.StatusPosted)
+	rts.NoError(err)
+
+	actual, err := repository.GetByID(ctx, saleOrder.ID)
+	rts.NoError(err)
+	rts.Equal(document.StatusPosted, actual.Status)
+	rts.Equal(uint64(3), actual.Version)
+}
+
+func (rts *TestRepositorySuite) TestGetByID_AuditFields() {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	tx, _ := rts.db.BeginTx(ctx, nil)
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	repository := NewRepository(tx)
+
+	_, err := tx.ExecContext(ctx, "INSERT INTO user (id, name, role) VALUES (1, 'Agent', 'sales_agent'), (2, 'Manager', 'manager')")
+	rts.NoError(err)
+
+	createdAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	saleOrder, err := repository.CreateOrder(ctx, &document.SaleOrder{
+		Document: document.Document{
+			Number:     "8",
+			Date:       createdAt,
+			Status:     document.StatusDraft,
+			Company:    testUser.Company,
+			AppendUser: &reference.User{Reference: reference.Reference{ID: 1}},
+			ChangeUser: &reference.User{Reference: reference.Reference{ID: 1}},
+			CreatedAt:  createdAt,
+			UpdatedAt:  createdAt,
+		},
+	})
+	rts.NoError(err)
+
+	updatedAt := time.Now().Truncate(time.Second)
+	saleOrder.ChangeUser = &reference.User{Reference: reference.Reference{ID: 2}}
+	saleOrder.UpdatedAt = updatedAt
+	_, err = repository.UpdateOrder(ctx, saleOrder)
+	rts.NoError(err)
+
+	// act
+	actual, err := repository.GetByID(ctx, saleOrder.ID)
+
+	// assert
+	rts.NoError(err)
+	rts.Equal("Agent", actual.AppendUser.Name)
+	rts.Equal("Manager", actual.ChangeUser.Name)
+	rts.True(createdAt.Equal(actual.CreatedAt))
+	rts.True(updatedAt.Equal(actual.UpdatedAt))
+}
+
+func (rts *TestRepositorySuite) TestUpdateStatus_AuditFields() {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	tx, _ := rts.db.BeginTx(ctx, nil)
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	repository := NewRepository(tx)
+
+	_, err := tx.ExecContext(ctx, "INSERT INTO user (id, name, role) VALUES (1, 'Agent', 'sales_agent'), (2, 'Manager', 'manager')")
+	rts.NoError(err)
+
+	createdAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	saleOrder, err := repository.CreateOrder(ctx, &document.SaleOrder{
+		Document: document.Document{
+			Number:     "9",
+			Date:       createdAt,
+			Status:     document.StatusDraft,
+			Company:    testUser.Company,
+			AppendUser: &reference.User{Reference: reference.Reference{ID: 1}},
+			ChangeUser: &reference.User{Reference: reference.Reference{ID: 1}},
+			CreatedAt:  createdAt,
+			UpdatedAt:  createdAt,
+		},
+	})
+	rts.NoError(err)
+
+	updatedAt := time.Now().Truncate(time.Second)
+	saleOrder.ChangeUser = &reference.User{Reference: reference.Reference{ID: 2}}
+	saleOrder.UpdatedAt = updatedAt
+
+	// act
+	err = repository.UpdateStatus(ctx, saleOrder, document.StatusPosted)
+
+	// assert
+	rts.NoError(err)
+	actual, err := repository.GetByID(ctx, saleOrder.ID)
+	rts.NoError(err)
+	rts.Equal(document.StatusPosted, actual.Status)
+	rts.Equal("Agent", actual.AppendUser.Name)
+	rts.Equal("Manager", actual.ChangeUser.Name)
+	rts.True(createdAt.Equal(actual.CreatedAt))
+	rts.True(updatedAt.Equal(actual.UpdatedAt))
+}
+
+func (rts *TestRepositorySuite) TestGetByID_ExactPrices() {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	tx, _ := rts.db.BeginTx(ctx, nil)
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	repository := NewRepository(tx)
+
+	_, err := tx.ExecContext(ctx, "INSERT INTO product (id, name) VALUES (1, 'Keyboard'), (2, 'Mouse')")
+	rts.NoError(err)
+
+	saleOrder, err := repository.CreateOrder(ctx, &document.SaleOrder{
+		Document: document.Document{
+			Number:  "7",
+			Date:    time.Now(),
+			Status:  document.StatusDraft,
+			Company: testUser.Company,
+		},
+		Currency: "USD",
+		Products: []document.SaleOrderProduct{
+			{Product: reference.Product{Reference: reference.Reference{ID: 1}}, Quantity: 3, Price: money.New(10, "USD")},
+			{Product: reference.Product{Reference: reference.Reference{ID: 2}}, Quantity: 1, Price: money.New(20, "USD")},
+		},
+	})
+	rts.NoError(err)
+
+	// act
+	actual, err := repository.GetByID(ctx, saleOrder.ID)
+
+	// assert
+	rts.NoError(err)
+	rts.Equal(money.Currency("USD"), actual.Currency)
+	rts.Equal(money.New(10, "USD"), actual.Products[0].Price)
+	rts.Equal(money.New(20, "USD"), actual.Products[1].Price)
+	rts.Equal("0.50", actual.Total().String())
+}
+
+func (rts *TestRepositorySuite) TestList_Pagination() {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	tx, _ := rts.db.BeginTx(ctx, nil)
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	repository := NewRepository(tx)
+
+	date := time.Now().Truncate(time.Second)
+	for _, number := range []string{"L-3", "L-1", "L-2", "X-1"} {
+		_, err := repository.CreateOrder(ctx, &document.SaleOrder{
+			Document: document.Document{
+				Number:  number,
+				Date:    date,
+				Status:  document.StatusDraft,
+				Company: testUser.Company,
+			},
+		})
+		rts.NoError(err)
+	}
+
+	filter := document.SaleOrderFilter{NumberPrefix: "L-"}
+	sort := document.SaleOrderSort{Field: document.SaleOrderSortByNumber}
+
+	// act & assert
+	firstPage, err := repository.List(ctx, filter, sort, nil, 2)
+	rts.NoError(err)
+	rts.Len(firstPage, 2)
+	rts.Equal("L-1", firstPage[0].Number)
+	rts.Equal("L-2", firstPage[1].Number)
+
+	secondPage, err := repository.List(ctx, filter, sort, &document.SaleOrderListCursor{
+		Number: firstPage[1].Number,
+		ID:     firstPage[1].ID,
+	}, 2)
+	rts.NoError(err)
+	rts.Len(secondPage, 1)
+	rts.Equal("L-3", secondPage[0].Number)
+}
+
+func (rts *TestRepositorySuite) TestCompanyIsolation() {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	tx, _ := rts.db.BeginTx(ctx, nil)
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	repository := NewRepository(tx)
+
+	_, err := tx.ExecContext(ctx, "INSERT INTO company (id, name) VALUES (2, 'Other company')")
+	rts.NoError(err)
+
+	saleOrder, err := repository.CreateOrder(ctx, &document.SaleOrder{
+		Document: document.Document{
+			Number:  "I-1",
+			Date:    time.Now(),
+			Status:  document.StatusDraft,
+			Company: testUser.Company,
+		},
+	})
+	rts.NoError(err)
+
+	otherUser := &reference.User{
+		Reference: reference.Reference{ID: 6},
+		Company:   reference.Company{Reference: reference.Reference{ID: 2}},
+	}
+	otherCtx := access.WithUser(ctx, otherUser)
+
+	// act & assert
+	actual, err := repository.GetByID(otherCtx, saleOrder.ID)
+	rts.NoError(err)
+	rts.Nil(actual)
+
+	list, err := repository.List(
+		otherCtx,
+		document.SaleOrderFilter{NumberPrefix: "I-"},
+		document.SaleOrderSort{Field: document.SaleOrderSortByNumber},
+		nil,
+		10,
+	)
+	rts.NoError(err)
+	rts.Empty(list)
+
+	err = repository.UpdateStatus(otherCtx, saleOrder, document.StatusPosted)
+	var errVersionConflict *domainerrors.ErrVersionConflict
+	rts.ErrorAs(err, &errVersionConflict)
+
+	actual, err = repository.GetByID(ctx, saleOrder.ID)
+	rts.NoError(err)
+	rts.Equal(document.StatusDraft, actual.Status)
+	rts.Equal("Default company", actual.Company.Name)
+}
+
+func (rts *TestRepositorySuite) TestCreateOrder_NumberUniquePerCompany() {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	tx, _ := rts.db.BeginTx(ctx, nil)
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	repository := NewRepository(tx)
+
+	_, err := tx.ExecContext(ctx, "INSERT INTO company (id, name) VALUES (2, 'Other company')")
+	rts.NoError(err)
+
+	newOrder := func(companyID uint64) *document.SaleOrder {
+		return &document.SaleOrder{
+			Document: document.Document{
+				Number:  "SO-2026-000001",
+				Date:    time.Now(),
+				Status:  document.StatusDraft,
+				Company: reference.Company{Reference: reference.Reference{ID: companyID}},
+			},
+		}
+	}
+
+	otherCtx := access.WithUser(ctx, &reference.User{
+		Reference: reference.Reference{ID: 6},
+		Company:   reference.Company{Reference: reference.Reference{ID: 2}},
+	})
+
+	// act
+	_, firstErr := repository.CreateOrder(ctx, newOrder(1))
+	_, otherCompanyErr := repository.CreateOrder(otherCtx, newOrder(2))
+	_, sameCompanyErr := repository.CreateOrder(ctx, newOrder(1))
+
+	// assert
+	rts.NoError(firstErr)
+	rts.NoError(otherCompanyErr)
+	rts.ErrorContains(sameCompanyErr, "UNIQUE constraint failed: sale_order.company_id, sale_order.number")
+}
@@ -3,20 +3,33 @@ package sale_order
 import (
 	"context"
 	"errors"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
 	"github.com/kiaplayer/clean-architecture-example/pkg/helpers"
 )
 
+const testCompanyID = 1
+
+var testUser = &reference.User{
+	Reference: reference.Reference{ID: 5},
+	Company: reference.Company{
+		Reference: reference.Reference{ID: testCompanyID},
+	},
+}
+
 func TestCreateOrder_Success(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -24,10 +37,20 @@ func TestCreateOrder_Success(t *testing.T) {
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
-			Number: "123",
-			Date:   time.Now(),
-			Status: document.StatusDraft,
+			Number:  "123",
+			Date:    time.Now(),
+			Status:  document.StatusDraft,
+			Company: testUser.Company,
+			AppendUser: &reference.User{
+				Reference: reference.Reference{ID: 5},
+			},
+			ChangeUser: &reference.User{
+				Reference: reference.Reference{ID: 5},
+			},
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
 		},
+		Currency: money.DefaultCurrency,
 		Products: []document.SaleOrderProduct{
 			{
 				Product: reference.Product{
@@ -38,7 +61,7 @@ func TestCreateOrder_Success(t *testing.T) {
 					},
 				},
 				Quantity: 1,
-				Price:    150.5,
+				Price:    money.New(15050, money.DefaultCurrency),
 			},
 		},
 	}
@@ -55,6 +78,13 @@ func TestCreateOrder_Success(t *testing.T) {
 			saleOrder.Number,
 			helpers.TimeToString(saleOrder.Date),
 			saleOrder.Status,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			saleOrder.AppendUser.ID,
+			saleOrder.ChangeUser.ID,
+			helpers.TimeToString(saleOrder.CreatedAt),
+			helpers.TimeToString(saleOrder.UpdatedAt),
+			testCompanyID,
 		).
 		WillReturnResult(insertSaleOrderResult)
 
@@ -64,7 +94,7 @@ func TestCreateOrder_Success(t *testing.T) {
 			saleOrderID,
 			saleOrder.Products[0].Product.ID,
 			saleOrder.Products[0].Quantity,
-			saleOrder.Products[0].Price,
+			saleOrder.Products[0].Price.Amount,
 		).
 		WillReturnResult(insertSaleOrderProductResult)
 
@@ -80,7 +110,7 @@ func TestCreateOrder_Success(t *testing.T) {
 
 func TestCreateOrder_InsertError(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -88,9 +118,10 @@ func TestCreateOrder_InsertError(t *testing.T) {
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
-			Number: "123",
-			Date:   time.Now(),
-			Status: document.StatusDraft,
+			Number:  "123",
+			Date:    time.Now(),
+			Status:  document.StatusDraft,
+			Company: testUser.Company,
 		},
 	}
 
@@ -102,6 +133,13 @@ func TestCreateOrder_InsertError(t *testing.T) {
 			saleOrder.Number,
 			helpers.TimeToString(saleOrder.Date),
 			saleOrder.Status,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			nil,
+			nil,
+			nil,
+			nil,
+			testCompanyID,
 		).
 		WillReturnError(insertError)
 
@@ -115,7 +153,7 @@ func TestCreateOrder_InsertError(t *testing.T) {
 
 func TestCreateOrder_InsertProductError(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -123,10 +161,12 @@ func TestCreateOrder_InsertProductError(t *testing.T) {
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
-			Number: "123",
-			Date:   time.Now(),
-			Status: document.StatusDraft,
+			Number:  "123",
+			Date:    time.Now(),
+			Status:  document.StatusDraft,
+			Company: testUser.Company,
 		},
+		Currency: money.DefaultCurrency,
 		Products: []document.SaleOrderProduct{
 			{
 				ID: 1000,
@@ -138,7 +178,7 @@ func TestCreateOrder_InsertProductError(t *testing.T) {
 					},
 				},
 				Quantity: 1,
-				Price:    300,
+				Price:    money.New(30000, money.DefaultCurrency),
 			},
 		},
 	}
@@ -154,6 +194,13 @@ func TestCreateOrder_InsertProductError(t *testing.T) {
 			saleOrder.Number,
 			helpers.TimeToString(saleOrder.Date),
 			saleOrder.Status,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			nil,
+			nil,
+			nil,
+			nil,
+			testCompanyID,
 		).
 		WillReturnResult(insertSaleOrderResult)
 
@@ -163,7 +210,7 @@ func TestCreateOrder_InsertProductError(t *testing.T) {
 			saleOrderID,
 			saleOrder.Products[0].Product.ID,
 			saleOrder.Products[0].Quantity,
-			saleOrder.Products[0].Price,
+			saleOrder.Products[0].Price.Amount,
 		).
 		WillReturnError(insertError)
 
@@ -177,7 +224,7 @@ func TestCreateOrder_InsertProductError(t *testing.T) {
 
 func TestCreateOrder_LastInsertIDError(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -185,9 +232,10 @@ func TestCreateOrder_LastInsertIDError(t *testing.T) {
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
-			Number: "123",
-			Date:   time.Now(),
-			Status: document.StatusDraft,
+			Number:  "123",
+			Date:    time.Now(),
+			Status:  document.StatusDraft,
+			Company: testUser.Company,
 		},
 	}
 
@@ -201,6 +249,13 @@ func TestCreateOrder_LastInsertIDError(t *testing.T) {
 			saleOrder.Number,
 			helpers.TimeToString(saleOrder.Date),
 			saleOrder.Status,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			nil,
+			nil,
+			nil,
+			nil,
+			testCompanyID,
 		).
 		WillReturnResult(insertResult)
 
@@ -214,7 +269,7 @@ func TestCreateOrder_LastInsertIDError(t *testing.T) {
 
 func TestCreateOrder_LastInsertProductIDError(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -222,10 +277,12 @@ func TestCreateOrder_LastInsertProductIDError(t *testing.T) {
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
-			Number: "123",
-			Date:   time.Now(),
-			Status: document.StatusDraft,
+			Number:  "123",
+			Date:    time.Now(),
+			Status:  document.StatusDraft,
+			Company: testUser.Company,
 		},
+		Currency: money.DefaultCurrency,
 		Products: []document.SaleOrderProduct{
 			{
 				Product: reference.Product{
@@ -236,7 +293,7 @@ func TestCreateOrder_LastInsertProductIDError(t *testing.T) {
 					},
 				},
 				Quantity: 1,
-				Price:    150.5,
+				Price:    money.New(15050, money.DefaultCurrency),
 			},
 		},
 	}
@@ -253,6 +310,13 @@ func TestCreateOrder_LastInsertProductIDError(t *testing.T) {
 			saleOrder.Number,
 			helpers.TimeToString(saleOrder.Date),
 			saleOrder.Status,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			nil,
+			nil,
+			nil,
+			nil,
+			testCompanyID,
 		).
 		WillReturnResult(insertSaleOrderResult)
 
@@ -262,7 +326,7 @@ func TestCreateOrder_LastInsertProductIDError(t *testing.T) {
 			saleOrderID,
 			saleOrder.Products[0].Product.ID,
 			saleOrder.Products[0].Quantity,
-			saleOrder.Products[0].Price,
+			saleOrder.Products[0].Price.Amount,
 		).
 		WillReturnResult(insertSaleOrderProductResult)
 
@@ -276,7 +340,7 @@ func TestCreateOrder_LastInsertProductIDError(t *testing.T) {
 
 func TestGetByID_Success(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -288,7 +352,24 @@ func TestGetByID_Success(t *testing.T) {
 			Number: "123",
 			Date:   time.Now().Truncate(time.Second),
 			Status: document.StatusDraft,
+			AppendUser: &reference.User{
+				Reference: reference.Reference{ID: 5, Name: "Agent"},
+			},
+			ChangeUser: &reference.User{
+				Reference: reference.Reference{ID: 6, Name: "Manager"},
+			},
+			CreatedAt: time.Now().Add(-time.Hour).Truncate(time.Second),
+			UpdatedAt: time.Now().Truncate(time.Second),
+			Version:   1,
 		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID:     10,
+				Name:   "Customer",
+				Status: reference.StatusActive,
+			},
+		},
+		Currency: money.DefaultCurrency,
 		Products: []document.SaleOrderProduct{
 			{
 				ID: 1000,
@@ -300,7 +381,7 @@ func TestGetByID_Success(t *testing.T) {
 					},
 				},
 				Quantity: 1,
-				Price:    300,
+				Price:    money.New(30000, money.DefaultCurrency),
 			},
 		},
 	}
@@ -310,12 +391,40 @@ func TestGetByID_Success(t *testing.T) {
 		"date",
 		"number",
 		"status",
+		"currency",
+		"customer_id",
+		"customer_name",
+		"customer_status",
+		"append_user_id",
+		"append_user_name",
+		"change_user_id",
+		"change_user_name",
+		"created_at",
+		"updated_at",
+		"company_id",
+		"company_name",
+		"company_status",
+		"version",
 	}).
 		AddRow(
 			saleOrder.ID,
 			helpers.TimeToString(saleOrder.Date),
 			saleOrder.Number,
 			saleOrder.Status,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			saleOrder.Customer.Name,
+			saleOrder.Customer.Status,
+			saleOrder.AppendUser.ID,
+			saleOrder.AppendUser.Name,
+			saleOrder.ChangeUser.ID,
+			saleOrder.ChangeUser.Name,
+			helpers.TimeToString(saleOrder.CreatedAt),
+			helpers.TimeToString(saleOrder.UpdatedAt),
+			nil,
+			nil,
+			nil,
+			1,
 		)
 
 	saleOrdersProductsResult := sqlmock.NewRows([]string{
@@ -330,14 +439,14 @@ func TestGetByID_Success(t *testing.T) {
 			saleOrder.Products[0].ID,
 			saleOrder.Products[0].Product.ID,
 			saleOrder.Products[0].Quantity,
-			saleOrder.Products[0].Price,
+			saleOrder.Products[0].Price.Amount,
 			saleOrder.Products[0].Product.Name,
 			saleOrder.Products[0].Product.Status,
 		)
 
 	mock.
 		ExpectQuery("^SELECT (.+) FROM sale_order ").
-		WithArgs(saleOrder.ID).
+		WithArgs(saleOrder.ID, testCompanyID).
 		WillReturnRows(saleOrdersResult)
 
 	mock.
@@ -355,7 +464,7 @@ func TestGetByID_Success(t *testing.T) {
 
 func TestGetByID_QueryError(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -374,7 +483,7 @@ func TestGetByID_QueryError(t *testing.T) {
 
 	mock.
 		ExpectQuery("^SELECT (.+) FROM sale_order ").
-		WithArgs(saleOrder.ID).
+		WithArgs(saleOrder.ID, testCompanyID).
 		WillReturnError(queryError)
 
 	// act
@@ -387,7 +496,7 @@ func TestGetByID_QueryError(t *testing.T) {
 
 func TestGetByID_QueryProductsError(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -400,6 +509,7 @@ func TestGetByID_QueryProductsError(t *testing.T) {
 			Date:   time.Now().Truncate(time.Second),
 			Status: document.StatusDraft,
 		},
+		Currency: money.DefaultCurrency,
 		Products: []document.SaleOrderProduct{
 			{
 				ID: 1000,
@@ -411,7 +521,7 @@ func TestGetByID_QueryProductsError(t *testing.T) {
 					},
 				},
 				Quantity: 1,
-				Price:    300,
+				Price:    money.New(30000, money.DefaultCurrency),
 			},
 		},
 	}
@@ -421,17 +531,45 @@ func TestGetByID_QueryProductsError(t *testing.T) {
 		"date",
 		"number",
 		"status",
+		"currency",
+		"customer_id",
+		"customer_name",
+		"customer_status",
+		"append_user_id",
+		"append_user_name",
+		"change_user_id",
+		"change_user_name",
+		"created_at",
+		"updated_at",
+		"company_id",
+		"company_name",
+		"company_status",
+		"version",
 	}).
 		AddRow(
 			saleOrder.ID,
 			helpers.TimeToString(saleOrder.Date),
 			saleOrder.Number,
 			saleOrder.Status,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			saleOrder.Customer.Name,
+			saleOrder.Customer.Status,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			1,
 		)
 
 	mock.
 		ExpectQuery("^SELECT (.+) FROM sale_order ").
-		WithArgs(saleOrder.ID).
+		WithArgs(saleOrder.ID, testCompanyID).
 		WillReturnRows(saleOrdersResult)
 
 	queryError := errors.New("query error")
@@ -451,7 +589,7 @@ func TestGetByID_QueryProductsError(t *testing.T) {
 
 func TestGetByID_NextError(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -473,18 +611,46 @@ func TestGetByID_NextError(t *testing.T) {
 		"date",
 		"number",
 		"status",
+		"currency",
+		"customer_id",
+		"customer_name",
+		"customer_status",
+		"append_user_id",
+		"append_user_name",
+		"change_user_id",
+		"change_user_name",
+		"created_at",
+		"updated_at",
+		"company_id",
+		"company_name",
+		"company_status",
+		"version",
 	}).
 		AddRow(
 			saleOrder.ID,
 			helpers.TimeToString(saleOrder.Date),
 			saleOrder.Number,
 			saleOrder.Status,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			saleOrder.Customer.Name,
+			saleOrder.Customer.Status,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			1,
 		).
 		RowError(0, nextError)
 
 	mock.
 		ExpectQuery("^SELECT (.+) FROM sale_order ").
-		WithArgs(saleOrder.ID).
+		WithArgs(saleOrder.ID, testCompanyID).
 		WillReturnRows(rowsResult)
 
 	// act
@@ -497,7 +663,7 @@ func TestGetByID_NextError(t *testing.T) {
 
 func TestGetByID_NextProductError(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -510,6 +676,7 @@ func TestGetByID_NextProductError(t *testing.T) {
 			Date:   time.Now().Truncate(time.Second),
 			Status: document.StatusDraft,
 		},
+		Currency: money.DefaultCurrency,
 		Products: []document.SaleOrderProduct{
 			{
 				ID: 1000,
@@ -521,7 +688,7 @@ func TestGetByID_NextProductError(t *testing.T) {
 					},
 				},
 				Quantity: 1,
-				Price:    300,
+				Price:    money.New(30000, money.DefaultCurrency),
 			},
 		},
 	}
@@ -533,12 +700,40 @@ func TestGetByID_NextProductError(t *testing.T) {
 		"date",
 		"number",
 		"status",
+		"currency",
+		"customer_id",
+		"customer_name",
+		"customer_status",
+		"append_user_id",
+		"append_user_name",
+		"change_user_id",
+		"change_user_name",
+		"created_at",
+		"updated_at",
+		"company_id",
+		"company_name",
+		"company_status",
+		"version",
 	}).
 		AddRow(
 			saleOrder.ID,
 			helpers.TimeToString(saleOrder.Date),
 			saleOrder.Number,
 			saleOrder.Status,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			saleOrder.Customer.Name,
+			saleOrder.Customer.Status,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			1,
 		)
 
 	saleOrdersProductsResult := sqlmock.NewRows([]string{
@@ -553,7 +748,7 @@ func TestGetByID_NextProductError(t *testing.T) {
 			saleOrder.Products[0].ID,
 			saleOrder.Products[0].Product.ID,
 			saleOrder.Products[0].Quantity,
-			saleOrder.Products[0].Price,
+			saleOrder.Products[0].Price.Amount,
 			saleOrder.Products[0].Product.Name,
 			saleOrder.Products[0].Product.Status,
 		).
@@ -561,7 +756,7 @@ func TestGetByID_NextProductError(t *testing.T) {
 
 	mock.
 		ExpectQuery("^SELECT (.+) FROM sale_order ").
-		WithArgs(saleOrder.ID).
+		WithArgs(saleOrder.ID, testCompanyID).
 		WillReturnRows(saleOrderResult)
 
 	mock.
@@ -579,7 +774,7 @@ func TestGetByID_NextProductError(t *testing.T) {
 
 func TestGetByID_ScanError(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -607,7 +802,7 @@ func TestGetByID_ScanError(t *testing.T) {
 
 	mock.
 		ExpectQuery("^SELECT (.+) FROM sale_order ").
-		WithArgs(saleOrder.ID).
+		WithArgs(saleOrder.ID, testCompanyID).
 		WillReturnRows(rowsResult)
 
 	// act
@@ -620,7 +815,7 @@ func TestGetByID_ScanError(t *testing.T) {
 
 func TestGetByID_ScanProductsError(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -633,6 +828,7 @@ func TestGetByID_ScanProductsError(t *testing.T) {
 			Date:   time.Now().Truncate(time.Second),
 			Status: document.StatusDraft,
 		},
+		Currency: money.DefaultCurrency,
 		Products: []document.SaleOrderProduct{
 			{
 				ID: 1000,
@@ -644,7 +840,7 @@ func TestGetByID_ScanProductsError(t *testing.T) {
 					},
 				},
 				Quantity: 1,
-				Price:    300,
+				Price:    money.New(30000, money.DefaultCurrency),
 			},
 		},
 	}
@@ -654,12 +850,40 @@ func TestGetByID_ScanProductsError(t *testing.T) {
 		"date",
 		"number",
 		"status",
+		"currency",
+		"customer_id",
+		"customer_name",
+		"customer_status",
+		"append_user_id",
+		"append_user_name",
+		"change_user_id",
+		"change_user_name",
+		"created_at",
+		"updated_at",
+		"company_id",
+		"company_name",
+		"company_status",
+		"version",
 	}).
 		AddRow(
 			saleOrder.ID,
 			helpers.TimeToString(saleOrder.Date),
 			saleOrder.Number,
 			saleOrder.Status,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			saleOrder.Customer.Name,
+			saleOrder.Customer.Status,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			1,
 		)
 
 	saleOrdersProductsResult := sqlmock.NewRows([]string{
@@ -673,13 +897,13 @@ func TestGetByID_ScanProductsError(t *testing.T) {
 			saleOrder.Products[0].ID,
 			saleOrder.Products[0].Product.ID,
 			saleOrder.Products[0].Quantity,
-			saleOrder.Products[0].Price,
+			saleOrder.Products[0].Price.Amount,
 			saleOrder.Products[0].Product.Name,
 		)
 
 	mock.
 		ExpectQuery("^SELECT (.+) FROM sale_order ").
-		WithArgs(saleOrder.ID).
+		WithArgs(saleOrder.ID, testCompanyID).
 		WillReturnRows(saleOrdersResult)
 
 	mock.
@@ -697,7 +921,7 @@ func TestGetByID_ScanProductsError(t *testing.T) {
 
 func TestGetByID_NotFound(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -717,11 +941,25 @@ func TestGetByID_NotFound(t *testing.T) {
 		"date",
 		"number",
 		"status",
+		"currency",
+		"customer_id",
+		"customer_name",
+		"customer_status",
+		"append_user_id",
+		"append_user_name",
+		"change_user_id",
+		"change_user_name",
+		"created_at",
+		"updated_at",
+		"company_id",
+		"company_name",
+		"company_status",
+		"version",
 	})
 
 	mock.
 		ExpectQuery("^SELECT (.+) FROM sale_order ").
-		WithArgs(saleOrder.ID).
+		WithArgs(saleOrder.ID, testCompanyID).
 		WillReturnRows(rowsResult)
 
 	// act
@@ -734,7 +972,7 @@ func TestGetByID_NotFound(t *testing.T) {
 
 func TestGetByID_BadDate(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -754,17 +992,45 @@ func TestGetByID_BadDate(t *testing.T) {
 		"date",
 		"number",
 		"status",
+		"currency",
+		"customer_id",
+		"customer_name",
+		"customer_status",
+		"append_user_id",
+		"append_user_name",
+		"change_user_id",
+		"change_user_name",
+		"created_at",
+		"updated_at",
+		"company_id",
+		"company_name",
+		"company_status",
+		"version",
 	}).
 		AddRow(
 			saleOrder.ID,
 			"0000-00-00",
 			saleOrder.Number,
 			saleOrder.Status,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			saleOrder.Customer.Name,
+			saleOrder.Customer.Status,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			1,
 		)
 
 	mock.
 		ExpectQuery("^SELECT (.+) FROM sale_order ").
-		WithArgs(saleOrder.ID).
+		WithArgs(saleOrder.ID, testCompanyID).
 		WillReturnRows(rowsResult)
 
 	// act
@@ -777,7 +1043,7 @@ func TestGetByID_BadDate(t *testing.T) {
 
 func TestGetByID_BadStatus(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -797,17 +1063,45 @@ func TestGetByID_BadStatus(t *testing.T) {
 		"date",
 		"number",
 		"status",
+		"currency",
+		"customer_id",
+		"customer_name",
+		"customer_status",
+		"append_user_id",
+		"append_user_name",
+		"change_user_id",
+		"change_user_name",
+		"created_at",
+		"updated_at",
+		"company_id",
+		"company_name",
+		"company_status",
+		"version",
 	}).
 		AddRow(
 			saleOrder.ID,
 			helpers.TimeToString(saleOrder.Date),
 			saleOrder.Number,
 			999,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			saleOrder.Customer.Name,
+			saleOrder.Customer.Status,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			1,
 		)
 
 	mock.
 		ExpectQuery("^SELECT (.+) FROM sale_order ").
-		WithArgs(saleOrder.ID).
+		WithArgs(saleOrder.ID, testCompanyID).
 		WillReturnRows(rowsResult)
 
 	// act
@@ -820,7 +1114,7 @@ func TestGetByID_BadStatus(t *testing.T) {
 
 func TestGetByID_BadProductStatus(t *testing.T) {
 	// arrange
-	ctx := context.Background()
+	ctx := access.WithUser(context.Background(), testUser)
 
 	db, mock, _ := sqlmock.New()
 
@@ -833,6 +1127,7 @@ func TestGetByID_BadProductStatus(t *testing.T) {
 			Date:   time.Now().Truncate(time.Second),
 			Status: document.StatusDraft,
 		},
+		Currency: money.DefaultCurrency,
 		Products: []document.SaleOrderProduct{
 			{
 				ID: 1000,
@@ -844,7 +1139,7 @@ func TestGetByID_BadProductStatus(t *testing.T) {
 					},
 				},
 				Quantity: 1,
-				Price:    300,
+				Price:    money.New(30000, money.DefaultCurrency),
 			},
 		},
 	}
@@ -854,12 +1149,40 @@ func TestGetByID_BadProductStatus(t *testing.T) {
 		"date",
 		"number",
 		"status",
+		"currency",
+		"customer_id",
+		"customer_name",
+		"customer_status",
+		"append_user_id",
+		"append_user_name",
+		"change_user_id",
+		"change_user_name",
+		"created_at",
+		"updated_at",
+		"company_id",
+		"company_name",
+		"company_status",
+		"version",
 	}).
 		AddRow(
 			saleOrder.ID,
 			helpers.TimeToString(saleOrder.Date),
 			saleOrder.Number,
 			saleOrder.Status,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			saleOrder.Customer.Name,
+			saleOrder.Customer.Status,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			1,
 		)
 
 	saleOrdersProductsResult := sqlmock.NewRows([]string{
@@ -874,14 +1197,14 @@ func TestGetByID_BadProductStatus(t *testing.T) {
 			saleOrder.Products[0].ID,
 			saleOrder.Products[0].Product.ID,
 			saleOrder.Products[0].Quantity,
-			saleOrder.Products[0].Price,
+			saleOrder.Products[0].Price.Amount,
 			saleOrder.Products[0].Product.Name,
 			999,
 		)
 
 	mock.
 		ExpectQuery("^SELECT (.+) FROM sale_order ").
-		WithArgs(saleOrder.ID).
+		WithArgs(saleOrder.ID, testCompanyID).
 		WillReturnRows(saleOrderResult)
 
 	mock.
@@ -896,3 +1219,740 @@ func TestGetByID_BadProductStatus(t *testing.T) {
 	assert.Nil(t, actualSaleOrder)
 	assert.ErrorContains(t, getErr, "bad status: 999")
 }
+
+func TestGetByID_BadCustomerStatus(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:     100,
+			Number: "123",
+			Date:   time.Now().Truncate(time.Second),
+			Status: document.StatusDraft,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID:   10,
+				Name: "Customer",
+			},
+		},
+	}
+
+	rowsResult := sqlmock.NewRows([]string{
+		"id",
+		"date",
+		"number",
+		"status",
+		"currency",
+		"customer_id",
+		"customer_name",
+		"customer_status",
+		"append_user_id",
+		"append_user_name",
+		"change_user_id",
+		"change_user_name",
+		"created_at",
+		"updated_at",
+		"company_id",
+		"company_name",
+		"company_status",
+		"version",
+	}).
+		AddRow(
+			saleOrder.ID,
+			helpers.TimeToString(saleOrder.Date),
+			saleOrder.Number,
+			saleOrder.Status,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			saleOrder.Customer.Name,
+			999,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			1,
+		)
+
+	mock.
+		ExpectQuery("^SELECT (.+) FROM sale_order ").
+		WithArgs(saleOrder.ID, testCompanyID).
+		WillReturnRows(rowsResult)
+
+	// act
+	actualSaleOrder, getErr := repository.GetByID(ctx, saleOrder.ID)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, getErr, "bad customer status: 999")
+}
+
+func TestUpdateStatus_Success(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:         100,
+			Version:    2,
+			ChangeUser: testUser,
+			UpdatedAt:  time.Now().Truncate(time.Second),
+		},
+	}
+
+	mock.
+		ExpectExec("UPDATE sale_order SET status = (.+), change_user_id = (.+), updated_at = (.+)").
+		WithArgs(
+			document.StatusPosted,
+			testUser.ID,
+			helpers.TimeToString(saleOrder.UpdatedAt),
+			saleOrder.ID,
+			testCompanyID,
+			uint64(2),
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// act
+	updateErr := repository.UpdateStatus(ctx, saleOrder, document.StatusPosted)
+
+	// assert
+	assert.NoError(t, updateErr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateStatus_Error(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:         100,
+			Version:    2,
+			ChangeUser: testUser,
+			UpdatedAt:  time.Now().Truncate(time.Second),
+		},
+	}
+
+	updateError := errors.New("update error")
+
+	mock.
+		ExpectExec("UPDATE sale_order SET status = (.+), change_user_id = (.+), updated_at = (.+)").
+		WithArgs(
+			document.StatusPosted,
+			testUser.ID,
+			helpers.TimeToString(saleOrder.UpdatedAt),
+			saleOrder.ID,
+			testCompanyID,
+			uint64(2),
+		).
+		WillReturnError(updateError)
+
+	// act
+	updateErr := repository.UpdateStatus(ctx, saleOrder, document.StatusPosted)
+
+	// assert
+	assert.ErrorContains(t, updateErr, updateError.Error())
+}
+
+func TestUpdateStatus_VersionConflict(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:         100,
+			Version:    2,
+			ChangeUser: testUser,
+			UpdatedAt:  time.Now().Truncate(time.Second),
+		},
+	}
+
+	mock.
+		ExpectExec("UPDATE sale_order SET status = (.+), change_user_id = (.+), updated_at = (.+), version = version \\+ 1 WHERE (.+) AND version = ?").
+		WithArgs(
+			document.StatusPosted,
+			testUser.ID,
+			helpers.TimeToString(saleOrder.UpdatedAt),
+			saleOrder.ID,
+			testCompanyID,
+			uint64(2),
+		).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// act
+	updateErr := repository.UpdateStatus(ctx, saleOrder, document.StatusPosted)
+
+	// assert
+	var errTarget *domainerrors.ErrVersionConflict
+	assert.ErrorAs(t, updateErr, &errTarget)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func newUpdatedSaleOrder() *document.SaleOrder {
+	return &document.SaleOrder{
+		Document: document.Document{
+			ID:     100,
+			Number: "123",
+			Date:   time.Now().Truncate(time.Second),
+			Status: document.StatusDraft,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID: 10,
+			},
+		},
+		Currency: money.DefaultCurrency,
+		Products: []document.SaleOrderProduct{
+			{
+				ID: 1000,
+				Product: reference.Product{
+					Reference: reference.Reference{
+						ID: 1,
+					},
+				},
+				Quantity: 2,
+				Price:    money.New(30000, money.DefaultCurrency),
+			},
+			{
+				Product: reference.Product{
+					Reference: reference.Reference{
+						ID: 2,
+					},
+				},
+				Quantity: 1,
+				Price:    money.New(15050, money.DefaultCurrency),
+			},
+		},
+	}
+}
+
+func TestUpdateOrder_Success(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	saleOrder := newUpdatedSaleOrder()
+
+	deletedLineID := uint64(1001)
+	insertedLineID := int64(1002)
+
+	mock.
+		ExpectExec("UPDATE sale_order SET currency = (.+), customer_id = (.+), change_user_id = (.+), updated_at").
+		WithArgs(saleOrder.Currency, saleOrder.Customer.ID, nil, nil, saleOrder.ID, testCompanyID, saleOrder.Version).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.
+		ExpectQuery("SELECT id FROM sale_order_product WHERE parent_id = ?").
+		WithArgs(saleOrder.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(saleOrder.Products[0].ID).AddRow(deletedLineID))
+
+	mock.
+		ExpectExec("DELETE FROM sale_order_product").
+		WithArgs(deletedLineID, saleOrder.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.
+		ExpectExec("UPDATE sale_order_product").
+		WithArgs(
+			saleOrder.Products[0].Product.ID,
+			saleOrder.Products[0].Quantity,
+			saleOrder.Products[0].Price.Amount,
+			saleOrder.Products[0].ID,
+			saleOrder.ID,
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.
+		ExpectExec("INSERT INTO sale_order_product").
+		WithArgs(
+			saleOrder.ID,
+			saleOrder.Products[1].Product.ID,
+			saleOrder.Products[1].Quantity,
+			saleOrder.Products[1].Price.Amount,
+		).
+		WillReturnResult(sqlmock.NewResult(insertedLineID, 1))
+
+	// act
+	updatedSaleOrder, updateErr := repository.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	assert.NoError(t, updateErr)
+	assert.Equal(t, uint64(insertedLineID), updatedSaleOrder.Products[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateOrder_UpdateError(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	saleOrder := newUpdatedSaleOrder()
+
+	updateError := errors.New("update error")
+
+	mock.
+		ExpectExec("UPDATE sale_order SET currency = (.+), customer_id = (.+), change_user_id = (.+), updated_at").
+		WithArgs(saleOrder.Currency, saleOrder.Customer.ID, nil, nil, saleOrder.ID, testCompanyID, saleOrder.Version).
+		WillReturnError(updateError)
+
+	// act
+	updatedSaleOrder, updateErr := repository.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, updatedSaleOrder)
+	assert.ErrorContains(t, updateErr, updateError.Error())
+}
+
+func TestUpdateOrder_QueryProductsError(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	saleOrder := newUpdatedSaleOrder()
+
+	queryError := errors.New("query error")
+
+	mock.
+		ExpectExec("UPDATE sale_order SET currency = (.+), customer_id = (.+), change_user_id = (.+), updated_at").
+		WithArgs(saleOrder.Currency, saleOrder.Customer.ID, nil, nil, saleOrder.ID, testCompanyID, saleOrder.Version).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.
+		ExpectQuery("SELECT id FROM sale_order_product WHERE parent_id = ?").
+		WithArgs(saleOrder.ID).
+		WillReturnError(queryError)
+
+	// act
+	updatedSaleOrder, updateErr := repository.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, updatedSaleOrder)
+	assert.ErrorContains(t, updateErr, queryError.Error())
+}
+
+func TestUpdateOrder_DeleteProductError(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	saleOrder := newUpdatedSaleOrder()
+
+	deletedLineID := uint64(1001)
+	deleteError := errors.New("delete error")
+
+	mock.
+		ExpectExec("UPDATE sale_order SET currency = (.+), customer_id = (.+), change_user_id = (.+), updated_at").
+		WithArgs(saleOrder.Currency, saleOrder.Customer.ID, nil, nil, saleOrder.ID, testCompanyID, saleOrder.Version).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.
+		ExpectQuery("SELECT id FROM sale_order_product WHERE parent_id = ?").
+		WithArgs(saleOrder.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(deletedLineID))
+
+	mock.
+		ExpectExec("DELETE FROM sale_order_product").
+		WithArgs(deletedLineID, saleOrder.ID).
+		WillReturnError(deleteError)
+
+	// act
+	updatedSaleOrder, updateErr := repository.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, updatedSaleOrder)
+	assert.ErrorContains(t, updateErr, deleteError.Error())
+}
+
+func TestUpdateOrder_UpdateProductError(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	saleOrder := newUpdatedSaleOrder()
+
+	updateError := errors.New("update product error")
+
+	mock.
+		ExpectExec("UPDATE sale_order SET currency = (.+), customer_id = (.+), change_user_id = (.+), updated_at").
+		WithArgs(saleOrder.Currency, saleOrder.Customer.ID, nil, nil, saleOrder.ID, testCompanyID, saleOrder.Version).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.
+		ExpectQuery("SELECT id FROM sale_order_product WHERE parent_id = ?").
+		WithArgs(saleOrder.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(saleOrder.Products[0].ID))
+
+	mock.
+		ExpectExec("UPDATE sale_order_product").
+		WithArgs(
+			saleOrder.Products[0].Product.ID,
+			saleOrder.Products[0].Quantity,
+			saleOrder.Products[0].Price.Amount,
+			saleOrder.Products[0].ID,
+			saleOrder.ID,
+		).
+		WillReturnError(updateError)
+
+	// act
+	updatedSaleOrder, updateErr := repository.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, updatedSaleOrder)
+	assert.ErrorContains(t, updateErr, updateError.Error())
+}
+
+func TestUpdateOrder_InsertProductError(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	saleOrder := newUpdatedSaleOrder()
+	saleOrder.Products = saleOrder.Products[1:]
+
+	insertError := errors.New("insert product error")
+
+	mock.
+		ExpectExec("UPDATE sale_order SET currency = (.+), customer_id = (.+), change_user_id = (.+), updated_at").
+		WithArgs(saleOrder.Currency, saleOrder.Customer.ID, nil, nil, saleOrder.ID, testCompanyID, saleOrder.Version).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.
+		ExpectQuery("SELECT id FROM sale_order_product WHERE parent_id = ?").
+		WithArgs(saleOrder.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	mock.
+		ExpectExec("INSERT INTO sale_order_product").
+		WithArgs(
+			saleOrder.ID,
+			saleOrder.Products[0].Product.ID,
+			saleOrder.Products[0].Quantity,
+			saleOrder.Products[0].Price.Amount,
+		).
+		WillReturnError(insertError)
+
+	// act
+	updatedSaleOrder, updateErr := repository.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, updatedSaleOrder)
+	assert.ErrorContains(t, updateErr, insertError.Error())
+}
+
+func TestList_AllFilters(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	status := document.StatusPosted
+	filter := document.SaleOrderFilter{
+		DateFrom:     time.Now().Add(-time.Hour).Truncate(time.Second),
+		DateTo:       time.Now().Truncate(time.Second),
+		Status:       &status,
+		CustomerID:   10,
+		NumberPrefix: "SO_1%",
+	}
+	sort := document.SaleOrderSort{Field: document.SaleOrderSortByDate}
+	after := &document.SaleOrderListCursor{
+		Date: time.Now().Add(-time.Minute).Truncate(time.Second),
+		ID:   50,
+	}
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:      100,
+			Number:  "SO_1%-1",
+			Date:    time.Now().Truncate(time.Second),
+			Status:  status,
+			Version: 1,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID:     10,
+				Name:   "Customer",
+				Status: reference.StatusActive,
+			},
+		},
+	}
+
+	rowsResult := sqlmock.NewRows([]string{
+		"id",
+		"date",
+		"number",
+		"status",
+		"currency",
+		"customer_id",
+		"customer_name",
+		"customer_status",
+		"append_user_id",
+		"append_user_name",
+		"change_user_id",
+		"change_user_name",
+		"created_at",
+		"updated_at",
+		"company_id",
+		"company_name",
+		"company_status",
+		"version",
+	}).
+		AddRow(
+			saleOrder.ID,
+			helpers.TimeToString(saleOrder.Date),
+			saleOrder.Number,
+			saleOrder.Status,
+			saleOrder.Currency,
+			saleOrder.Customer.ID,
+			saleOrder.Customer.Name,
+			saleOrder.Customer.Status,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			1,
+		)
+
+	mock.
+		ExpectQuery(regexp.QuoteMeta(
+			"WHERE so.company_id = ? AND so.date >= ? AND so.date <= ? AND so.status = ? AND so.customer_id = ? "+
+				`AND so.number LIKE ? ESCAPE '\' AND (so.date, so.id) > (?, ?) `+
+				"ORDER BY so.date ASC, so.id ASC LIMIT ?",
+		)).
+		WithArgs(
+			testCompanyID,
+			helpers.TimeToString(filter.DateFrom),
+			helpers.TimeToString(filter.DateTo),
+			status,
+			filter.CustomerID,
+			`SO\_1\%%`,
+			helpers.TimeToString(after.Date),
+			after.ID,
+			21,
+		).
+		WillReturnRows(rowsResult)
+
+	// act
+	actual, listErr := repository.List(ctx, filter, sort, after, 21)
+
+	// assert
+	assert.NoError(t, listErr)
+	assert.Equal(t, []*document.SaleOrder{saleOrder}, actual)
+}
+
+func TestList_SortByNumberDesc(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	sort := document.SaleOrderSort{Field: document.SaleOrderSortByNumber, Desc: true}
+	after := &document.SaleOrderListCursor{
+		Number: "0050",
+		ID:     50,
+	}
+
+	mock.
+		ExpectQuery(regexp.QuoteMeta(
+			"WHERE so.company_id = ? AND (so.number, so.id) < (?, ?) ORDER BY so.number DESC, so.id DESC LIMIT ?",
+		)).
+		WithArgs(testCompanyID, after.Number, after.ID, 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// act
+	actual, listErr := repository.List(ctx, document.SaleOrderFilter{}, sort, after, 11)
+
+	// assert
+	assert.NoError(t, listErr)
+	assert.Empty(t, actual)
+}
+
+func TestList_BadSortField(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, _, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	sort := document.SaleOrderSort{Field: "customer"}
+
+	// act
+	actual, listErr := repository.List(ctx, document.SaleOrderFilter{}, sort, nil, 10)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, listErr, "bad sort field: customer")
+}
+
+func TestList_QueryError(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	sort := document.SaleOrderSort{Field: document.SaleOrderSortByDate}
+
+	queryError := errors.New("query error")
+
+	mock.
+		ExpectQuery("^SELECT (.+) FROM sale_order ").
+		WithArgs(testCompanyID, 10).
+		WillReturnError(queryError)
+
+	// act
+	actual, listErr := repository.List(ctx, document.SaleOrderFilter{}, sort, nil, 10)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, listErr, queryError.Error())
+}
+
+func TestList_ScanError(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	sort := document.SaleOrderSort{Field: document.SaleOrderSortByDate}
+
+	rowsResult := sqlmock.NewRows([]string{
+		"id",
+		"date",
+	}).
+		AddRow(1, helpers.TimeToString(time.Now()))
+
+	mock.
+		ExpectQuery("^SELECT (.+) FROM sale_order ").
+		WithArgs(testCompanyID, 10).
+		WillReturnRows(rowsResult)
+
+	// act
+	actual, listErr := repository.List(ctx, document.SaleOrderFilter{}, sort, nil, 10)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, listErr, "destination arguments in Scan")
+}
+
+func TestGetByID_NoCompany(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, _, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	// act
+	actual, getErr := repository.GetByID(ctx, 1)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorIs(t, getErr, access.ErrNoCompany)
+}
+
+func TestCreateOrder_OtherCompany(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, _, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			Number: "123",
+			Date:   time.Now(),
+			Company: reference.Company{
+				Reference: reference.Reference{ID: testCompanyID + 1},
+			},
+		},
+	}
+
+	// act
+	actual, createErr := repository.CreateOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, createErr, "sale order company 2 differs from user company 1")
+}
+
+func TestUpdateOrder_OtherCompany(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID: 100,
+		},
+	}
+
+	mock.
+		ExpectExec("UPDATE sale_order SET currency = (.+) WHERE id = (.+) AND company_id").
+		WithArgs(saleOrder.Currency, saleOrder.Customer.ID, nil, nil, saleOrder.ID, testCompanyID, saleOrder.Version).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// act
+	actual, updateErr := repository.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	var errTarget *domainerrors.ErrVersionConflict
+	assert.Nil(t, actual)
+	assert.ErrorAs(t, updateErr, &errTarget)
+}
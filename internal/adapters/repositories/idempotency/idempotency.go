@@ -0,0 +1,109 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/idempotency"
+	"github.com/kiaplayer/clean-architecture-example/pkg/helpers"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+// Repository stores idempotency records of the authenticated user, keys of different users never collide.
+type Repository struct {
+	*db.TransactionalRepository
+}
+
+func NewRepository(qe db.QueryExecutor) *Repository {
+	return &Repository{
+		TransactionalRepository: db.NewTransactionalRepository(qe),
+	}
+}
+
+// Get returns the record of the key, or nil if there is none.
+func (r *Repository) Get(ctx context.Context, key string) (*idempotency.Record, error) {
+	userID, err := access.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult, err := r.DB(ctx).QueryContext(
+		ctx,
+		"SELECT key, request_hash, result, created_at FROM idempotency_key WHERE user_id = ? AND key = ?",
+		userID,
+		key,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(queryResult *sql.Rows) {
+		_ = queryResult.Close()
+	}(queryResult)
+
+	if !queryResult.Next() {
+		return nil, queryResult.Err()
+	}
+
+	recordDTO := struct {
+		Key         string
+		RequestHash string
+		Result      string
+		CreatedAt   string
+	}{}
+
+	err = queryResult.Scan(
+		&recordDTO.Key,
+		&recordDTO.RequestHash,
+		&recordDTO.Result,
+		&recordDTO.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, err := helpers.StringToTime(recordDTO.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &idempotency.Record{
+		Key:         recordDTO.Key,
+		RequestHash: recordDTO.RequestHash,
+		Result:      []byte(recordDTO.Result),
+		CreatedAt:   createdAt,
+	}, nil
+}
+
+// Save stores the record. It returns false without changes if the key is already stored by a concurrent request.
+func (r *Repository) Save(ctx context.Context, record *idempotency.Record) (bool, error) {
+	userID, err := access.UserID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	insertResult, err := r.DB(ctx).ExecContext(
+		ctx,
+		`
+			INSERT INTO idempotency_key (user_id, key, request_hash, result, created_at)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (user_id, key) DO NOTHING
+		`,
+		userID,
+		record.Key,
+		record.RequestHash,
+		string(record.Result),
+		helpers.TimeToString(record.CreatedAt),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := insertResult.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
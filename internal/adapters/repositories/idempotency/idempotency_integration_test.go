@@ -0,0 +1,110 @@
+//go:build integration
+
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/idempotency"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+)
+
+const testDBFilePath = "sqlite_test.db"
+
+type TestRepositorySuite struct {
+	suite.Suite
+	db *sql.DB
+}
+
+func TestRepositoryByTestSuite(t *testing.T) {
+	suite.Run(t, new(TestRepositorySuite))
+}
+
+func (rts *TestRepositorySuite) SetupSuite() {
+	_ = os.Remove(testDBFilePath)
+
+	dbConn, err := sql.Open("sqlite3", testDBFilePath)
+	if err != nil {
+		rts.Failf("cannot open db connection before tests: %s", err.Error())
+	}
+
+	driver, err := sqlite3.WithInstance(dbConn, &sqlite3.Config{})
+	if err != nil {
+		rts.Failf("cannot init db driver before tests: %s", err.Error())
+	}
+
+	m, err := migrate.NewWithDatabaseInstance(
+		"file://./../../../../db/migrations",
+		"sqlite3",
+		driver,
+	)
+	if err != nil {
+		rts.Failf("cannot init db migrator before tests: %s", err.Error())
+	}
+
+	err = m.Up()
+	if err != nil {
+		rts.Failf("cannot apply db migrations before tests: %s", err.Error())
+	}
+
+	rts.db = dbConn
+}
+func (rts *TestRepositorySuite) TearDownSuite() {
+	err := rts.db.Close()
+	if err != nil {
+		rts.Failf("tear down suite: %s", err.Error())
+	}
+	_ = os.Remove(testDBFilePath)
+}
+
+func (rts *TestRepositorySuite) TestSaveAndGet() {
+	// arrange
+	ctx := access.WithUser(context.Background(), &reference.User{Reference: reference.Reference{ID: 1}})
+	otherUserCtx := access.WithUser(context.Background(), &reference.User{Reference: reference.Reference{ID: 2}})
+
+	tx, _ := rts.db.BeginTx(ctx, nil)
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	repository := NewRepository(tx)
+
+	record := &idempotency.Record{
+		Key:         "key-1",
+		RequestHash: "hash",
+		Result:      []byte(`{"id":1}`),
+		CreatedAt:   time.Now().Truncate(time.Second),
+	}
+
+	// act & assert
+	actual, err := repository.Get(ctx, record.Key)
+	rts.NoError(err)
+	rts.Nil(actual)
+
+	saved, err := repository.Save(ctx, record)
+	rts.NoError(err)
+	rts.True(saved)
+
+	saved, err = repository.Save(ctx, record)
+	rts.NoError(err)
+	rts.False(saved)
+
+	actual, err = repository.Get(ctx, record.Key)
+	rts.NoError(err)
+	rts.Equal(record, actual)
+
+	actual, err = repository.Get(otherUserCtx, record.Key)
+	rts.NoError(err)
+	rts.Nil(actual)
+}
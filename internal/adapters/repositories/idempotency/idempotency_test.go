@@ -0,0 +1,166 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/idempotency"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	"github.com/kiaplayer/clean-architecture-example/pkg/helpers"
+)
+
+func userContext() context.Context {
+	return access.WithUser(context.Background(), &reference.User{
+		Reference: reference.Reference{ID: 7},
+	})
+}
+
+func TestGet_Success(t *testing.T) {
+	// arrange
+	ctx := userContext()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	createdAt := time.Now().Truncate(time.Second)
+
+	mock.
+		ExpectQuery("SELECT (.+) FROM idempotency_key WHERE user_id = (.+) AND key = (.+)").
+		WithArgs(uint64(7), "key-1").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"key", "request_hash", "result", "created_at"}).
+				AddRow("key-1", "hash", `{"id":1}`, helpers.TimeToString(createdAt)),
+		)
+
+	// act
+	actual, err := repository.Get(ctx, "key-1")
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, &idempotency.Record{
+		Key:         "key-1",
+		RequestHash: "hash",
+		Result:      []byte(`{"id":1}`),
+		CreatedAt:   createdAt,
+	}, actual)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGet_NotFound(t *testing.T) {
+	// arrange
+	ctx := userContext()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	mock.
+		ExpectQuery("SELECT (.+) FROM idempotency_key").
+		WithArgs(uint64(7), "key-1").
+		WillReturnRows(sqlmock.NewRows([]string{"key", "request_hash", "result", "created_at"}))
+
+	// act
+	actual, err := repository.Get(ctx, "key-1")
+
+	// assert
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGet_NoUser(t *testing.T) {
+	// arrange
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	// act
+	actual, err := repository.Get(context.Background(), "key-1")
+
+	// assert
+	assert.ErrorIs(t, err, access.ErrNoUser)
+	assert.Nil(t, actual)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSave_Success(t *testing.T) {
+	// arrange
+	ctx := userContext()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	record := &idempotency.Record{
+		Key:         "key-1",
+		RequestHash: "hash",
+		Result:      []byte(`{"id":1}`),
+		CreatedAt:   time.Now().Truncate(time.Second),
+	}
+
+	mock.
+		ExpectExec("INSERT INTO idempotency_key (.+) VALUES (.+) ON CONFLICT").
+		WithArgs(uint64(7), "key-1", "hash", `{"id":1}`, helpers.TimeToString(record.CreatedAt)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// act
+	saved, err := repository.Save(ctx, record)
+
+	// assert
+	assert.NoError(t, err)
+	assert.True(t, saved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSave_AlreadyExists(t *testing.T) {
+	// arrange
+	ctx := userContext()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	mock.
+		ExpectExec("INSERT INTO idempotency_key").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// act
+	saved, err := repository.Save(ctx, &idempotency.Record{Key: "key-1"})
+
+	// assert
+	assert.NoError(t, err)
+	assert.False(t, saved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSave_Error(t *testing.T) {
+	// arrange
+	ctx := userContext()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	expectedErr := errors.New("db error")
+
+	mock.
+		ExpectExec("INSERT INTO idempotency_key").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(expectedErr)
+
+	// act
+	saved, err := repository.Save(ctx, &idempotency.Record{Key: "key-1"})
+
+	// assert
+	assert.ErrorIs(t, err, expectedErr)
+	assert.False(t, saved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
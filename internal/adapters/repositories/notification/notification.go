@@ -0,0 +1,59 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/pkg/helpers"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+// Repository records notifications sent for outbox events, so a redelivered event is not notified twice.
+type Repository struct {
+	*db.TransactionalRepository
+}
+
+func NewRepository(qe db.QueryExecutor) *Repository {
+	return &Repository{
+		TransactionalRepository: db.NewTransactionalRepository(qe),
+	}
+}
+
+// IsSent reports whether the notification of the event has been sent to the recipient.
+func (r *Repository) IsSent(ctx context.Context, eventID uint64, recipient string) (bool, error) {
+	queryResult, err := r.DB(ctx).QueryContext(
+		ctx,
+		"SELECT 1 FROM notification_sent WHERE event_id = ? AND recipient = ?",
+		eventID,
+		recipient,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	defer func(queryResult *sql.Rows) {
+		_ = queryResult.Close()
+	}(queryResult)
+
+	sent := queryResult.Next()
+
+	return sent, queryResult.Err()
+}
+
+// MarkSent records the notification of the event to the recipient, a repeated record is ignored.
+func (r *Repository) MarkSent(ctx context.Context, eventID uint64, recipient string, sentAt time.Time) error {
+	_, err := r.DB(ctx).ExecContext(
+		ctx,
+		`
+			INSERT INTO notification_sent (event_id, recipient, sent_at)
+			VALUES (?, ?, ?)
+			ON CONFLICT (event_id, recipient) DO NOTHING
+		`,
+		eventID,
+		recipient,
+		helpers.TimeToString(sentAt),
+	)
+
+	return err
+}
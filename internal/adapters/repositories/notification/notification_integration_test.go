@@ -0,0 +1,111 @@
+//go:build integration
+
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/kiaplayer/clean-architecture-example/pkg/helpers"
+)
+
+const testDBFilePath = "sqlite_test.db"
+
+type TestRepositorySuite struct {
+	suite.Suite
+	db *sql.DB
+}
+
+func TestRepositoryByTestSuite(t *testing.T) {
+	suite.Run(t, new(TestRepositorySuite))
+}
+
+func (rts *TestRepositorySuite) SetupSuite() {
+	_ = os.Remove(testDBFilePath)
+
+	dbConn, err := sql.Open("sqlite3", testDBFilePath)
+	if err != nil {
+		rts.Failf("cannot open db connection before tests: %s", err.Error())
+	}
+
+	driver, err := sqlite3.WithInstance(dbConn, &sqlite3.Config{})
+	if err != nil {
+		rts.Failf("cannot init db driver before tests: %s", err.Error())
+	}
+
+	m, err := migrate.NewWithDatabaseInstance(
+		"file://./../../../../db/migrations",
+		"sqlite3",
+		driver,
+	)
+	if err != nil {
+		rts.Failf("cannot init db migrator before tests: %s", err.Error())
+	}
+
+	err = m.Up()
+	if err != nil {
+		rts.Failf("cannot apply db migrations before tests: %s", err.Error())
+	}
+
+	rts.db = dbConn
+}
+func (rts *TestRepositorySuite) TearDownSuite() {
+	err := rts.db.Close()
+	if err != nil {
+		rts.Failf("tear down suite: %s", err.Error())
+	}
+	_ = os.Remove(testDBFilePath)
+}
+
+func (rts *TestRepositorySuite) TestMarkSentAndIsSent() {
+	// arrange
+	ctx := context.Background()
+
+	tx, _ := rts.db.BeginTx(ctx, nil)
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	now := helpers.TimeToString(time.Now())
+	insertResult, err := tx.ExecContext(
+		ctx,
+		"INSERT INTO outbox_event (type, aggregate_id, payload, created_at, next_attempt_at) VALUES (?, ?, ?, ?, ?)",
+		"SaleOrderCreated",
+		1,
+		"{}",
+		now,
+		now,
+	)
+	rts.NoError(err)
+	eventID, err := insertResult.LastInsertId()
+	rts.NoError(err)
+
+	repository := NewRepository(tx)
+
+	// act & assert
+	sent, err := repository.IsSent(ctx, uint64(eventID), "customer")
+	rts.NoError(err)
+	rts.False(sent)
+
+	err = repository.MarkSent(ctx, uint64(eventID), "customer", time.Now())
+	rts.NoError(err)
+	err = repository.MarkSent(ctx, uint64(eventID), "customer", time.Now())
+	rts.NoError(err)
+
+	sent, err = repository.IsSent(ctx, uint64(eventID), "customer")
+	rts.NoError(err)
+	rts.True(sent)
+
+	sent, err = repository.IsSent(ctx, uint64(eventID), "managers")
+	rts.NoError(err)
+	rts.False(sent)
+}
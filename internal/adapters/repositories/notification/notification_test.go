@@ -0,0 +1,127 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiaplayer/clean-architecture-example/pkg/helpers"
+)
+
+func TestIsSent_Sent(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	mock.
+		ExpectQuery("SELECT 1 FROM notification_sent WHERE event_id = (.+) AND recipient = (.+)").
+		WithArgs(uint64(1), "customer").
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+
+	// act
+	sent, err := repository.IsSent(ctx, 1, "customer")
+
+	// assert
+	assert.NoError(t, err)
+	assert.True(t, sent)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsSent_NotSent(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	mock.
+		ExpectQuery("SELECT 1 FROM notification_sent").
+		WithArgs(uint64(1), "customer").
+		WillReturnRows(sqlmock.NewRows([]string{"1"}))
+
+	// act
+	sent, err := repository.IsSent(ctx, 1, "customer")
+
+	// assert
+	assert.NoError(t, err)
+	assert.False(t, sent)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsSent_Error(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	expectedErr := errors.New("db error")
+
+	mock.
+		ExpectQuery("SELECT 1 FROM notification_sent").
+		WithArgs(uint64(1), "customer").
+		WillReturnError(expectedErr)
+
+	// act
+	sent, err := repository.IsSent(ctx, 1, "customer")
+
+	// assert
+	assert.ErrorIs(t, err, expectedErr)
+	assert.False(t, sent)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkSent_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	sentAt := time.Now().Truncate(time.Second)
+
+	mock.
+		ExpectExec("INSERT INTO notification_sent (.+) VALUES (.+) ON CONFLICT").
+		WithArgs(uint64(1), "customer", helpers.TimeToString(sentAt)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// act
+	err := repository.MarkSent(ctx, 1, "customer", sentAt)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkSent_Error(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	expectedErr := errors.New("db error")
+
+	mock.
+		ExpectExec("INSERT INTO notification_sent").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(expectedErr)
+
+	// act
+	err := repository.MarkSent(ctx, 1, "customer", time.Now())
+
+	// assert
+	assert.ErrorIs(t, err, expectedErr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,137 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/pkg/helpers"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+type Repository struct {
+	*db.TransactionalRepository
+}
+
+func NewRepository(qe db.QueryExecutor) *Repository {
+	return &Repository{
+		TransactionalRepository: db.NewTransactionalRepository(qe),
+	}
+}
+
+// Add stores the event, it is ready for delivery right away.
+func (r *Repository) Add(ctx context.Context, e *event.Event) (*event.Event, error) {
+	createdAt := helpers.TimeToString(e.CreatedAt)
+
+	insertResult, err := r.DB(ctx).ExecContext(
+		ctx,
+		"INSERT INTO outbox_event (type, aggregate_id, payload, created_at, next_attempt_at) VALUES (?, ?, ?, ?, ?)",
+		string(e.Type),
+		e.AggregateID,
+		string(e.Payload),
+		createdAt,
+		createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	lastID, err := insertResult.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	savedEvent := *e
+	savedEvent.ID = uint64(lastID)
+
+	return &savedEvent, nil
+}
+
+// GetPending returns undelivered events due at the time which have less than maxAttempts delivery attempts.
+func (r *Repository) GetPending(ctx context.Context, now time.Time, maxAttempts int, limit int) ([]*event.Event, error) {
+	queryResult, err := r.DB(ctx).QueryContext(
+		ctx,
+		`
+			SELECT id, type, aggregate_id, payload, created_at, attempts
+			FROM outbox_event
+			WHERE delivered_at IS NULL AND next_attempt_at <= ? AND attempts < ?
+			ORDER BY id
+			LIMIT ?
+		`,
+		helpers.TimeToString(now),
+		maxAttempts,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(queryResult *sql.Rows) {
+		_ = queryResult.Close()
+	}(queryResult)
+
+	events := make([]*event.Event, 0)
+
+	for queryResult.Next() {
+		eventDTO := struct {
+			ID          uint64
+			Type        string
+			AggregateID uint64
+			Payload     string
+			CreatedAt   string
+			Attempts    int
+		}{}
+
+		err = queryResult.Scan(
+			&eventDTO.ID,
+			&eventDTO.Type,
+			&eventDTO.AggregateID,
+			&eventDTO.Payload,
+			&eventDTO.CreatedAt,
+			&eventDTO.Attempts,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		createdAt, err := helpers.StringToTime(eventDTO.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, &event.Event{
+			ID:          eventDTO.ID,
+			Type:        event.Type(eventDTO.Type),
+			AggregateID: eventDTO.AggregateID,
+			Payload:     []byte(eventDTO.Payload),
+			CreatedAt:   createdAt,
+			Attempts:    eventDTO.Attempts,
+		})
+	}
+
+	return events, queryResult.Err()
+}
+
+// MarkDelivered marks the event as delivered, it is never returned as pending again.
+func (r *Repository) MarkDelivered(ctx context.Context, id uint64, deliveredAt time.Time) error {
+	_, err := r.DB(ctx).ExecContext(
+		ctx,
+		"UPDATE outbox_event SET attempts = attempts + 1, delivered_at = ?, last_error = NULL WHERE id = ?",
+		helpers.TimeToString(deliveredAt),
+		id,
+	)
+	return err
+}
+
+// MarkFailed records the failed delivery attempt and postpones the next one.
+func (r *Repository) MarkFailed(ctx context.Context, id uint64, nextAttemptAt time.Time, lastError string) error {
+	_, err := r.DB(ctx).ExecContext(
+		ctx,
+		"UPDATE outbox_event SET attempts = attempts + 1, next_attempt_at = ?, last_error = ? WHERE id = ?",
+		helpers.TimeToString(nextAttemptAt),
+		lastError,
+		id,
+	)
+	return err
+}
@@ -0,0 +1,126 @@
+//go:build integration
+
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+)
+
+const testDBFilePath = "sqlite_test.db"
+
+type TestRepositorySuite struct {
+	suite.Suite
+	db *sql.DB
+}
+
+func TestRepositoryByTestSuite(t *testing.T) {
+	suite.Run(t, new(TestRepositorySuite))
+}
+
+func (rts *TestRepositorySuite) SetupSuite() {
+	_ = os.Remove(testDBFilePath)
+
+	dbConn, err := sql.Open("sqlite3", testDBFilePath)
+	if err != nil {
+		rts.Failf("cannot open db connection before tests: %s", err.Error())
+	}
+
+	driver, err := sqlite3.WithInstance(dbConn, &sqlite3.Config{})
+	if err != nil {
+		rts.Failf("cannot init db driver before tests: %s", err.Error())
+	}
+
+	m, err := migrate.NewWithDatabaseInstance(
+		"file://./../../../../db/migrations",
+		"sqlite3",
+		driver,
+	)
+	if err != nil {
+		rts.Failf("cannot init db migrator before tests: %s", err.Error())
+	}
+
+	err = m.Up()
+	if err != nil {
+		rts.Failf("cannot apply db migrations before tests: %s", err.Error())
+	}
+
+	rts.db = dbConn
+}
+func (rts *TestRepositorySuite) TearDownSuite() {
+	err := rts.db.Close()
+	if err != nil {
+		rts.Failf("tear down suite: %s", err.Error())
+	}
+	_ = os.Remove(testDBFilePath)
+}
+
+func (rts *TestRepositorySuite) TestDeliveryLifecycle() {
+	// arrange
+	ctx := context.Background()
+
+	tx, _ := rts.db.BeginTx(ctx, nil)
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	repository := NewRepository(tx)
+
+	now := time.Now().Truncate(time.Second)
+	maxAttempts := 3
+
+	// act & assert
+	first, err := repository.Add(ctx, &event.Event{
+		Type:        event.TypeSaleOrderCreated,
+		AggregateID: 100,
+		Payload:     []byte(`{"id":100}`),
+		CreatedAt:   now,
+	})
+	rts.NoError(err)
+	second, err := repository.Add(ctx, &event.Event{
+		Type:        event.TypeSaleOrderPosted,
+		AggregateID: 100,
+		Payload:     []byte(`{"id":100}`),
+		CreatedAt:   now,
+	})
+	rts.NoError(err)
+
+	pending, err := repository.GetPending(ctx, now, maxAttempts, 10)
+	rts.NoError(err)
+	rts.Equal([]*event.Event{first, second}, pending)
+
+	err = repository.MarkDelivered(ctx, first.ID, now)
+	rts.NoError(err)
+	err = repository.MarkFailed(ctx, second.ID, now.Add(time.Minute), "sink error")
+	rts.NoError(err)
+
+	pending, err = repository.GetPending(ctx, now, maxAttempts, 10)
+	rts.NoError(err)
+	rts.Empty(pending)
+
+	pending, err = repository.GetPending(ctx, now.Add(time.Minute), maxAttempts, 10)
+	rts.NoError(err)
+	rts.Len(pending, 1)
+	rts.Equal(second.ID, pending[0].ID)
+	rts.Equal(1, pending[0].Attempts)
+
+	err = repository.MarkFailed(ctx, second.ID, now, "sink error")
+	rts.NoError(err)
+	err = repository.MarkFailed(ctx, second.ID, now, "sink error")
+	rts.NoError(err)
+
+	pending, err = repository.GetPending(ctx, now.Add(time.Minute), maxAttempts, 10)
+	rts.NoError(err)
+	rts.Empty(pending)
+}
@@ -0,0 +1,126 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/pkg/helpers"
+)
+
+func TestAdd_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	e := &event.Event{
+		Type:        event.TypeSaleOrderCreated,
+		AggregateID: 100,
+		Payload:     []byte(`{"id":100}`),
+		CreatedAt:   time.Now().Truncate(time.Second),
+	}
+	createdAt := helpers.TimeToString(e.CreatedAt)
+
+	mock.
+		ExpectExec("INSERT INTO outbox_event (.+) VALUES").
+		WithArgs("SaleOrderCreated", uint64(100), `{"id":100}`, createdAt, createdAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// act
+	actual, err := repository.Add(ctx, e)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), actual.ID)
+	assert.Equal(t, e.Payload, actual.Payload)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_Error(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	expectedErr := errors.New("db error")
+
+	mock.
+		ExpectExec("INSERT INTO outbox_event").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(expectedErr)
+
+	// act
+	actual, err := repository.Add(ctx, &event.Event{CreatedAt: time.Now()})
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorIs(t, err, expectedErr)
+}
+
+func TestGetPending_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	now := time.Now().Truncate(time.Second)
+
+	queryResult := sqlmock.NewRows([]string{"id", "type", "aggregate_id", "payload", "created_at", "attempts"}).
+		AddRow(1, "SaleOrderPosted", 100, `{"id":100}`, helpers.TimeToString(now), 2)
+
+	mock.
+		ExpectQuery("SELECT (.+) FROM outbox_event WHERE delivered_at IS NULL AND next_attempt_at <= (.+) AND attempts < (.+) ORDER BY id LIMIT").
+		WithArgs(helpers.TimeToString(now), 10, 50).
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.GetPending(ctx, now, 10, 50)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []*event.Event{
+		{
+			ID:          1,
+			Type:        event.TypeSaleOrderPosted,
+			AggregateID: 100,
+			Payload:     []byte(`{"id":100}`),
+			CreatedAt:   now,
+			Attempts:    2,
+		},
+	}, actual)
+}
+
+func TestMarkFailed_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	nextAttemptAt := time.Now()
+
+	mock.
+		ExpectExec("UPDATE outbox_event SET attempts = attempts \\+ 1, next_attempt_at = (.+), last_error = (.+) WHERE id =").
+		WithArgs(helpers.TimeToString(nextAttemptAt), "sink error", uint64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// act
+	err := repository.MarkFailed(ctx, 1, nextAttemptAt, "sink error")
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,64 @@
+package company
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+type Repository struct {
+	*db.TransactionalRepository
+}
+
+func NewRepository(qe db.QueryExecutor) *Repository {
+	return &Repository{
+		TransactionalRepository: db.NewTransactionalRepository(qe),
+	}
+}
+
+func (r *Repository) GetByID(ctx context.Context, id uint64) (*reference.Company, error) {
+	companyDTO := struct {
+		ID     uint64
+		Name   string
+		Status int
+	}{}
+
+	queryResult, err := r.DB(ctx).QueryContext(
+		ctx,
+		"SELECT id, name, status FROM company WHERE id = ?",
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(queryResult *sql.Rows) {
+		_ = queryResult.Close()
+	}(queryResult)
+
+	if !queryResult.Next() {
+		return nil, queryResult.Err()
+	}
+
+	err = queryResult.Scan(&companyDTO.ID, &companyDTO.Name, &companyDTO.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	status := reference.Status(companyDTO.Status)
+	if !slices.Contains(reference.ValidStatuses, status) {
+		return nil, fmt.Errorf("bad status: %d", status)
+	}
+
+	return &reference.Company{
+		Reference: reference.Reference{
+			ID:     companyDTO.ID,
+			Name:   companyDTO.Name,
+			Status: status,
+		},
+	}, nil
+}
@@ -0,0 +1,144 @@
+package company
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+)
+
+func TestGetByID_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	company := &reference.Company{
+		Reference: reference.Reference{
+			ID:     1,
+			Name:   "Company",
+			Status: reference.StatusActive,
+		},
+	}
+
+	queryResult := sqlmock.NewRows([]string{"id", "name", "status"}).
+		AddRow(company.ID, company.Name, company.Status)
+
+	mock.
+		ExpectQuery("SELECT id, name, status FROM company WHERE id = ?").
+		WithArgs(company.ID).
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.GetByID(ctx, company.ID)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, company, actual)
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	id := uint64(1)
+
+	queryResult := sqlmock.NewRows([]string{"id", "name", "status"})
+
+	mock.
+		ExpectQuery("SELECT id, name, status FROM company WHERE id = ?").
+		WithArgs(id).
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.GetByID(ctx, id)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+}
+
+func TestGetByID_QueryError(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	id := uint64(1)
+
+	queryError := errors.New("some query error")
+
+	mock.
+		ExpectQuery("SELECT id, name, status FROM company WHERE id = ?").
+		WithArgs(id).
+		WillReturnError(queryError)
+
+	// act
+	actual, err := repository.GetByID(ctx, id)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, err, queryError.Error())
+}
+
+func TestGetByID_ScanError(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	id := uint64(1)
+
+	queryResult := sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "Company")
+
+	mock.
+		ExpectQuery("SELECT id, name, status FROM company WHERE id = ?").
+		WithArgs(id).
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.GetByID(ctx, id)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, err, "destination arguments in Scan")
+}
+
+func TestGetByID_BadStatus(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	id := uint64(1)
+
+	queryResult := sqlmock.NewRows([]string{"id", "name", "status"}).AddRow(id, "Company", 999)
+
+	mock.
+		ExpectQuery("SELECT id, name, status FROM company WHERE id = ?").
+		WithArgs(id).
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.GetByID(ctx, id)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, err, "bad status: 999")
+}
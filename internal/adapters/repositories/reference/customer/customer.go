@@ -0,0 +1,66 @@
+package customer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+type Repository struct {
+	*db.TransactionalRepository
+}
+
+func NewRepository(qe db.QueryExecutor) *Repository {
+	return &Repository{
+		TransactionalRepository: db.NewTransactionalRepository(qe),
+	}
+}
+
+func (r *Repository) GetByID(ctx context.Context, id uint64) (*reference.Customer, error) {
+	customerDTO := struct {
+		ID     uint64
+		Name   string
+		Status int
+		Email  sql.NullString
+	}{}
+
+	queryResult, err := r.DB(ctx).QueryContext(
+		ctx,
+		"SELECT id, name, status, email FROM customer WHERE id = ?",
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(queryResult *sql.Rows) {
+		_ = queryResult.Close()
+	}(queryResult)
+
+	if !queryResult.Next() {
+		return nil, queryResult.Err()
+	}
+
+	err = queryResult.Scan(&customerDTO.ID, &customerDTO.Name, &customerDTO.Status, &customerDTO.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	status := reference.Status(customerDTO.Status)
+	if !slices.Contains(reference.ValidStatuses, status) {
+		return nil, fmt.Errorf("bad status: %d", status)
+	}
+
+	return &reference.Customer{
+		Reference: reference.Reference{
+			ID:     customerDTO.ID,
+			Name:   customerDTO.Name,
+			Status: status,
+		},
+		Email: customerDTO.Email.String,
+	}, nil
+}
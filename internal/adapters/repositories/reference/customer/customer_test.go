@@ -0,0 +1,145 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+)
+
+func TestGetByID_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	customer := &reference.Customer{
+		Reference: reference.Reference{
+			ID:     1,
+			Name:   "Customer",
+			Status: reference.StatusActive,
+		},
+		Email: "customer@example.com",
+	}
+
+	queryResult := sqlmock.NewRows([]string{"id", "name", "status", "email"}).
+		AddRow(customer.ID, customer.Name, customer.Status, customer.Email)
+
+	mock.
+		ExpectQuery("SELECT id, name, status, email FROM customer WHERE id = ?").
+		WithArgs(customer.ID).
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.GetByID(ctx, customer.ID)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, customer, actual)
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	id := uint64(1)
+
+	queryResult := sqlmock.NewRows([]string{"id", "name", "status", "email"})
+
+	mock.
+		ExpectQuery("SELECT id, name, status, email FROM customer WHERE id = ?").
+		WithArgs(id).
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.GetByID(ctx, id)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+}
+
+func TestGetByID_QueryError(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	id := uint64(1)
+
+	queryError := errors.New("some query error")
+
+	mock.
+		ExpectQuery("SELECT id, name, status, email FROM customer WHERE id = ?").
+		WithArgs(id).
+		WillReturnError(queryError)
+
+	// act
+	actual, err := repository.GetByID(ctx, id)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, err, queryError.Error())
+}
+
+func TestGetByID_ScanError(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	id := uint64(1)
+
+	queryResult := sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "Customer")
+
+	mock.
+		ExpectQuery("SELECT id, name, status, email FROM customer WHERE id = ?").
+		WithArgs(id).
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.GetByID(ctx, id)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, err, "destination arguments in Scan")
+}
+
+func TestGetByID_BadStatus(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	id := uint64(1)
+
+	queryResult := sqlmock.NewRows([]string{"id", "name", "status", "email"}).AddRow(id, "Customer", 999, nil)
+
+	mock.
+		ExpectQuery("SELECT id, name, status, email FROM customer WHERE id = ?").
+		WithArgs(id).
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.GetByID(ctx, id)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, err, "bad status: 999")
+}
@@ -3,7 +3,9 @@ package product
 import (
 	"context"
 	"database/sql"
+	"strings"
 
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
 	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
 )
 
@@ -37,3 +39,43 @@ func (r *Repository) Exists(ctx context.Context, id uint64) (bool, error) {
 
 	return false, queryResult.Err()
 }
+
+func (r *Repository) GetPrices(ctx context.Context, ids []uint64) (map[uint64]money.Money, error) {
+	prices := make(map[uint64]money.Money, len(ids))
+	if len(ids) == 0 {
+		return prices, nil
+	}
+
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	queryResult, err := r.DB(ctx).QueryContext(
+		ctx,
+		"SELECT id, price, currency FROM product WHERE id IN (?"+strings.Repeat(", ?", len(ids)-1)+")",
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(queryResult *sql.Rows) {
+		_ = queryResult.Close()
+	}(queryResult)
+
+	for queryResult.Next() {
+		var (
+			id       uint64
+			price    int64
+			currency string
+		)
+		err = queryResult.Scan(&id, &price, &currency)
+		if err != nil {
+			return nil, err
+		}
+		prices[id] = money.New(price, money.Currency(currency))
+	}
+
+	return prices, queryResult.Err()
+}
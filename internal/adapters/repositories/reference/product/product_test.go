@@ -3,10 +3,13 @@ package product
 import (
 	"context"
 	"errors"
+	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
 )
 
 func TestExists_Success(t *testing.T) {
@@ -83,3 +86,70 @@ func TestExists_Error(t *testing.T) {
 	assert.False(t, actual)
 	assert.ErrorContains(t, err, queryError.Error())
 }
+
+func TestGetPrices_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	ids := []uint64{1, 2, 3}
+
+	queryResult := sqlmock.NewRows([]string{"id", "price", "currency"}).
+		AddRow(1, 10050, "RUB").
+		AddRow(2, 2000, "USD")
+
+	mock.
+		ExpectQuery(regexp.QuoteMeta("SELECT id, price, currency FROM product WHERE id IN (?, ?, ?)")).
+		WithArgs(ids[0], ids[1], ids[2]).
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.GetPrices(ctx, ids)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint64]money.Money{1: money.New(10050, "RUB"), 2: money.New(2000, "USD")}, actual)
+}
+
+func TestGetPrices_Empty(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	// act
+	actual, err := repository.GetPrices(ctx, nil)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPrices_Error(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	queryErr := errors.New("query error")
+
+	mock.
+		ExpectQuery(regexp.QuoteMeta("SELECT id, price, currency FROM product WHERE id IN (?)")).
+		WithArgs(uint64(1)).
+		WillReturnError(queryErr)
+
+	// act
+	actual, err := repository.GetPrices(ctx, []uint64{1})
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorIs(t, err, queryErr)
+}
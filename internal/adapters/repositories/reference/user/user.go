@@ -0,0 +1,101 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+type Repository struct {
+	*db.TransactionalRepository
+}
+
+func NewRepository(qe db.QueryExecutor) *Repository {
+	return &Repository{
+		TransactionalRepository: db.NewTransactionalRepository(qe),
+	}
+}
+
+// GetByAPIKeyHash returns the owner of the API key with the given hex-encoded SHA-256 hash, nil if there is none.
+func (r *Repository) GetByAPIKeyHash(ctx context.Context, keyHash string) (*reference.User, error) {
+	userDTO := struct {
+		ID            uint64
+		Name          string
+		Status        int
+		Role          string
+		CompanyID     uint64
+		CompanyName   string
+		CompanyStatus int
+	}{}
+
+	queryResult, err := r.DB(ctx).QueryContext(
+		ctx,
+		`
+			SELECT u.id, u.name, u.status, u.role, c.id, c.name, c.status
+			FROM user_api_key k
+			JOIN user u ON u.id = k.user_id
+			JOIN company c ON c.id = u.company_id
+			WHERE k.key_hash = ?
+		`,
+		keyHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(queryResult *sql.Rows) {
+		_ = queryResult.Close()
+	}(queryResult)
+
+	if !queryResult.Next() {
+		return nil, queryResult.Err()
+	}
+
+	err = queryResult.Scan(
+		&userDTO.ID,
+		&userDTO.Name,
+		&userDTO.Status,
+		&userDTO.Role,
+		&userDTO.CompanyID,
+		&userDTO.CompanyName,
+		&userDTO.CompanyStatus,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	status := reference.Status(userDTO.Status)
+	if !slices.Contains(reference.ValidStatuses, status) {
+		return nil, fmt.Errorf("bad status: %d", status)
+	}
+
+	companyStatus := reference.Status(userDTO.CompanyStatus)
+	if !slices.Contains(reference.ValidStatuses, companyStatus) {
+		return nil, fmt.Errorf("bad company status: %d", companyStatus)
+	}
+
+	role := reference.Role(userDTO.Role)
+	if !slices.Contains(reference.ValidRoles, role) {
+		return nil, fmt.Errorf("bad role: %s", role)
+	}
+
+	return &reference.User{
+		Reference: reference.Reference{
+			ID:     userDTO.ID,
+			Name:   userDTO.Name,
+			Status: status,
+		},
+		Role: role,
+		Company: reference.Company{
+			Reference: reference.Reference{
+				ID:     userDTO.CompanyID,
+				Name:   userDTO.CompanyName,
+				Status: companyStatus,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,124 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+)
+
+const getByAPIKeyHashQuery = "SELECT u.id, u.name, u.status, u.role, c.id, c.name, c.status FROM user_api_key k JOIN user u ON u.id = k.user_id JOIN company c ON c.id = u.company_id WHERE k.key_hash = ?"
+
+var userColumns = []string{"id", "name", "status", "role", "company_id", "company_name", "company_status"}
+
+func TestGetByAPIKeyHash_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	user := &reference.User{
+		Reference: reference.Reference{
+			ID:     1,
+			Name:   "User",
+			Status: reference.StatusActive,
+		},
+		Role: reference.RoleManager,
+		Company: reference.Company{
+			Reference: reference.Reference{
+				ID:     2,
+				Name:   "Company",
+				Status: reference.StatusActive,
+			},
+		},
+	}
+
+	queryResult := sqlmock.NewRows(userColumns).
+		AddRow(user.ID, user.Name, user.Status, string(user.Role), user.Company.ID, user.Company.Name, user.Company.Status)
+
+	mock.
+		ExpectQuery(getByAPIKeyHashQuery).
+		WithArgs("hash").
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.GetByAPIKeyHash(ctx, "hash")
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, user, actual)
+}
+
+func TestGetByAPIKeyHash_NotFound(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	mock.
+		ExpectQuery(getByAPIKeyHashQuery).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows(userColumns))
+
+	// act
+	actual, err := repository.GetByAPIKeyHash(ctx, "hash")
+
+	// assert
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+}
+
+func TestGetByAPIKeyHash_QueryError(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	queryError := errors.New("some query error")
+
+	mock.
+		ExpectQuery(getByAPIKeyHashQuery).
+		WithArgs("hash").
+		WillReturnError(queryError)
+
+	// act
+	actual, err := repository.GetByAPIKeyHash(ctx, "hash")
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, err, queryError.Error())
+}
+
+func TestGetByAPIKeyHash_BadRole(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	queryResult := sqlmock.NewRows(userColumns).
+		AddRow(1, "User", reference.StatusActive, "root", 2, "Company", reference.StatusActive)
+
+	mock.
+		ExpectQuery(getByAPIKeyHashQuery).
+		WithArgs("hash").
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.GetByAPIKeyHash(ctx, "hash")
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, err, "bad role: root")
+}
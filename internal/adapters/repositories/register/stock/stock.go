@@ -0,0 +1,97 @@
+package stock
+
+import (
+	"context"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+type Repository struct {
+	*db.TransactionalRepository
+}
+
+func NewRepository(qe db.QueryExecutor) *Repository {
+	return &Repository{
+		TransactionalRepository: db.NewTransactionalRepository(qe),
+	}
+}
+
+// Reserve reserves the quantity of the product in the stock of the user company for the sale order.
+// It returns false without changes if the free quantity is not enough.
+func (r *Repository) Reserve(ctx context.Context, saleOrderID uint64, productID uint64, quantity int) (bool, error) {
+	companyID, err := access.CompanyID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	updateResult, err := r.DB(ctx).ExecContext(
+		ctx,
+		"UPDATE stock SET reserved = reserved + ? WHERE company_id = ? AND product_id = ? AND quantity - reserved >= ?",
+		quantity,
+		companyID,
+		productID,
+		quantity,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := updateResult.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	_, err = r.DB(ctx).ExecContext(
+		ctx,
+		`
+			INSERT INTO stock_reservation (sale_order_id, product_id, quantity) VALUES (?, ?, ?)
+			ON CONFLICT (sale_order_id, product_id) DO UPDATE SET quantity = quantity + excluded.quantity
+		`,
+		saleOrderID,
+		productID,
+		quantity,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Release returns all quantities reserved for the sale order to the stock of the user company.
+func (r *Repository) Release(ctx context.Context, saleOrderID uint64) error {
+	companyID, err := access.CompanyID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.DB(ctx).ExecContext(
+		ctx,
+		`
+			UPDATE stock SET reserved = reserved - (
+				SELECT sr.quantity FROM stock_reservation AS sr
+				WHERE sr.sale_order_id = ? AND sr.product_id = stock.product_id
+			)
+			WHERE company_id = ? AND product_id IN (
+				SELECT product_id FROM stock_reservation WHERE sale_order_id = ?
+			)
+		`,
+		saleOrderID,
+		companyID,
+		saleOrderID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.DB(ctx).ExecContext(
+		ctx,
+		"DELETE FROM stock_reservation WHERE sale_order_id = ?",
+		saleOrderID,
+	)
+	return err
+}
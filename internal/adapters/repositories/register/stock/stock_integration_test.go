@@ -0,0 +1,114 @@
+//go:build integration
+
+package stock
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+)
+
+const testDBFilePath = "sqlite_test.db"
+
+type TestRepositorySuite struct {
+	suite.Suite
+	db *sql.DB
+}
+
+func TestRepositoryByTestSuite(t *testing.T) {
+	suite.Run(t, new(TestRepositorySuite))
+}
+
+func (rts *TestRepositorySuite) SetupSuite() {
+	_ = os.Remove(testDBFilePath)
+
+	dbConn, err := sql.Open("sqlite3", testDBFilePath)
+	if err != nil {
+		rts.Failf("cannot open db connection before tests: %s", err.Error())
+	}
+
+	driver, err := sqlite3.WithInstance(dbConn, &sqlite3.Config{})
+	if err != nil {
+		rts.Failf("cannot init db driver before tests: %s", err.Error())
+	}
+
+	m, err := migrate.NewWithDatabaseInstance(
+		"file://./../../../../../db/migrations",
+		"sqlite3",
+		driver,
+	)
+	if err != nil {
+		rts.Failf("cannot init db migrator before tests: %s", err.Error())
+	}
+
+	err = m.Up()
+	if err != nil {
+		rts.Failf("cannot apply db migrations before tests: %s", err.Error())
+	}
+
+	rts.db = dbConn
+}
+
+func (rts *TestRepositorySuite) TearDownSuite() {
+	err := rts.db.Close()
+	if err != nil {
+		rts.Failf("tear down suite: %s", err.Error())
+	}
+	_ = os.Remove(testDBFilePath)
+}
+
+func (rts *TestRepositorySuite) TestReserveAndRelease() {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	tx, _ := rts.db.BeginTx(ctx, nil)
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	repository := NewRepository(tx)
+
+	_, err := tx.ExecContext(ctx, "INSERT INTO product (id, name) VALUES (1, 'Keyboard'), (2, 'Mouse')")
+	rts.NoError(err)
+	_, err = tx.ExecContext(ctx, "INSERT INTO stock (company_id, product_id, quantity) VALUES (1, 1, 5), (1, 2, 1)")
+	rts.NoError(err)
+
+	reserved := func(productID uint64) int {
+		var value int
+		err := tx.QueryRowContext(ctx, "SELECT reserved FROM stock WHERE product_id = ?", productID).Scan(&value)
+		rts.NoError(err)
+		return value
+	}
+
+	// act & assert
+	ok, err := repository.Reserve(ctx, 100, 1, 3)
+	rts.NoError(err)
+	rts.True(ok)
+
+	ok, err = repository.Reserve(ctx, 100, 1, 2)
+	rts.NoError(err)
+	rts.True(ok)
+	rts.Equal(5, reserved(1))
+
+	ok, err = repository.Reserve(ctx, 101, 1, 1)
+	rts.NoError(err)
+	rts.False(ok)
+
+	ok, err = repository.Reserve(ctx, 101, 2, 1)
+	rts.NoError(err)
+	rts.True(ok)
+
+	err = repository.Release(ctx, 100)
+	rts.NoError(err)
+	rts.Equal(0, reserved(1))
+	rts.Equal(1, reserved(2))
+}
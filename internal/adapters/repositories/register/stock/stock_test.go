@@ -0,0 +1,156 @@
+package stock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+)
+
+const testCompanyID = 1
+
+var testUser = &reference.User{
+	Reference: reference.Reference{ID: 5},
+	Company: reference.Company{
+		Reference: reference.Reference{ID: testCompanyID},
+	},
+}
+
+func TestReserve_Success(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	mock.
+		ExpectExec("UPDATE stock SET reserved = reserved \\+ (.+) WHERE company_id = (.+) AND product_id = (.+) AND quantity - reserved >=").
+		WithArgs(3, testCompanyID, uint64(10), 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.
+		ExpectExec("INSERT INTO stock_reservation (.+) ON CONFLICT").
+		WithArgs(uint64(100), uint64(10), 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// act
+	reserved, err := repository.Reserve(ctx, 100, 10, 3)
+
+	// assert
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReserve_NotEnough(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	mock.
+		ExpectExec("UPDATE stock SET reserved").
+		WithArgs(3, testCompanyID, uint64(10), 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// act
+	reserved, err := repository.Reserve(ctx, 100, 10, 3)
+
+	// assert
+	assert.NoError(t, err)
+	assert.False(t, reserved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReserve_UpdateError(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	updateError := errors.New("update error")
+
+	mock.
+		ExpectExec("UPDATE stock SET reserved").
+		WithArgs(3, testCompanyID, uint64(10), 3).
+		WillReturnError(updateError)
+
+	// act
+	reserved, err := repository.Reserve(ctx, 100, 10, 3)
+
+	// assert
+	assert.ErrorIs(t, err, updateError)
+	assert.False(t, reserved)
+}
+
+func TestReserve_NoCompany(t *testing.T) {
+	// arrange
+	db, _, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	// act
+	reserved, err := repository.Reserve(context.Background(), 100, 10, 3)
+
+	// assert
+	assert.ErrorIs(t, err, access.ErrNoCompany)
+	assert.False(t, reserved)
+}
+
+func TestRelease_Success(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	mock.
+		ExpectExec("UPDATE stock SET reserved = reserved - (.+) FROM stock_reservation").
+		WithArgs(uint64(100), testCompanyID, uint64(100)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	mock.
+		ExpectExec("DELETE FROM stock_reservation WHERE sale_order_id = ?").
+		WithArgs(uint64(100)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	// act
+	err := repository.Release(ctx, 100)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRelease_UpdateError(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	updateError := errors.New("update error")
+
+	mock.
+		ExpectExec("UPDATE stock SET reserved").
+		WithArgs(uint64(100), testCompanyID, uint64(100)).
+		WillReturnError(updateError)
+
+	// act
+	err := repository.Release(ctx, 100)
+
+	// assert
+	assert.ErrorIs(t, err, updateError)
+}
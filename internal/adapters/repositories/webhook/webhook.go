@@ -0,0 +1,349 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+	"github.com/kiaplayer/clean-architecture-example/pkg/helpers"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+const selectDeliveryQuery = `
+	SELECT
+		d.id,
+		d.event_id,
+		d.event_type,
+		d.payload,
+		d.status,
+		d.attempts,
+		d.next_attempt_at,
+		d.last_error,
+		d.response_status,
+		d.created_at,
+		d.updated_at,
+		s.id,
+		s.company_id,
+		s.url,
+		s.secret,
+		s.event_types,
+		s.status
+	FROM webhook_delivery AS d
+	INNER JOIN webhook_subscription AS s ON s.id = d.subscription_id
+`
+
+type Repository struct {
+	*db.TransactionalRepository
+}
+
+func NewRepository(qe db.QueryExecutor) *Repository {
+	return &Repository{
+		TransactionalRepository: db.NewTransactionalRepository(qe),
+	}
+}
+
+// GetActiveSubscriptions returns active subscriptions of the company.
+func (r *Repository) GetActiveSubscriptions(ctx context.Context, companyID uint64) ([]*webhook.Subscription, error) {
+	queryResult, err := r.DB(ctx).QueryContext(
+		ctx,
+		"SELECT id, company_id, url, secret, event_types, status FROM webhook_subscription WHERE company_id = ? AND status = ? ORDER BY id",
+		companyID,
+		reference.StatusActive,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(queryResult *sql.Rows) {
+		_ = queryResult.Close()
+	}(queryResult)
+
+	subscriptions := make([]*webhook.Subscription, 0)
+
+	for queryResult.Next() {
+		subscriptionDTO := struct {
+			ID         uint64
+			CompanyID  uint64
+			URL        string
+			Secret     string
+			EventTypes string
+			Status     int
+		}{}
+
+		err = queryResult.Scan(
+			&subscriptionDTO.ID,
+			&subscriptionDTO.CompanyID,
+			&subscriptionDTO.URL,
+			&subscriptionDTO.Secret,
+			&subscriptionDTO.EventTypes,
+			&subscriptionDTO.Status,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		subscription, err := buildSubscription(
+			subscriptionDTO.ID,
+			subscriptionDTO.CompanyID,
+			subscriptionDTO.URL,
+			subscriptionDTO.Secret,
+			subscriptionDTO.EventTypes,
+			subscriptionDTO.Status,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, queryResult.Err()
+}
+
+// AddDelivery stores the pending delivery, a delivery of the same event to the same subscription is stored once.
+func (r *Repository) AddDelivery(ctx context.Context, delivery *webhook.Delivery) error {
+	_, err := r.DB(ctx).ExecContext(
+		ctx,
+		`
+			INSERT INTO webhook_delivery
+				(subscription_id, event_id, event_type, payload, status, next_attempt_at, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (subscription_id, event_id) DO NOTHING
+		`,
+		delivery.Subscription.ID,
+		delivery.EventID,
+		string(delivery.EventType),
+		string(delivery.Payload),
+		delivery.Status,
+		helpers.TimeToString(delivery.NextAttemptAt),
+		helpers.TimeToString(delivery.CreatedAt),
+		helpers.TimeToString(delivery.UpdatedAt),
+	)
+	return err
+}
+
+// GetPendingDeliveries returns pending deliveries due at the time.
+func (r *Repository) GetPendingDeliveries(ctx context.Context, now time.Time, limit int) ([]*webhook.Delivery, error) {
+	return r.queryDeliveries(
+		ctx,
+		selectDeliveryQuery+"WHERE d.status = ? AND d.next_attempt_at <= ? ORDER BY d.id LIMIT ?",
+		webhook.DeliveryStatusPending,
+		helpers.TimeToString(now),
+		limit,
+	)
+}
+
+// ListDeliveries returns the latest deliveries of the user company in the status.
+func (r *Repository) ListDeliveries(ctx context.Context, status webhook.DeliveryStatus, limit int) ([]*webhook.Delivery, error) {
+	companyID, err := access.CompanyID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queryDeliveries(
+		ctx,
+		selectDeliveryQuery+"WHERE s.company_id = ? AND d.status = ? ORDER BY d.id DESC LIMIT ?",
+		companyID,
+		status,
+		limit,
+	)
+}
+
+// GetDeliveryByID returns the delivery of the user company, or nil if there is none.
+func (r *Repository) GetDeliveryByID(ctx context.Context, id uint64) (*webhook.Delivery, error) {
+	companyID, err := access.CompanyID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := r.queryDeliveries(
+		ctx,
+		selectDeliveryQuery+"WHERE d.id = ? AND s.company_id = ?",
+		id,
+		companyID,
+	)
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+
+	return deliveries[0], nil
+}
+
+// UpdateDelivery saves the status and the result of the last attempt of the delivery.
+func (r *Repository) UpdateDelivery(ctx context.Context, delivery *webhook.Delivery) error {
+	_, err := r.DB(ctx).ExecContext(
+		ctx,
+		`
+			UPDATE webhook_delivery
+			SET status = ?, attempts = ?, next_attempt_at = ?, last_error = ?, response_status = ?, updated_at = ?
+			WHERE id = ?
+		`,
+		delivery.Status,
+		delivery.Attempts,
+		helpers.TimeToString(delivery.NextAttemptAt),
+		nullString(delivery.LastError),
+		nullInt(delivery.ResponseStatus),
+		helpers.TimeToString(delivery.UpdatedAt),
+		delivery.ID,
+	)
+	return err
+}
+
+func (r *Repository) queryDeliveries(ctx context.Context, query string, args ...any) ([]*webhook.Delivery, error) {
+	queryResult, err := r.DB(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(queryResult *sql.Rows) {
+		_ = queryResult.Close()
+	}(queryResult)
+
+	deliveries := make([]*webhook.Delivery, 0)
+
+	for queryResult.Next() {
+		deliveryDTO := struct {
+			ID                     uint64
+			EventID                uint64
+			EventType              string
+			Payload                string
+			Status                 int
+			Attempts               int
+			NextAttemptAt          string
+			LastError              sql.NullString
+			ResponseStatus         sql.NullInt64
+			CreatedAt              string
+			UpdatedAt              string
+			SubscriptionID         uint64
+			SubscriptionCompanyID  uint64
+			SubscriptionURL        string
+			SubscriptionSecret     string
+			SubscriptionEventTypes string
+			SubscriptionStatus     int
+		}{}
+
+		err = queryResult.Scan(
+			&deliveryDTO.ID,
+			&deliveryDTO.EventID,
+			&deliveryDTO.EventType,
+			&deliveryDTO.Payload,
+			&deliveryDTO.Status,
+			&deliveryDTO.Attempts,
+			&deliveryDTO.NextAttemptAt,
+			&deliveryDTO.LastError,
+			&deliveryDTO.ResponseStatus,
+			&deliveryDTO.CreatedAt,
+			&deliveryDTO.UpdatedAt,
+			&deliveryDTO.SubscriptionID,
+			&deliveryDTO.SubscriptionCompanyID,
+			&deliveryDTO.SubscriptionURL,
+			&deliveryDTO.SubscriptionSecret,
+			&deliveryDTO.SubscriptionEventTypes,
+			&deliveryDTO.SubscriptionStatus,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		status := webhook.DeliveryStatus(deliveryDTO.Status)
+		if !slices.Contains(webhook.ValidDeliveryStatuses, status) {
+			return nil, fmt.Errorf("bad delivery status: %d", status)
+		}
+
+		nextAttemptAt, err := helpers.StringToTime(deliveryDTO.NextAttemptAt)
+		if err != nil {
+			return nil, err
+		}
+		createdAt, err := helpers.StringToTime(deliveryDTO.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		updatedAt, err := helpers.StringToTime(deliveryDTO.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		subscription, err := buildSubscription(
+			deliveryDTO.SubscriptionID,
+			deliveryDTO.SubscriptionCompanyID,
+			deliveryDTO.SubscriptionURL,
+			deliveryDTO.SubscriptionSecret,
+			deliveryDTO.SubscriptionEventTypes,
+			deliveryDTO.SubscriptionStatus,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, &webhook.Delivery{
+			ID:             deliveryDTO.ID,
+			Subscription:   *subscription,
+			EventID:        deliveryDTO.EventID,
+			EventType:      event.Type(deliveryDTO.EventType),
+			Payload:        []byte(deliveryDTO.Payload),
+			Status:         status,
+			Attempts:       deliveryDTO.Attempts,
+			NextAttemptAt:  nextAttemptAt,
+			LastError:      deliveryDTO.LastError.String,
+			ResponseStatus: int(deliveryDTO.ResponseStatus.Int64),
+			CreatedAt:      createdAt,
+			UpdatedAt:      updatedAt,
+		})
+	}
+
+	return deliveries, queryResult.Err()
+}
+
+// buildSubscription builds the subscription from its columns, event types are stored comma separated.
+func buildSubscription(
+	id uint64,
+	companyID uint64,
+	url string,
+	secret string,
+	eventTypes string,
+	statusValue int,
+) (*webhook.Subscription, error) {
+	status := reference.Status(statusValue)
+	if !slices.Contains(reference.ValidStatuses, status) {
+		return nil, fmt.Errorf("bad subscription status: %d", status)
+	}
+
+	subscription := &webhook.Subscription{
+		ID:        id,
+		CompanyID: companyID,
+		URL:       url,
+		Secret:    secret,
+		Status:    status,
+	}
+	for _, eventType := range strings.Split(eventTypes, ",") {
+		eventType = strings.TrimSpace(eventType)
+		if eventType != "" {
+			subscription.EventTypes = append(subscription.EventTypes, event.Type(eventType))
+		}
+	}
+
+	return subscription, nil
+}
+
+// nullString returns the string for a nullable column.
+func nullString(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// nullInt returns the number for a nullable column.
+func nullInt(value int) any {
+	if value == 0 {
+		return nil
+	}
+	return value
+}
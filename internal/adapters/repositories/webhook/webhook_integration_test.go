@@ -0,0 +1,161 @@
+//go:build integration
+
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+)
+
+const testDBFilePath = "sqlite_test.db"
+
+type TestRepositorySuite struct {
+	suite.Suite
+	db *sql.DB
+}
+
+func TestRepositoryByTestSuite(t *testing.T) {
+	suite.Run(t, new(TestRepositorySuite))
+}
+
+func (rts *TestRepositorySuite) SetupSuite() {
+	_ = os.Remove(testDBFilePath)
+
+	dbConn, err := sql.Open("sqlite3", testDBFilePath)
+	if err != nil {
+		rts.Failf("cannot open db connection before tests: %s", err.Error())
+	}
+
+	driver, err := sqlite3.WithInstance(dbConn, &sqlite3.Config{})
+	if err != nil {
+		rts.Failf("cannot init db driver before tests: %s", err.Error())
+	}
+
+	m, err := migrate.NewWithDatabaseInstance(
+		"file://./../../../../db/migrations",
+		"sqlite3",
+		driver,
+	)
+	if err != nil {
+		rts.Failf("cannot init db migrator before tests: %s", err.Error())
+	}
+
+	err = m.Up()
+	if err != nil {
+		rts.Failf("cannot apply db migrations before tests: %s", err.Error())
+	}
+
+	rts.db = dbConn
+}
+
+func (rts *TestRepositorySuite) TearDownSuite() {
+	err := rts.db.Close()
+	if err != nil {
+		rts.Failf("tear down suite: %s", err.Error())
+	}
+	_ = os.Remove(testDBFilePath)
+}
+
+func (rts *TestRepositorySuite) TestDeliveryLifecycle() {
+	// arrange
+	ctx := context.Background()
+
+	tx, _ := rts.db.BeginTx(ctx, nil)
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	repository := NewRepository(tx)
+
+	_, err := tx.ExecContext(ctx, "INSERT INTO company (id, name) VALUES (2, 'Other company')")
+	rts.NoError(err)
+	_, err = tx.ExecContext(
+		ctx,
+		`
+			INSERT INTO webhook_subscription (id, company_id, url, secret, event_types, status) VALUES
+				(1, 1, 'https://erp.example.com/hook', 'secret', 'SaleOrderCreated,SaleOrderPosted', 0),
+				(2, 1, 'https://old.example.com/hook', 'secret', 'SaleOrderCreated', 1),
+				(3, 2, 'https://other.example.com/hook', 'secret', 'SaleOrderCreated', 0)
+		`,
+	)
+	rts.NoError(err)
+
+	now := time.Now().Truncate(time.Second)
+	userCtx := access.WithUser(ctx, &reference.User{Company: reference.Company{Reference: reference.Reference{ID: 1}}})
+	otherUserCtx := access.WithUser(ctx, &reference.User{Company: reference.Company{Reference: reference.Reference{ID: 2}}})
+
+	// act & assert
+	subscriptions, err := repository.GetActiveSubscriptions(ctx, 1)
+	rts.NoError(err)
+	rts.Equal([]*webhook.Subscription{
+		{
+			ID:         1,
+			CompanyID:  1,
+			URL:        "https://erp.example.com/hook",
+			Secret:     "secret",
+			EventTypes: []event.Type{event.TypeSaleOrderCreated, event.TypeSaleOrderPosted},
+			Status:     reference.StatusActive,
+		},
+	}, subscriptions)
+
+	delivery := &webhook.Delivery{
+		Subscription:  *subscriptions[0],
+		EventID:       10,
+		EventType:     event.TypeSaleOrderCreated,
+		Payload:       []byte(`{"id":10}`),
+		Status:        webhook.DeliveryStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	rts.NoError(repository.AddDelivery(ctx, delivery))
+	rts.NoError(repository.AddDelivery(ctx, delivery))
+
+	pending, err := repository.GetPendingDeliveries(ctx, now, 10)
+	rts.NoError(err)
+	rts.Len(pending, 1)
+	rts.Equal(delivery.Payload, pending[0].Payload)
+	rts.Equal(*subscriptions[0], pending[0].Subscription)
+
+	failed := pending[0]
+	failed.Status = webhook.DeliveryStatusFailed
+	failed.Attempts = 8
+	failed.LastError = "unexpected response status: 500"
+	failed.ResponseStatus = 500
+	failed.UpdatedAt = now.Add(time.Minute)
+	rts.NoError(repository.UpdateDelivery(ctx, failed))
+
+	pending, err = repository.GetPendingDeliveries(ctx, now, 10)
+	rts.NoError(err)
+	rts.Empty(pending)
+
+	listed, err := repository.ListDeliveries(userCtx, webhook.DeliveryStatusFailed, 10)
+	rts.NoError(err)
+	rts.Equal([]*webhook.Delivery{failed}, listed)
+
+	listed, err = repository.ListDeliveries(otherUserCtx, webhook.DeliveryStatusFailed, 10)
+	rts.NoError(err)
+	rts.Empty(listed)
+
+	found, err := repository.GetDeliveryByID(userCtx, failed.ID)
+	rts.NoError(err)
+	rts.Equal(failed, found)
+
+	found, err = repository.GetDeliveryByID(otherUserCtx, failed.ID)
+	rts.NoError(err)
+	rts.Nil(found)
+}
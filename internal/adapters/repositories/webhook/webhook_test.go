@@ -0,0 +1,215 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+	"github.com/kiaplayer/clean-architecture-example/pkg/helpers"
+)
+
+const testCompanyID = 1
+
+var testUser = &reference.User{
+	Reference: reference.Reference{ID: 5},
+	Company: reference.Company{
+		Reference: reference.Reference{ID: testCompanyID},
+	},
+}
+
+var deliveryColumns = []string{
+	"id", "event_id", "event_type", "payload", "status", "attempts", "next_attempt_at", "last_error",
+	"response_status", "created_at", "updated_at", "s.id", "s.company_id", "s.url", "s.secret", "s.event_types",
+	"s.status",
+}
+
+func TestGetActiveSubscriptions_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	queryResult := sqlmock.NewRows([]string{"id", "company_id", "url", "secret", "event_types", "status"}).
+		AddRow(1, testCompanyID, "https://erp.example.com/hook", "secret", "SaleOrderCreated, SaleOrderPosted", 0)
+
+	mock.
+		ExpectQuery("SELECT (.+) FROM webhook_subscription WHERE company_id = (.+) AND status = (.+) ORDER BY id").
+		WithArgs(testCompanyID, reference.StatusActive).
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.GetActiveSubscriptions(ctx, testCompanyID)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []*webhook.Subscription{
+		{
+			ID:         1,
+			CompanyID:  testCompanyID,
+			URL:        "https://erp.example.com/hook",
+			Secret:     "secret",
+			EventTypes: []event.Type{event.TypeSaleOrderCreated, event.TypeSaleOrderPosted},
+			Status:     reference.StatusActive,
+		},
+	}, actual)
+}
+
+func TestGetActiveSubscriptions_BadStatus(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	queryResult := sqlmock.NewRows([]string{"id", "company_id", "url", "secret", "event_types", "status"}).
+		AddRow(1, testCompanyID, "https://erp.example.com/hook", "secret", "SaleOrderCreated", 999)
+
+	mock.
+		ExpectQuery("SELECT (.+) FROM webhook_subscription").
+		WithArgs(testCompanyID, reference.StatusActive).
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.GetActiveSubscriptions(ctx, testCompanyID)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, err, "bad subscription status: 999")
+}
+
+func TestListDeliveries_Success(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	date := time.Date(2024, 5, 1, 10, 20, 30, 0, time.Local)
+	dateString := helpers.TimeToString(date)
+
+	queryResult := sqlmock.NewRows(deliveryColumns).
+		AddRow(
+			1, 10, "SaleOrderCreated", `{"id":10}`, 2, 8, dateString, "unexpected response status: 500",
+			500, dateString, dateString, 1, testCompanyID, "https://erp.example.com/hook", "secret", "SaleOrderCreated", 0,
+		)
+
+	mock.
+		ExpectQuery("SELECT (.+) FROM webhook_delivery AS d (.+) WHERE s.company_id = (.+) AND d.status = (.+) ORDER BY d.id DESC LIMIT").
+		WithArgs(testCompanyID, webhook.DeliveryStatusFailed, 100).
+		WillReturnRows(queryResult)
+
+	// act
+	actual, err := repository.ListDeliveries(ctx, webhook.DeliveryStatusFailed, 100)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []*webhook.Delivery{
+		{
+			ID: 1,
+			Subscription: webhook.Subscription{
+				ID:         1,
+				CompanyID:  testCompanyID,
+				URL:        "https://erp.example.com/hook",
+				Secret:     "secret",
+				EventTypes: []event.Type{event.TypeSaleOrderCreated},
+				Status:     reference.StatusActive,
+			},
+			EventID:        10,
+			EventType:      event.TypeSaleOrderCreated,
+			Payload:        []byte(`{"id":10}`),
+			Status:         webhook.DeliveryStatusFailed,
+			Attempts:       8,
+			NextAttemptAt:  date,
+			LastError:      "unexpected response status: 500",
+			ResponseStatus: 500,
+			CreatedAt:      date,
+			UpdatedAt:      date,
+		},
+	}, actual)
+}
+
+func TestListDeliveries_NoCompany(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, _, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	// act
+	actual, err := repository.ListDeliveries(ctx, webhook.DeliveryStatusFailed, 100)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorIs(t, err, access.ErrNoCompany)
+}
+
+func TestGetDeliveryByID_NotFound(t *testing.T) {
+	// arrange
+	ctx := access.WithUser(context.Background(), testUser)
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	mock.
+		ExpectQuery("SELECT (.+) FROM webhook_delivery AS d (.+) WHERE d.id = (.+) AND s.company_id =").
+		WithArgs(uint64(1), testCompanyID).
+		WillReturnRows(sqlmock.NewRows(deliveryColumns))
+
+	// act
+	actual, err := repository.GetDeliveryByID(ctx, 1)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+}
+
+func TestUpdateDelivery_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	repository := NewRepository(db)
+
+	now := time.Now()
+	delivery := &webhook.Delivery{
+		ID:            1,
+		Status:        webhook.DeliveryStatusDelivered,
+		Attempts:      1,
+		NextAttemptAt: now,
+		UpdatedAt:     now,
+	}
+
+	mock.
+		ExpectExec("UPDATE webhook_delivery SET (.+) WHERE id =").
+		WithArgs(
+			webhook.DeliveryStatusDelivered,
+			1,
+			helpers.TimeToString(now),
+			nil,
+			nil,
+			helpers.TimeToString(now),
+			uint64(1),
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// act
+	err := repository.UpdateDelivery(ctx, delivery)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,20 @@
+package logger
+
+import (
+	"context"
+	"log"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+)
+
+// Sink writes events to the standard logger.
+type Sink struct{}
+
+func NewSink() *Sink {
+	return &Sink{}
+}
+
+func (s *Sink) Handle(_ context.Context, e *event.Event) error {
+	log.Printf("Event %d %s (aggregate %d): %s", e.ID, e.Type, e.AggregateID, e.Payload)
+	return nil
+}
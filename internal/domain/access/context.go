@@ -0,0 +1,47 @@
+package access
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+)
+
+type userKey struct{}
+
+// WithUser returns a copy of ctx carrying the authenticated user.
+func WithUser(ctx context.Context, user *reference.User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFromContext returns the authenticated user, nil for anonymous requests.
+func UserFromContext(ctx context.Context) *reference.User {
+	if user, ok := ctx.Value(userKey{}).(*reference.User); ok {
+		return user
+	}
+	return nil
+}
+
+// ErrNoCompany is returned by CompanyID when there is no user with a company in the context.
+var ErrNoCompany = errors.New("no company in context")
+
+// CompanyID returns the company of the authenticated user, repositories scope their queries to it.
+func CompanyID(ctx context.Context) (uint64, error) {
+	user := UserFromContext(ctx)
+	if user == nil || user.Company.ID == 0 {
+		return 0, ErrNoCompany
+	}
+	return user.Company.ID, nil
+}
+
+// ErrNoUser is returned by UserID when there is no user in the context.
+var ErrNoUser = errors.New("no user in context")
+
+// UserID returns the authenticated user, repositories of per-user data scope their queries to it.
+func UserID(ctx context.Context) (uint64, error) {
+	user := UserFromContext(ctx)
+	if user == nil || user.ID == 0 {
+		return 0, ErrNoUser
+	}
+	return user.ID, nil
+}
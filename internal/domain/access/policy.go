@@ -0,0 +1,78 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+)
+
+type Permission string
+
+const (
+	PermissionReadOrders   Permission = "orders:read"
+	PermissionCreateOrders Permission = "orders:create"
+	PermissionUpdateOrders Permission = "orders:update"
+	PermissionPostOrders   Permission = "orders:post"
+	PermissionDeleteOrders Permission = "orders:delete"
+
+	PermissionManageWebhooks Permission = "webhooks:manage"
+)
+
+// DefaultRolePermissions grants every role the permissions of the previous one plus its own.
+var DefaultRolePermissions = map[reference.Role][]Permission{
+	reference.RoleViewer: {
+		PermissionReadOrders,
+	},
+	reference.RoleSalesAgent: {
+		PermissionReadOrders,
+		PermissionCreateOrders,
+		PermissionUpdateOrders,
+	},
+	reference.RoleManager: {
+		PermissionReadOrders,
+		PermissionCreateOrders,
+		PermissionUpdateOrders,
+		PermissionPostOrders,
+	},
+	reference.RoleAdmin: {
+		PermissionReadOrders,
+		PermissionCreateOrders,
+		PermissionUpdateOrders,
+		PermissionPostOrders,
+		PermissionDeleteOrders,
+		PermissionManageWebhooks,
+	},
+}
+
+// Policy decides whether the user in the context may perform an operation.
+type Policy struct {
+	rolePermissions map[reference.Role][]Permission
+}
+
+func NewPolicy(rolePermissions map[reference.Role][]Permission) *Policy {
+	return &Policy{
+		rolePermissions: rolePermissions,
+	}
+}
+
+// Authorize returns ErrUnauthorized for anonymous users, inactive users or users of an inactive company,
+// and ErrForbidden if the user role lacks the permission.
+func (p *Policy) Authorize(ctx context.Context, permission Permission) error {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return errors.NewErrUnauthorized("authentication required", nil)
+	}
+	if user.Status != reference.StatusActive {
+		return errors.NewErrUnauthorized(fmt.Sprintf("user is deleted: %d", user.ID), nil)
+	}
+	if user.Company.Status != reference.StatusActive {
+		return errors.NewErrUnauthorized(fmt.Sprintf("company is deleted: %d", user.Company.ID), nil)
+	}
+	if !slices.Contains(p.rolePermissions[user.Role], permission) {
+		return errors.NewErrForbidden(fmt.Sprintf("role %s has no permission %s", user.Role, permission), nil)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package access
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+)
+
+func TestPolicy_Authorize(t *testing.T) {
+	user := func(role reference.Role, status reference.Status) *reference.User {
+		return &reference.User{
+			Reference: reference.Reference{ID: 1, Name: "User", Status: status},
+			Role:      role,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		user       *reference.User
+		permission Permission
+		wantErr    error
+	}{
+		{
+			name:       "anonymous",
+			user:       nil,
+			permission: PermissionReadOrders,
+			wantErr:    &domainerrors.ErrUnauthorized{},
+		},
+		{
+			name:       "deleted user",
+			user:       user(reference.RoleAdmin, reference.StatusDeleted),
+			permission: PermissionReadOrders,
+			wantErr:    &domainerrors.ErrUnauthorized{},
+		},
+		{
+			name: "deleted company",
+			user: &reference.User{
+				Reference: reference.Reference{ID: 1, Name: "User"},
+				Role:      reference.RoleAdmin,
+				Company:   reference.Company{Reference: reference.Reference{ID: 2, Status: reference.StatusDeleted}},
+			},
+			permission: PermissionReadOrders,
+			wantErr:    &domainerrors.ErrUnauthorized{},
+		},
+		{
+			name:       "viewer reads",
+			user:       user(reference.RoleViewer, reference.StatusActive),
+			permission: PermissionReadOrders,
+		},
+		{
+			name:       "viewer creates",
+			user:       user(reference.RoleViewer, reference.StatusActive),
+			permission: PermissionCreateOrders,
+			wantErr:    &domainerrors.ErrForbidden{},
+		},
+		{
+			name:       "sales agent posts",
+			user:       user(reference.RoleSalesAgent, reference.StatusActive),
+			permission: PermissionPostOrders,
+			wantErr:    &domainerrors.ErrForbidden{},
+		},
+		{
+			name:       "manager posts",
+			user:       user(reference.RoleManager, reference.StatusActive),
+			permission: PermissionPostOrders,
+		},
+		{
+			name:       "manager deletes",
+			user:       user(reference.RoleManager, reference.StatusActive),
+			permission: PermissionDeleteOrders,
+			wantErr:    &domainerrors.ErrForbidden{},
+		},
+		{
+			name:       "admin deletes",
+			user:       user(reference.RoleAdmin, reference.StatusActive),
+			permission: PermissionDeleteOrders,
+		},
+		{
+			name:       "manager manages webhooks",
+			user:       user(reference.RoleManager, reference.StatusActive),
+			permission: PermissionManageWebhooks,
+			wantErr:    &domainerrors.ErrForbidden{},
+		},
+		{
+			name:       "admin manages webhooks",
+			user:       user(reference.RoleAdmin, reference.StatusActive),
+			permission: PermissionManageWebhooks,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctx := context.Background()
+			if tt.user != nil {
+				ctx = WithUser(ctx, tt.user)
+			}
+			policy := NewPolicy(DefaultRolePermissions)
+
+			// act
+			err := policy.Authorize(ctx, tt.permission)
+
+			// assert
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.IsType(t, tt.wantErr, err)
+			}
+		})
+	}
+}
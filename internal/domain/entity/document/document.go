@@ -20,6 +20,28 @@ var ValidStatuses = []Status{
 	StatusDeleted,
 }
 
+var statusNames = map[Status]string{
+	StatusDraft:   "draft",
+	StatusPosted:  "posted",
+	StatusDeleted: "deleted",
+}
+
+func ParseStatus(name string) (Status, bool) {
+	for status, statusName := range statusNames {
+		if statusName == name {
+			return status, true
+		}
+	}
+	return 0, false
+}
+
+func (s Status) String() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
 type Document struct {
 	ID            uint64
 	Number        string
@@ -29,4 +51,8 @@ type Document struct {
 	Company       reference.Company
 	AppendUser    *reference.User
 	ChangeUser    *reference.User
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	// Version is incremented on every change, it guards against lost updates.
+	Version uint64
 }
@@ -1,16 +1,39 @@
 package document
 
-import "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+import (
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+)
+
+// MaxLineQuantity is the max quantity of a sale order line, larger values are rejected as input errors.
+const MaxLineQuantity = 1_000_000
 
 type SaleOrder struct {
 	Document
 	Customer reference.Customer
+	Currency money.Currency
 	Products []SaleOrderProduct
 }
 
+// Total returns the order amount calculated from its lines, line prices are in the order currency.
+func (o *SaleOrder) Total() money.Money {
+	total := money.New(0, o.Currency)
+	for _, product := range o.Products {
+		total.Amount += product.Amount().Amount
+	}
+	return total
+}
+
 type SaleOrderProduct struct {
 	ID       uint64
 	Product  reference.Product
 	Quantity int
-	Price    float32
+	Price    money.Money
+	// ManualPrice is set when the price is supplied by the client instead of the product price list.
+	ManualPrice bool
+}
+
+// Amount returns the line amount.
+func (p SaleOrderProduct) Amount() money.Money {
+	return p.Price.Multiply(p.Quantity)
 }
@@ -0,0 +1,47 @@
+package document
+
+import "time"
+
+type SaleOrderSortField string
+
+const (
+	SaleOrderSortByDate   SaleOrderSortField = "date"
+	SaleOrderSortByNumber SaleOrderSortField = "number"
+)
+
+var ValidSaleOrderSortFields = []SaleOrderSortField{
+	SaleOrderSortByDate,
+	SaleOrderSortByNumber,
+}
+
+type SaleOrderFilter struct {
+	DateFrom     time.Time
+	DateTo       time.Time
+	Status       *Status
+	CustomerID   uint64
+	NumberPrefix string
+}
+
+type SaleOrderSort struct {
+	Field SaleOrderSortField
+	Desc  bool
+}
+
+type SaleOrderListQuery struct {
+	Filter SaleOrderFilter
+	Sort   SaleOrderSort
+	Limit  int
+	Cursor string
+}
+
+// SaleOrderListCursor points to the last sale order of the previous page.
+type SaleOrderListCursor struct {
+	Date   time.Time
+	Number string
+	ID     uint64
+}
+
+type SaleOrderList struct {
+	Items      []*SaleOrder
+	NextCursor string
+}
@@ -0,0 +1,36 @@
+package event
+
+import "time"
+
+type Type string
+
+const (
+	TypeSaleOrderCreated  Type = "SaleOrderCreated"
+	TypeSaleOrderUpdated  Type = "SaleOrderUpdated"
+	TypeSaleOrderPosted   Type = "SaleOrderPosted"
+	TypeSaleOrderUnposted Type = "SaleOrderUnposted"
+	TypeSaleOrderDeleted  Type = "SaleOrderDeleted"
+)
+
+// SaleOrderPayload is the payload of sale order events.
+type SaleOrderPayload struct {
+	ID         uint64    `json:"id"`
+	Number     string    `json:"number"`
+	Date       time.Time `json:"date"`
+	Status     string    `json:"status"`
+	CompanyID  uint64    `json:"company_id"`
+	CustomerID uint64    `json:"customer_id"`
+	Currency   string    `json:"currency"`
+	Total      int64     `json:"total"`
+}
+
+// Event is a domain event stored in the outbox until it is delivered to all sinks.
+type Event struct {
+	ID          uint64
+	Type        Type
+	AggregateID uint64
+	// Payload is the JSON encoded event data.
+	Payload   []byte
+	CreatedAt time.Time
+	Attempts  int
+}
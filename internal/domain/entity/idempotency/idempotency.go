@@ -0,0 +1,13 @@
+package idempotency
+
+import "time"
+
+// Record is the stored result of a use case call made with an idempotency key.
+type Record struct {
+	Key string
+	// RequestHash is the hex-encoded SHA-256 of the use case input, the key is accepted only with the same input.
+	RequestHash string
+	// Result is the JSON with the ID of the call result, e.g. {"id":10}, the result is loaded by it for every repeat of the call.
+	Result    []byte
+	CreatedAt time.Time
+}
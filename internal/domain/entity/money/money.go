@@ -0,0 +1,80 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Currency string
+
+const DefaultCurrency Currency = "RUB"
+
+// minorUnitDigits is the number of digits after the decimal point, all supported currencies have two.
+const minorUnitDigits = 2
+
+// Money is an exact amount of money in minor units (kopecks, cents) of its currency.
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+func New(amount int64, currency Currency) Money {
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+	}
+}
+
+// Parse converts a decimal string like "150.5" to money.
+func Parse(value string, currency Currency) (Money, error) {
+	integerPart, fractionalPart, _ := strings.Cut(value, ".")
+	if len(fractionalPart) > minorUnitDigits {
+		return Money{}, fmt.Errorf("bad amount: %s: too many decimal places", value)
+	}
+
+	digits := strings.TrimLeft(integerPart, "+-")
+	if digits == "" || strings.ContainsAny(digits+fractionalPart, "+-") {
+		return Money{}, fmt.Errorf("bad amount: %s", value)
+	}
+
+	amount, err := strconv.ParseInt(
+		integerPart+fractionalPart+strings.Repeat("0", minorUnitDigits-len(fractionalPart)),
+		10,
+		64,
+	)
+	if err != nil {
+		return Money{}, fmt.Errorf("bad amount: %s: %w", value, errors.Unwrap(err))
+	}
+
+	return New(amount, currency), nil
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("currency mismatch: %s and %s", m.Currency, other.Currency)
+	}
+	return New(m.Amount+other.Amount, m.Currency), nil
+}
+
+func (m Money) Multiply(quantity int) Money {
+	return New(m.Amount*int64(quantity), m.Currency)
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// String returns the amount as a decimal string like "150.50", without the currency.
+func (m Money) String() string {
+	sign, amount := "", m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	unit := int64(1)
+	for range minorUnitDigits {
+		unit *= 10
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/unit, minorUnitDigits, amount%unit)
+}
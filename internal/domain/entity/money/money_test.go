@@ -0,0 +1,86 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Money
+		wantErr string
+	}{
+		{name: "integer", value: "150", want: New(15000, DefaultCurrency)},
+		{name: "one decimal place", value: "150.5", want: New(15050, DefaultCurrency)},
+		{name: "two decimal places", value: "0.07", want: New(7, DefaultCurrency)},
+		{name: "negative", value: "-1.25", want: New(-125, DefaultCurrency)},
+		{name: "trailing point", value: "3.", want: New(300, DefaultCurrency)},
+		{name: "too many decimal places", value: "1.005", wantErr: "bad amount: 1.005: too many decimal places"},
+		{name: "exponent", value: "1e3", wantErr: "bad amount: 1e3"},
+		{name: "empty", value: "", wantErr: "bad amount: "},
+		{name: "no integer part", value: ".5", wantErr: "bad amount: .5"},
+		{name: "sign in fraction", value: "1.-5", wantErr: "bad amount: 1.-5"},
+		{name: "overflow", value: "100000000000000000000", wantErr: "value out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.value, DefaultCurrency)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		want  string
+	}{
+		{name: "zero", money: New(0, DefaultCurrency), want: "0.00"},
+		{name: "minor units only", money: New(7, DefaultCurrency), want: "0.07"},
+		{name: "major and minor units", money: New(15050, DefaultCurrency), want: "150.50"},
+		{name: "negative", money: New(-125, DefaultCurrency), want: "-1.25"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.money.String())
+		})
+	}
+}
+
+func TestAdd(t *testing.T) {
+	// arrange
+	a := New(150, "RUB")
+	b := New(25, "RUB")
+
+	// act
+	actual, err := a.Add(b)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, New(175, "RUB"), actual)
+}
+
+func TestAdd_CurrencyMismatch(t *testing.T) {
+	// arrange
+	a := New(150, "RUB")
+	b := New(25, "USD")
+
+	// act
+	_, err := a.Add(b)
+
+	// assert
+	assert.EqualError(t, err, "currency mismatch: RUB and USD")
+}
+
+func TestMultiply(t *testing.T) {
+	assert.Equal(t, New(4515, "RUB"), New(1505, "RUB").Multiply(3))
+}
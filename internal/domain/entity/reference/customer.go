@@ -2,4 +2,6 @@ package reference
 
 type Customer struct {
 	Reference
+	// Email is the address for order notifications, empty if the customer has none.
+	Email string
 }
@@ -1,5 +1,23 @@
 package reference
 
+type Role string
+
+const (
+	RoleViewer     Role = "viewer"
+	RoleSalesAgent Role = "sales_agent"
+	RoleManager    Role = "manager"
+	RoleAdmin      Role = "admin"
+)
+
+var ValidRoles = []Role{
+	RoleViewer,
+	RoleSalesAgent,
+	RoleManager,
+	RoleAdmin,
+}
+
 type User struct {
 	Reference
+	Role    Role
+	Company Company
 }
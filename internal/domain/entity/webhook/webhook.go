@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+)
+
+// Subscription is an external endpoint notified about company events of the given types.
+type Subscription struct {
+	ID        uint64
+	CompanyID uint64
+	URL       string
+	// Secret is the HMAC-SHA256 key of payload signatures.
+	Secret     string
+	EventTypes []event.Type
+	Status     reference.Status
+}
+
+type DeliveryStatus int
+
+const (
+	DeliveryStatusPending   DeliveryStatus = 0
+	DeliveryStatusDelivered DeliveryStatus = 1
+	DeliveryStatusFailed    DeliveryStatus = 2
+)
+
+var ValidDeliveryStatuses = []DeliveryStatus{
+	DeliveryStatusPending,
+	DeliveryStatusDelivered,
+	DeliveryStatusFailed,
+}
+
+var deliveryStatusNames = map[DeliveryStatus]string{
+	DeliveryStatusPending:   "pending",
+	DeliveryStatusDelivered: "delivered",
+	DeliveryStatusFailed:    "failed",
+}
+
+func ParseDeliveryStatus(name string) (DeliveryStatus, bool) {
+	for status, statusName := range deliveryStatusNames {
+		if statusName == name {
+			return status, true
+		}
+	}
+	return 0, false
+}
+
+func (s DeliveryStatus) String() string {
+	if name, ok := deliveryStatusNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Delivery is a delivery of one event to one subscription together with the result of its last attempt.
+type Delivery struct {
+	ID           uint64
+	Subscription Subscription
+	EventID      uint64
+	EventType    event.Type
+	// Payload is the JSON request body, it is signed with the subscription secret on every attempt.
+	Payload        []byte
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastError      string
+	ResponseStatus int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
@@ -17,6 +17,14 @@ func (e *AppError) Error() string {
 	return fmt.Sprintf("%s (cause: %s)", e.reason, e.cause.Error())
 }
 
+func (e *AppError) Unwrap() error {
+	return e.cause
+}
+
+func (e *AppError) Reason() string {
+	return e.reason
+}
+
 func NewAppError(reason string, cause error) AppError {
 	return AppError{
 		reason: reason,
@@ -0,0 +1,82 @@
+package errors
+
+// ErrNotFound is returned when a requested entity does not exist.
+type ErrNotFound struct{ AppError }
+
+func NewErrNotFound(reason string, cause error) *ErrNotFound {
+	return &ErrNotFound{NewAppError(reason, cause)}
+}
+
+// FieldError describes a problem with a single input field, Field is a path like products[2].quantity.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ErrValidation is returned when input data or an entity state breaks business rules.
+type ErrValidation struct {
+	AppError
+	fields []FieldError
+}
+
+func NewErrValidation(reason string, cause error, fields ...FieldError) *ErrValidation {
+	return &ErrValidation{
+		AppError: NewAppError(reason, cause),
+		fields:   fields,
+	}
+}
+
+func (e *ErrValidation) Fields() []FieldError {
+	return e.fields
+}
+
+// ErrConflict is returned when an operation conflicts with the current state of an entity.
+type ErrConflict struct{ AppError }
+
+func NewErrConflict(reason string, cause error) *ErrConflict {
+	return &ErrConflict{NewAppError(reason, cause)}
+}
+
+// ErrVersionConflict is returned when an entity was changed by someone else since the client has read it.
+type ErrVersionConflict struct{ AppError }
+
+func NewErrVersionConflict(reason string, cause error) *ErrVersionConflict {
+	return &ErrVersionConflict{NewAppError(reason, cause)}
+}
+
+// ErrPreconditionRequired is returned when a conditional request has no precondition,
+// e.g. a change of an entity without its version.
+type ErrPreconditionRequired struct{ AppError }
+
+func NewErrPreconditionRequired(reason string, cause error) *ErrPreconditionRequired {
+	return &ErrPreconditionRequired{NewAppError(reason, cause)}
+}
+
+// ErrUnprocessable is returned when a well-formed request cannot be processed,
+// e.g. an idempotency key is reused with a different request.
+type ErrUnprocessable struct{ AppError }
+
+func NewErrUnprocessable(reason string, cause error) *ErrUnprocessable {
+	return &ErrUnprocessable{NewAppError(reason, cause)}
+}
+
+// ErrUnauthorized is returned when the request has no valid credentials.
+type ErrUnauthorized struct{ AppError }
+
+func NewErrUnauthorized(reason string, cause error) *ErrUnauthorized {
+	return &ErrUnauthorized{NewAppError(reason, cause)}
+}
+
+// ErrForbidden is returned when the current user is not allowed to perform an operation.
+type ErrForbidden struct{ AppError }
+
+func NewErrForbidden(reason string, cause error) *ErrForbidden {
+	return &ErrForbidden{NewAppError(reason, cause)}
+}
+
+// ErrUnavailable is returned when a dependency is temporarily unavailable and the operation may be retried.
+type ErrUnavailable struct{ AppError }
+
+func NewErrUnavailable(reason string, cause error) *ErrUnavailable {
+	return &ErrUnavailable{NewAppError(reason, cause)}
+}
@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "without cause",
+			err:  NewErrValidation("bad status", nil),
+			want: "bad status",
+		},
+		{
+			name: "with cause",
+			err:  NewErrUnavailable("db is busy", errors.New("database is locked")),
+			want: "db is busy (cause: database is locked)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.err.Error())
+		})
+	}
+}
+
+func TestAppError_Unwrap(t *testing.T) {
+	// arrange
+	cause := errors.New("no rows")
+	err := fmt.Errorf("get order: %w", NewErrNotFound("sale order not found: 1", cause))
+
+	// act
+	var errNotFound *ErrNotFound
+	isNotFound := errors.As(err, &errNotFound)
+
+	// assert
+	assert.True(t, isNotFound)
+	assert.Equal(t, "sale order not found: 1", errNotFound.Reason())
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestAppError_As(t *testing.T) {
+	// arrange
+	err := NewErrConflict("sale order was changed", nil)
+
+	// act
+	var errValidation *ErrValidation
+	var errConflict *ErrConflict
+	isValidation := errors.As(err, &errValidation)
+	isConflict := errors.As(err, &errConflict)
+
+	// assert
+	assert.False(t, isValidation)
+	assert.True(t, isConflict)
+}
+
+func TestErrValidation_Fields(t *testing.T) {
+	// arrange
+	fields := []FieldError{
+		{Field: "customer_id", Message: "is required"},
+		{Field: "products[0].quantity", Message: "must be positive"},
+	}
+
+	// act
+	err := NewErrValidation("bad order data", nil, fields...)
+
+	// assert
+	assert.Equal(t, "bad order data", err.Error())
+	assert.Equal(t, fields, err.Fields())
+}
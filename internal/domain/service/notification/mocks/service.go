@@ -0,0 +1,184 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -package=notification -source=service.go -destination=mocks/service.go
+//
+
+// Package notification is a generated GoMock package.
+package notification
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	reference "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	mail "github.com/kiaplayer/clean-architecture-example/pkg/mail"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockcustomerRepository is a mock of customerRepository interface.
+type MockcustomerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockcustomerRepositoryMockRecorder
+}
+
+// MockcustomerRepositoryMockRecorder is the mock recorder for MockcustomerRepository.
+type MockcustomerRepositoryMockRecorder struct {
+	mock *MockcustomerRepository
+}
+
+// NewMockcustomerRepository creates a new mock instance.
+func NewMockcustomerRepository(ctrl *gomock.Controller) *MockcustomerRepository {
+	mock := &MockcustomerRepository{ctrl: ctrl}
+	mock.recorder = &MockcustomerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcustomerRepository) EXPECT() *MockcustomerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockcustomerRepository) GetByID(ctx context.Context, id uint64) (*reference.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*reference.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockcustomerRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockcustomerRepository)(nil).GetByID), ctx, id)
+}
+
+// MocksentRepository is a mock of sentRepository interface.
+type MocksentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MocksentRepositoryMockRecorder
+}
+
+// MocksentRepositoryMockRecorder is the mock recorder for MocksentRepository.
+type MocksentRepositoryMockRecorder struct {
+	mock *MocksentRepository
+}
+
+// NewMocksentRepository creates a new mock instance.
+func NewMocksentRepository(ctrl *gomock.Controller) *MocksentRepository {
+	mock := &MocksentRepository{ctrl: ctrl}
+	mock.recorder = &MocksentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksentRepository) EXPECT() *MocksentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// IsSent mocks base method.
+func (m *MocksentRepository) IsSent(ctx context.Context, eventID uint64, recipient string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSent", ctx, eventID, recipient)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSent indicates an expected call of IsSent.
+func (mr *MocksentRepositoryMockRecorder) IsSent(ctx, eventID, recipient any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSent", reflect.TypeOf((*MocksentRepository)(nil).IsSent), ctx, eventID, recipient)
+}
+
+// MarkSent mocks base method.
+func (m *MocksentRepository) MarkSent(ctx context.Context, eventID uint64, recipient string, sentAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, eventID, recipient, sentAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MocksentRepositoryMockRecorder) MarkSent(ctx, eventID, recipient, sentAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MocksentRepository)(nil).MarkSent), ctx, eventID, recipient, sentAt)
+}
+
+// MocktimeGenerator is a mock of timeGenerator interface.
+type MocktimeGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MocktimeGeneratorMockRecorder
+}
+
+// MocktimeGeneratorMockRecorder is the mock recorder for MocktimeGenerator.
+type MocktimeGeneratorMockRecorder struct {
+	mock *MocktimeGenerator
+}
+
+// NewMocktimeGenerator creates a new mock instance.
+func NewMocktimeGenerator(ctrl *gomock.Controller) *MocktimeGenerator {
+	mock := &MocktimeGenerator{ctrl: ctrl}
+	mock.recorder = &MocktimeGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktimeGenerator) EXPECT() *MocktimeGeneratorMockRecorder {
+	return m.recorder
+}
+
+// NowDate mocks base method.
+func (m *MocktimeGenerator) NowDate() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NowDate")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// NowDate indicates an expected call of NowDate.
+func (mr *MocktimeGeneratorMockRecorder) NowDate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NowDate", reflect.TypeOf((*MocktimeGenerator)(nil).NowDate))
+}
+
+// Mockmailer is a mock of mailer interface.
+type Mockmailer struct {
+	ctrl     *gomock.Controller
+	recorder *MockmailerMockRecorder
+}
+
+// MockmailerMockRecorder is the mock recorder for Mockmailer.
+type MockmailerMockRecorder struct {
+	mock *Mockmailer
+}
+
+// NewMockmailer creates a new mock instance.
+func NewMockmailer(ctrl *gomock.Controller) *Mockmailer {
+	mock := &Mockmailer{ctrl: ctrl}
+	mock.recorder = &MockmailerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockmailer) EXPECT() *MockmailerMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *Mockmailer) Send(ctx context.Context, msg mail.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockmailerMockRecorder) Send(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*Mockmailer)(nil).Send), ctx, msg)
+}
@@ -0,0 +1,197 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package notification
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	"github.com/kiaplayer/clean-architecture-example/pkg/mail"
+)
+
+type customerRepository interface {
+	GetByID(ctx context.Context, id uint64) (*reference.Customer, error)
+}
+
+type sentRepository interface {
+	IsSent(ctx context.Context, eventID uint64, recipient string) (bool, error)
+	MarkSent(ctx context.Context, eventID uint64, recipient string, sentAt time.Time) error
+}
+
+type timeGenerator interface {
+	NowDate() time.Time
+}
+
+type mailer interface {
+	Send(ctx context.Context, msg mail.Message) error
+}
+
+//go:embed templates
+var templatesFS embed.FS
+
+// eventTemplates maps events to templates, every template has a .txt and a .html file in the templates dir.
+// The .txt file also defines the "subject" template.
+var eventTemplates = map[event.Type]string{
+	event.TypeSaleOrderCreated: "sale_order_created",
+	event.TypeSaleOrderPosted:  "sale_order_posted",
+	event.TypeSaleOrderDeleted: "sale_order_cancelled",
+}
+
+// Recipients of a notification, every one is sent and recorded independently.
+const (
+	recipientCustomer = "customer"
+	recipientManagers = "managers"
+)
+
+type Config struct {
+	// From is the sender address.
+	From string
+	// ManagerEmails receive a copy of every notification, in a separate message.
+	ManagerEmails []string
+}
+
+type templates struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+type templateData struct {
+	Order    event.SaleOrderPayload
+	Customer *reference.Customer
+	Total    money.Money
+}
+
+// Service sends email notifications about sale order events to the customer and managers.
+// It is an outbox sink, so an event can be handled more than once: sent notifications are recorded
+// per event and recipient and are not sent again, a notification can be duplicated only if recording fails.
+type Service struct {
+	customerRepository customerRepository
+	sentRepository     sentRepository
+	mailer             mailer
+	timeGenerator      timeGenerator
+	config             Config
+	templates          map[event.Type]templates
+}
+
+func NewService(cr customerRepository, sr sentRepository, m mailer, tg timeGenerator, config Config) (*Service, error) {
+	s := &Service{
+		customerRepository: cr,
+		sentRepository:     sr,
+		mailer:             m,
+		timeGenerator:      tg,
+		config:             config,
+		templates:          make(map[event.Type]templates, len(eventTemplates)),
+	}
+
+	for eventType, name := range eventTemplates {
+		text, err := texttemplate.ParseFS(templatesFS, "templates/"+name+".txt")
+		if err != nil {
+			return nil, err
+		}
+		html, err := htmltemplate.ParseFS(templatesFS, "templates/"+name+".html")
+		if err != nil {
+			return nil, err
+		}
+		s.templates[eventType] = templates{text: text, html: html}
+	}
+
+	return s, nil
+}
+
+// Handle sends notifications for the event, events without templates are skipped.
+func (s *Service) Handle(ctx context.Context, e *event.Event) error {
+	tmpl, ok := s.templates[e.Type]
+	if !ok {
+		return nil
+	}
+
+	var order event.SaleOrderPayload
+	err := json.Unmarshal(e.Payload, &order)
+	if err != nil {
+		return fmt.Errorf("bad event payload: %w", err)
+	}
+
+	customer, err := s.customerRepository.GetByID(ctx, order.CustomerID)
+	if err != nil {
+		return err
+	}
+	if customer == nil {
+		return fmt.Errorf("customer not found: %d", order.CustomerID)
+	}
+
+	msg, err := s.render(tmpl, templateData{
+		Order:    order,
+		Customer: customer,
+		Total:    money.New(order.Total, money.Currency(order.Currency)),
+	})
+	if err != nil {
+		return err
+	}
+
+	if customer.Email != "" {
+		err = s.send(ctx, e.ID, recipientCustomer, msg, []string{customer.Email})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(s.config.ManagerEmails) > 0 {
+		err = s.send(ctx, e.ID, recipientManagers, msg, s.config.ManagerEmails)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// send sends the message to the addresses unless the notification of the event has already been sent to the recipient.
+func (s *Service) send(ctx context.Context, eventID uint64, recipient string, msg mail.Message, to []string) error {
+	sent, err := s.sentRepository.IsSent(ctx, eventID, recipient)
+	if err != nil {
+		return err
+	}
+	if sent {
+		return nil
+	}
+
+	msg.To = to
+	err = s.mailer.Send(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	return s.sentRepository.MarkSent(ctx, eventID, recipient, s.timeGenerator.NowDate())
+}
+
+func (s *Service) render(tmpl templates, data templateData) (mail.Message, error) {
+	var subject, text, html bytes.Buffer
+
+	err := tmpl.text.ExecuteTemplate(&subject, "subject", data)
+	if err != nil {
+		return mail.Message{}, err
+	}
+	err = tmpl.text.Execute(&text, data)
+	if err != nil {
+		return mail.Message{}, err
+	}
+	err = tmpl.html.Execute(&html, data)
+	if err != nil {
+		return mail.Message{}, err
+	}
+
+	return mail.Message{
+		From:    s.config.From,
+		Subject: subject.String(),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
@@ -0,0 +1,326 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/service/notification/mocks"
+	"github.com/kiaplayer/clean-architecture-example/pkg/mail"
+	"github.com/kiaplayer/clean-architecture-example/pkg/mail/mailtest"
+)
+
+var testCustomer = &reference.Customer{
+	Reference: reference.Reference{ID: 7, Name: "Customer & Co"},
+	Email:     "customer@example.com",
+}
+
+func newEvent(t *testing.T, eventType event.Type) *event.Event {
+	payload, err := json.Marshal(event.SaleOrderPayload{
+		ID:         100,
+		Number:     "SO-0001",
+		Date:       time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		CustomerID: testCustomer.ID,
+		Currency:   "RUB",
+		Total:      15050,
+	})
+	require.NoError(t, err)
+	return &event.Event{ID: 1, Type: eventType, AggregateID: 100, Payload: payload}
+}
+
+func TestHandle_Created(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	server := mailtest.NewServer()
+	defer server.Close()
+
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	sentRepositoryMock := mocks.NewMocksentRepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service, err := NewService(customerRepositoryMock, sentRepositoryMock, mail.NewClient(mail.Config{Addr: server.Addr}), timeGeneratorMock, Config{
+		From:          "shop@example.com",
+		ManagerEmails: []string{"manager@example.com"},
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	timeGeneratorMock.EXPECT().
+		NowDate().
+		Return(now).
+		Times(2)
+
+	customerRepositoryMock.EXPECT().
+		GetByID(ctx, testCustomer.ID).
+		Return(testCustomer, nil)
+
+	sentRepositoryMock.EXPECT().
+		IsSent(ctx, uint64(1), "customer").
+		Return(false, nil)
+	sentRepositoryMock.EXPECT().
+		MarkSent(ctx, uint64(1), "customer", now).
+		Return(nil)
+
+	sentRepositoryMock.EXPECT().
+		IsSent(ctx, uint64(1), "managers").
+		Return(false, nil)
+	sentRepositoryMock.EXPECT().
+		MarkSent(ctx, uint64(1), "managers", now).
+		Return(nil)
+
+	// act
+	err = service.Handle(ctx, newEvent(t, event.TypeSaleOrderCreated))
+
+	// assert
+	assert.NoError(t, err)
+	messages := server.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, []string{"customer@example.com"}, messages[0].To)
+	assert.Equal(t, []string{"manager@example.com"}, messages[1].To)
+	for _, msg := range messages {
+		assert.Equal(t, "shop@example.com", msg.From)
+		assert.Contains(t, msg.Data, "Subject: Sale order SO-0001 is created")
+		assert.Contains(t, msg.Data, "Hello, Customer & Co!")
+		assert.Contains(t, msg.Data, "Hello, Customer &amp; Co!")
+		assert.Contains(t, msg.Data, "Total: 150.50 RUB")
+	}
+}
+
+func TestHandle_Cancelled(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	server := mailtest.NewServer()
+	defer server.Close()
+
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	sentRepositoryMock := mocks.NewMocksentRepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service, err := NewService(customerRepositoryMock, sentRepositoryMock, mail.NewClient(mail.Config{Addr: server.Addr}), timeGeneratorMock, Config{
+		From: "shop@example.com",
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	timeGeneratorMock.EXPECT().
+		NowDate().
+		Return(now).
+		Times(1)
+
+	customerRepositoryMock.EXPECT().
+		GetByID(ctx, testCustomer.ID).
+		Return(testCustomer, nil)
+
+	sentRepositoryMock.EXPECT().
+		IsSent(ctx, uint64(1), "customer").
+		Return(false, nil)
+	sentRepositoryMock.EXPECT().
+		MarkSent(ctx, uint64(1), "customer", now).
+		Return(nil)
+
+	// act
+	err = service.Handle(ctx, newEvent(t, event.TypeSaleOrderDeleted))
+
+	// assert
+	assert.NoError(t, err)
+	messages := server.Messages()
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0].Data, "Subject: Sale order SO-0001 is cancelled")
+}
+
+func TestHandle_CustomerWithoutEmail(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	server := mailtest.NewServer()
+	defer server.Close()
+
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	sentRepositoryMock := mocks.NewMocksentRepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service, err := NewService(customerRepositoryMock, sentRepositoryMock, mail.NewClient(mail.Config{Addr: server.Addr}), timeGeneratorMock, Config{
+		From: "shop@example.com",
+	})
+	require.NoError(t, err)
+
+	customerRepositoryMock.EXPECT().
+		GetByID(ctx, testCustomer.ID).
+		Return(&reference.Customer{Reference: testCustomer.Reference}, nil)
+
+	// act
+	err = service.Handle(ctx, newEvent(t, event.TypeSaleOrderPosted))
+
+	// assert
+	assert.NoError(t, err)
+	assert.Empty(t, server.Messages())
+}
+
+func TestHandle_EventWithoutTemplate(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	sentRepositoryMock := mocks.NewMocksentRepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+	mailerMock := mocks.NewMockmailer(ctrl)
+
+	service, err := NewService(customerRepositoryMock, sentRepositoryMock, mailerMock, timeGeneratorMock, Config{})
+	require.NoError(t, err)
+
+	// act
+	err = service.Handle(ctx, newEvent(t, event.TypeSaleOrderUpdated))
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestHandle_SendError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	server := mailtest.NewServer()
+	defer server.Close()
+	server.RejectRcpt = true
+
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	sentRepositoryMock := mocks.NewMocksentRepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service, err := NewService(customerRepositoryMock, sentRepositoryMock, mail.NewClient(mail.Config{Addr: server.Addr}), timeGeneratorMock, Config{
+		From: "shop@example.com",
+	})
+	require.NoError(t, err)
+
+	customerRepositoryMock.EXPECT().
+		GetByID(ctx, testCustomer.ID).
+		Return(testCustomer, nil)
+
+	sentRepositoryMock.EXPECT().
+		IsSent(ctx, uint64(1), "customer").
+		Return(false, nil)
+
+	// act
+	err = service.Handle(ctx, newEvent(t, event.TypeSaleOrderCreated))
+
+	// assert
+	assert.ErrorContains(t, err, "550")
+}
+
+func TestHandle_CustomerError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	sentRepositoryMock := mocks.NewMocksentRepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+	mailerMock := mocks.NewMockmailer(ctrl)
+
+	service, err := NewService(customerRepositoryMock, sentRepositoryMock, mailerMock, timeGeneratorMock, Config{})
+	require.NoError(t, err)
+
+	expectedErr := errors.New("db error")
+
+	customerRepositoryMock.EXPECT().
+		GetByID(ctx, testCustomer.ID).
+		Return(nil, expectedErr)
+
+	// act
+	err = service.Handle(ctx, newEvent(t, event.TypeSaleOrderCreated))
+
+	// assert
+	assert.ErrorIs(t, err, expectedErr)
+}
+
+func TestHandle_CustomerAlreadyNotified(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	server := mailtest.NewServer()
+	defer server.Close()
+
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	sentRepositoryMock := mocks.NewMocksentRepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service, err := NewService(customerRepositoryMock, sentRepositoryMock, mail.NewClient(mail.Config{Addr: server.Addr}), timeGeneratorMock, Config{
+		From:          "shop@example.com",
+		ManagerEmails: []string{"manager@example.com"},
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	timeGeneratorMock.EXPECT().
+		NowDate().
+		Return(now)
+
+	customerRepositoryMock.EXPECT().
+		GetByID(ctx, testCustomer.ID).
+		Return(testCustomer, nil)
+
+	sentRepositoryMock.EXPECT().
+		IsSent(ctx, uint64(1), "customer").
+		Return(true, nil)
+	sentRepositoryMock.EXPECT().
+		IsSent(ctx, uint64(1), "managers").
+		Return(false, nil)
+	sentRepositoryMock.EXPECT().
+		MarkSent(ctx, uint64(1), "managers", now).
+		Return(nil)
+
+	// act
+	err = service.Handle(ctx, newEvent(t, event.TypeSaleOrderCreated))
+
+	// assert
+	assert.NoError(t, err)
+	messages := server.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{"manager@example.com"}, messages[0].To)
+}
+
+func TestHandle_IsSentError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	sentRepositoryMock := mocks.NewMocksentRepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+	mailerMock := mocks.NewMockmailer(ctrl)
+
+	service, err := NewService(customerRepositoryMock, sentRepositoryMock, mailerMock, timeGeneratorMock, Config{})
+	require.NoError(t, err)
+
+	expectedErr := errors.New("db error")
+
+	customerRepositoryMock.EXPECT().
+		GetByID(ctx, testCustomer.ID).
+		Return(testCustomer, nil)
+
+	sentRepositoryMock.EXPECT().
+		IsSent(ctx, uint64(1), "customer").
+		Return(false, expectedErr)
+
+	// act
+	err = service.Handle(ctx, newEvent(t, event.TypeSaleOrderCreated))
+
+	// assert
+	assert.ErrorIs(t, err, expectedErr)
+}
@@ -0,0 +1,124 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/pkg/helpers"
+)
+
+type dispatcherRepository interface {
+	GetPending(ctx context.Context, now time.Time, maxAttempts int, limit int) ([]*event.Event, error)
+	MarkDelivered(ctx context.Context, id uint64, deliveredAt time.Time) error
+	MarkFailed(ctx context.Context, id uint64, nextAttemptAt time.Time, lastError string) error
+}
+
+// Sink delivers events outside the service.
+// An event can be delivered more than once, so sinks must tolerate duplicates (e.g. by event ID).
+type Sink interface {
+	Handle(ctx context.Context, e *event.Event) error
+}
+
+type DispatcherConfig struct {
+	// PollInterval is the pause between outbox polls.
+	PollInterval time.Duration
+	// BatchSize is the max number of events delivered per poll.
+	BatchSize int
+	// MaxAttempts is the number of delivery attempts after which the event is left in the outbox undelivered.
+	MaxAttempts int
+	// RetryDelay is the delay after the first failed attempt, it doubles after every next one up to MaxRetryDelay.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+}
+
+var DefaultDispatcherConfig = DispatcherConfig{
+	PollInterval:  time.Second,
+	BatchSize:     100,
+	MaxAttempts:   10,
+	RetryDelay:    time.Second,
+	MaxRetryDelay: time.Hour,
+}
+
+// Dispatcher delivers stored events to all sinks with at-least-once semantics:
+// an event is marked as delivered only after every sink has handled it.
+type Dispatcher struct {
+	repository    dispatcherRepository
+	timeGenerator timeGenerator
+	sinks         []Sink
+	config        DispatcherConfig
+}
+
+func NewDispatcher(r dispatcherRepository, tg timeGenerator, config DispatcherConfig, sinks ...Sink) *Dispatcher {
+	return &Dispatcher{
+		repository:    r,
+		timeGenerator: tg,
+		sinks:         sinks,
+		config:        config,
+	}
+}
+
+// Run dispatches pending events every poll interval until the context is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := d.Dispatch(ctx)
+			if err != nil {
+				log.Printf("Outbox dispatch error: %v", err)
+			}
+		}
+	}
+}
+
+// Dispatch delivers one batch of pending events and returns the number of delivered ones.
+// Failed deliveries are rescheduled, they do not stop the batch.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	events, err := d.repository.GetPending(ctx, d.timeGenerator.NowDate(), d.config.MaxAttempts, d.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, e := range events {
+		deliverErr := d.deliver(ctx, e)
+		if deliverErr != nil {
+			log.Printf("Outbox event %d (%s) delivery error: %v", e.ID, e.Type, deliverErr)
+			err = d.repository.MarkFailed(ctx, e.ID, d.timeGenerator.NowDate().Add(d.retryDelay(e.Attempts)), deliverErr.Error())
+			if err != nil {
+				return delivered, err
+			}
+			continue
+		}
+
+		err = d.repository.MarkDelivered(ctx, e.ID, d.timeGenerator.NowDate())
+		if err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+
+	return delivered, nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, e *event.Event) error {
+	for _, sink := range d.sinks {
+		err := sink.Handle(ctx, e)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retryDelay returns the delay before the next attempt after the given number of previous attempts.
+func (d *Dispatcher) retryDelay(attempts int) time.Duration {
+	return helpers.Backoff(d.config.RetryDelay, d.config.MaxRetryDelay, attempts)
+}
@@ -0,0 +1,122 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/service/outbox/mocks"
+)
+
+func TestDispatch_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockdispatcherRepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+	firstSinkMock := mocks.NewMockSink(ctrl)
+	secondSinkMock := mocks.NewMockSink(ctrl)
+
+	dispatcher := NewDispatcher(repositoryMock, timeGeneratorMock, DefaultDispatcherConfig, firstSinkMock, secondSinkMock)
+
+	now := time.Now()
+	events := []*event.Event{
+		{ID: 1, Type: event.TypeSaleOrderCreated, AggregateID: 100},
+		{ID: 2, Type: event.TypeSaleOrderPosted, AggregateID: 100},
+	}
+
+	timeGeneratorMock.EXPECT().NowDate().Return(now).AnyTimes()
+
+	gomock.InOrder(
+		repositoryMock.EXPECT().GetPending(ctx, now, DefaultDispatcherConfig.MaxAttempts, DefaultDispatcherConfig.BatchSize).Return(events, nil),
+		firstSinkMock.EXPECT().Handle(ctx, events[0]).Return(nil),
+		secondSinkMock.EXPECT().Handle(ctx, events[0]).Return(nil),
+		repositoryMock.EXPECT().MarkDelivered(ctx, uint64(1), now).Return(nil),
+		firstSinkMock.EXPECT().Handle(ctx, events[1]).Return(nil),
+		secondSinkMock.EXPECT().Handle(ctx, events[1]).Return(nil),
+		repositoryMock.EXPECT().MarkDelivered(ctx, uint64(2), now).Return(nil),
+	)
+
+	// act
+	delivered, err := dispatcher.Dispatch(ctx)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, delivered)
+}
+
+func TestDispatch_SinkError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockdispatcherRepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+	firstSinkMock := mocks.NewMockSink(ctrl)
+	secondSinkMock := mocks.NewMockSink(ctrl)
+
+	dispatcher := NewDispatcher(repositoryMock, timeGeneratorMock, DefaultDispatcherConfig, firstSinkMock, secondSinkMock)
+
+	now := time.Now()
+	events := []*event.Event{
+		{ID: 1, Type: event.TypeSaleOrderCreated, AggregateID: 100, Attempts: 2},
+		{ID: 2, Type: event.TypeSaleOrderPosted, AggregateID: 100},
+	}
+
+	timeGeneratorMock.EXPECT().NowDate().Return(now).AnyTimes()
+
+	gomock.InOrder(
+		repositoryMock.EXPECT().GetPending(ctx, now, DefaultDispatcherConfig.MaxAttempts, DefaultDispatcherConfig.BatchSize).Return(events, nil),
+		firstSinkMock.EXPECT().Handle(ctx, events[0]).Return(errors.New("sink error")),
+		repositoryMock.EXPECT().MarkFailed(ctx, uint64(1), now.Add(4*time.Second), "sink error").Return(nil),
+		firstSinkMock.EXPECT().Handle(ctx, events[1]).Return(nil),
+		secondSinkMock.EXPECT().Handle(ctx, events[1]).Return(nil),
+		repositoryMock.EXPECT().MarkDelivered(ctx, uint64(2), now).Return(nil),
+	)
+
+	// act
+	delivered, err := dispatcher.Dispatch(ctx)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+}
+
+func TestDispatch_RepositoryError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockdispatcherRepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	dispatcher := NewDispatcher(repositoryMock, timeGeneratorMock, DefaultDispatcherConfig)
+
+	expectedErr := errors.New("db error")
+
+	timeGeneratorMock.EXPECT().NowDate().Return(time.Now())
+	repositoryMock.EXPECT().GetPending(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+
+	// act
+	delivered, err := dispatcher.Dispatch(ctx)
+
+	// assert
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Equal(t, 0, delivered)
+}
+
+func TestRetryDelay(t *testing.T) {
+	dispatcher := NewDispatcher(nil, nil, DispatcherConfig{RetryDelay: time.Second, MaxRetryDelay: time.Minute})
+
+	assert.Equal(t, time.Second, dispatcher.retryDelay(0))
+	assert.Equal(t, 2*time.Second, dispatcher.retryDelay(1))
+	assert.Equal(t, 32*time.Second, dispatcher.retryDelay(5))
+	assert.Equal(t, time.Minute, dispatcher.retryDelay(6))
+	assert.Equal(t, time.Minute, dispatcher.retryDelay(100))
+}
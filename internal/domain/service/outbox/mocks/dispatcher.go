@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dispatcher.go
+//
+// Generated by this command:
+//
+//	mockgen -package=outbox -source=dispatcher.go -destination=mocks/dispatcher.go
+//
+
+// Package outbox is a generated GoMock package.
+package outbox
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	event "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockdispatcherRepository is a mock of dispatcherRepository interface.
+type MockdispatcherRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockdispatcherRepositoryMockRecorder
+}
+
+// MockdispatcherRepositoryMockRecorder is the mock recorder for MockdispatcherRepository.
+type MockdispatcherRepositoryMockRecorder struct {
+	mock *MockdispatcherRepository
+}
+
+// NewMockdispatcherRepository creates a new mock instance.
+func NewMockdispatcherRepository(ctrl *gomock.Controller) *MockdispatcherRepository {
+	mock := &MockdispatcherRepository{ctrl: ctrl}
+	mock.recorder = &MockdispatcherRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdispatcherRepository) EXPECT() *MockdispatcherRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPending mocks base method.
+func (m *MockdispatcherRepository) GetPending(ctx context.Context, now time.Time, maxAttempts, limit int) ([]*event.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPending", ctx, now, maxAttempts, limit)
+	ret0, _ := ret[0].([]*event.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPending indicates an expected call of GetPending.
+func (mr *MockdispatcherRepositoryMockRecorder) GetPending(ctx, now, maxAttempts, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPending", reflect.TypeOf((*MockdispatcherRepository)(nil).GetPending), ctx, now, maxAttempts, limit)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockdispatcherRepository) MarkDelivered(ctx context.Context, id uint64, deliveredAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, id, deliveredAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockdispatcherRepositoryMockRecorder) MarkDelivered(ctx, id, deliveredAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockdispatcherRepository)(nil).MarkDelivered), ctx, id, deliveredAt)
+}
+
+// MarkFailed mocks base method.
+func (m *MockdispatcherRepository) MarkFailed(ctx context.Context, id uint64, nextAttemptAt time.Time, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, nextAttemptAt, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockdispatcherRepositoryMockRecorder) MarkFailed(ctx, id, nextAttemptAt, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockdispatcherRepository)(nil).MarkFailed), ctx, id, nextAttemptAt, lastError)
+}
+
+// MockSink is a mock of Sink interface.
+type MockSink struct {
+	ctrl     *gomock.Controller
+	recorder *MockSinkMockRecorder
+}
+
+// MockSinkMockRecorder is the mock recorder for MockSink.
+type MockSinkMockRecorder struct {
+	mock *MockSink
+}
+
+// NewMockSink creates a new mock instance.
+func NewMockSink(ctrl *gomock.Controller) *MockSink {
+	mock := &MockSink{ctrl: ctrl}
+	mock.recorder = &MockSinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSink) EXPECT() *MockSinkMockRecorder {
+	return m.recorder
+}
+
+// Handle mocks base method.
+func (m *MockSink) Handle(ctx context.Context, e *event.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockSinkMockRecorder) Handle(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockSink)(nil).Handle), ctx, e)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -package=outbox -source=service.go -destination=mocks/service.go
+//
+
+// Package outbox is a generated GoMock package.
+package outbox
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	event "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockrepository is a mock of repository interface.
+type Mockrepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockrepositoryMockRecorder
+}
+
+// MockrepositoryMockRecorder is the mock recorder for Mockrepository.
+type MockrepositoryMockRecorder struct {
+	mock *Mockrepository
+}
+
+// NewMockrepository creates a new mock instance.
+func NewMockrepository(ctrl *gomock.Controller) *Mockrepository {
+	mock := &Mockrepository{ctrl: ctrl}
+	mock.recorder = &MockrepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockrepository) EXPECT() *MockrepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *Mockrepository) Add(ctx context.Context, e *event.Event) (*event.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, e)
+	ret0, _ := ret[0].(*event.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockrepositoryMockRecorder) Add(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*Mockrepository)(nil).Add), ctx, e)
+}
+
+// MocktimeGenerator is a mock of timeGenerator interface.
+type MocktimeGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MocktimeGeneratorMockRecorder
+}
+
+// MocktimeGeneratorMockRecorder is the mock recorder for MocktimeGenerator.
+type MocktimeGeneratorMockRecorder struct {
+	mock *MocktimeGenerator
+}
+
+// NewMocktimeGenerator creates a new mock instance.
+func NewMocktimeGenerator(ctrl *gomock.Controller) *MocktimeGenerator {
+	mock := &MocktimeGenerator{ctrl: ctrl}
+	mock.recorder = &MocktimeGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktimeGenerator) EXPECT() *MocktimeGeneratorMockRecorder {
+	return m.recorder
+}
+
+// NowDate mocks base method.
+func (m *MocktimeGenerator) NowDate() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NowDate")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// NowDate indicates an expected call of NowDate.
+func (mr *MocktimeGeneratorMockRecorder) NowDate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NowDate", reflect.TypeOf((*MocktimeGenerator)(nil).NowDate))
+}
@@ -0,0 +1,49 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+)
+
+type repository interface {
+	Add(ctx context.Context, e *event.Event) (*event.Event, error)
+}
+
+type timeGenerator interface {
+	NowDate() time.Time
+}
+
+// Service stores domain events in the outbox.
+// It must be called in the transaction that saves the changes the event is about,
+// so events are stored if and only if the changes are committed.
+type Service struct {
+	repository    repository
+	timeGenerator timeGenerator
+}
+
+func NewService(r repository, tg timeGenerator) *Service {
+	return &Service{
+		repository:    r,
+		timeGenerator: tg,
+	}
+}
+
+// Publish stores the event with the JSON encoded payload.
+func (s *Service) Publish(ctx context.Context, eventType event.Type, aggregateID uint64, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.repository.Add(ctx, &event.Event{
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     data,
+		CreatedAt:   s.timeGenerator.NowDate(),
+	})
+	return err
+}
@@ -0,0 +1,83 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/service/outbox/mocks"
+)
+
+func TestPublish_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service := NewService(repositoryMock, timeGeneratorMock)
+
+	now := time.Now()
+
+	timeGeneratorMock.EXPECT().NowDate().Return(now)
+
+	repositoryMock.EXPECT().
+		Add(ctx, &event.Event{
+			Type:        event.TypeSaleOrderCreated,
+			AggregateID: 100,
+			Payload:     []byte(`{"id":100}`),
+			CreatedAt:   now,
+		}).
+		Return(&event.Event{ID: 1}, nil)
+
+	// act
+	err := service.Publish(ctx, event.TypeSaleOrderCreated, 100, map[string]any{"id": 100})
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestPublish_BadPayload(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service := NewService(repositoryMock, timeGeneratorMock)
+
+	// act
+	err := service.Publish(ctx, event.TypeSaleOrderCreated, 100, func() {})
+
+	// assert
+	assert.Error(t, err)
+}
+
+func TestPublish_RepositoryError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service := NewService(repositoryMock, timeGeneratorMock)
+
+	expectedErr := errors.New("db error")
+
+	timeGeneratorMock.EXPECT().NowDate().Return(time.Now())
+	repositoryMock.EXPECT().Add(ctx, gomock.Any()).Return(nil, expectedErr)
+
+	// act
+	err := service.Publish(ctx, event.TypeSaleOrderCreated, 100, map[string]any{"id": 100})
+
+	// assert
+	assert.ErrorIs(t, err, expectedErr)
+}
@@ -0,0 +1,52 @@
+package sale_order
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+)
+
+type listCursor struct {
+	SortField document.SaleOrderSortField `json:"f"`
+	SortDesc  bool                        `json:"r,omitempty"`
+	Date      time.Time                   `json:"d"`
+	Number    string                      `json:"n"`
+	ID        uint64                      `json:"i"`
+}
+
+func encodeListCursor(sort document.SaleOrderSort, order *document.SaleOrder) string {
+	data, _ := json.Marshal(listCursor{
+		SortField: sort.Field,
+		SortDesc:  sort.Desc,
+		Date:      order.Date,
+		Number:    order.Number,
+		ID:        order.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListCursor(value string, sort document.SaleOrderSort) (*document.SaleOrderListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor listCursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor.SortField != sort.Field || cursor.SortDesc != sort.Desc {
+		return nil, errors.New("cursor does not match sort order")
+	}
+
+	return &document.SaleOrderListCursor{
+		Date:   cursor.Date,
+		Number: cursor.Number,
+		ID:     cursor.ID,
+	}, nil
+}
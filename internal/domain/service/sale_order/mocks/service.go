@@ -14,6 +14,9 @@ import (
 	reflect "reflect"
 
 	document "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	event "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	money "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
+	reference "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -70,6 +73,50 @@ func (mr *MockrepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*Mockrepository)(nil).GetByID), ctx, id)
 }
 
+// List mocks base method.
+func (m *Mockrepository) List(ctx context.Context, filter document.SaleOrderFilter, sort document.SaleOrderSort, after *document.SaleOrderListCursor, limit int) ([]*document.SaleOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, sort, after, limit)
+	ret0, _ := ret[0].([]*document.SaleOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockrepositoryMockRecorder) List(ctx, filter, sort, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*Mockrepository)(nil).List), ctx, filter, sort, after, limit)
+}
+
+// UpdateOrder mocks base method.
+func (m *Mockrepository) UpdateOrder(ctx context.Context, order *document.SaleOrder) (*document.SaleOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrder", ctx, order)
+	ret0, _ := ret[0].(*document.SaleOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrder indicates an expected call of UpdateOrder.
+func (mr *MockrepositoryMockRecorder) UpdateOrder(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrder", reflect.TypeOf((*Mockrepository)(nil).UpdateOrder), ctx, order)
+}
+
+// UpdateStatus mocks base method.
+func (m *Mockrepository) UpdateStatus(ctx context.Context, order *document.SaleOrder, status document.Status) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, order, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockrepositoryMockRecorder) UpdateStatus(ctx, order, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*Mockrepository)(nil).UpdateStatus), ctx, order, status)
+}
+
 // MockproductRepository is a mock of productRepository interface.
 type MockproductRepository struct {
 	ctrl     *gomock.Controller
@@ -107,3 +154,182 @@ func (mr *MockproductRepositoryMockRecorder) Exists(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockproductRepository)(nil).Exists), ctx, id)
 }
+
+// GetPrices mocks base method.
+func (m *MockproductRepository) GetPrices(ctx context.Context, ids []uint64) (map[uint64]money.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrices", ctx, ids)
+	ret0, _ := ret[0].(map[uint64]money.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrices indicates an expected call of GetPrices.
+func (mr *MockproductRepositoryMockRecorder) GetPrices(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrices", reflect.TypeOf((*MockproductRepository)(nil).GetPrices), ctx, ids)
+}
+
+// MockcustomerRepository is a mock of customerRepository interface.
+type MockcustomerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockcustomerRepositoryMockRecorder
+}
+
+// MockcustomerRepositoryMockRecorder is the mock recorder for MockcustomerRepository.
+type MockcustomerRepositoryMockRecorder struct {
+	mock *MockcustomerRepository
+}
+
+// NewMockcustomerRepository creates a new mock instance.
+func NewMockcustomerRepository(ctrl *gomock.Controller) *MockcustomerRepository {
+	mock := &MockcustomerRepository{ctrl: ctrl}
+	mock.recorder = &MockcustomerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcustomerRepository) EXPECT() *MockcustomerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockcustomerRepository) GetByID(ctx context.Context, id uint64) (*reference.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*reference.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockcustomerRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockcustomerRepository)(nil).GetByID), ctx, id)
+}
+
+// MockcompanyRepository is a mock of companyRepository interface.
+type MockcompanyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockcompanyRepositoryMockRecorder
+}
+
+// MockcompanyRepositoryMockRecorder is the mock recorder for MockcompanyRepository.
+type MockcompanyRepositoryMockRecorder struct {
+	mock *MockcompanyRepository
+}
+
+// NewMockcompanyRepository creates a new mock instance.
+func NewMockcompanyRepository(ctrl *gomock.Controller) *MockcompanyRepository {
+	mock := &MockcompanyRepository{ctrl: ctrl}
+	mock.recorder = &MockcompanyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcompanyRepository) EXPECT() *MockcompanyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockcompanyRepository) GetByID(ctx context.Context, id uint64) (*reference.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*reference.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockcompanyRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockcompanyRepository)(nil).GetByID), ctx, id)
+}
+
+// MockstockService is a mock of stockService interface.
+type MockstockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockstockServiceMockRecorder
+}
+
+// MockstockServiceMockRecorder is the mock recorder for MockstockService.
+type MockstockServiceMockRecorder struct {
+	mock *MockstockService
+}
+
+// NewMockstockService creates a new mock instance.
+func NewMockstockService(ctrl *gomock.Controller) *MockstockService {
+	mock := &MockstockService{ctrl: ctrl}
+	mock.recorder = &MockstockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstockService) EXPECT() *MockstockServiceMockRecorder {
+	return m.recorder
+}
+
+// Release mocks base method.
+func (m *MockstockService) Release(ctx context.Context, orderID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockstockServiceMockRecorder) Release(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockstockService)(nil).Release), ctx, orderID)
+}
+
+// Reserve mocks base method.
+func (m *MockstockService) Reserve(ctx context.Context, order *document.SaleOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockstockServiceMockRecorder) Reserve(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockstockService)(nil).Reserve), ctx, order)
+}
+
+// MockeventPublisher is a mock of eventPublisher interface.
+type MockeventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockeventPublisherMockRecorder
+}
+
+// MockeventPublisherMockRecorder is the mock recorder for MockeventPublisher.
+type MockeventPublisherMockRecorder struct {
+	mock *MockeventPublisher
+}
+
+// NewMockeventPublisher creates a new mock instance.
+func NewMockeventPublisher(ctrl *gomock.Controller) *MockeventPublisher {
+	mock := &MockeventPublisher{ctrl: ctrl}
+	mock.recorder = &MockeventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockeventPublisher) EXPECT() *MockeventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockeventPublisher) Publish(ctx context.Context, eventType event.Type, aggregateID uint64, payload any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, eventType, aggregateID, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockeventPublisherMockRecorder) Publish(ctx, eventType, aggregateID, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockeventPublisher)(nil).Publish), ctx, eventType, aggregateID, payload)
+}
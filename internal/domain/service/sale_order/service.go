@@ -5,31 +5,118 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
 )
 
 type repository interface {
 	CreateOrder(ctx context.Context, order *document.SaleOrder) (*document.SaleOrder, error)
 	GetByID(ctx context.Context, id uint64) (*document.SaleOrder, error)
+	UpdateOrder(ctx context.Context, order *document.SaleOrder) (*document.SaleOrder, error)
+	UpdateStatus(ctx context.Context, order *document.SaleOrder, status document.Status) error
+	List(
+		ctx context.Context,
+		filter document.SaleOrderFilter,
+		sort document.SaleOrderSort,
+		after *document.SaleOrderListCursor,
+		limit int,
+	) ([]*document.SaleOrder, error)
 }
 
 type productRepository interface {
 	Exists(ctx context.Context, id uint64) (bool, error)
+	GetPrices(ctx context.Context, ids []uint64) (map[uint64]money.Money, error)
+}
+
+type customerRepository interface {
+	GetByID(ctx context.Context, id uint64) (*reference.Customer, error)
+}
+
+type companyRepository interface {
+	GetByID(ctx context.Context, id uint64) (*reference.Company, error)
+}
+
+type stockService interface {
+	Reserve(ctx context.Context, order *document.SaleOrder) error
+	Release(ctx context.Context, orderID uint64) error
+}
+
+type eventPublisher interface {
+	Publish(ctx context.Context, eventType event.Type, aggregateID uint64, payload any) error
+}
+
+// PricePolicy defines what happens with prices supplied by the client.
+type PricePolicy string
+
+const (
+	// PricePolicyReject rejects orders with client supplied prices.
+	PricePolicyReject PricePolicy = "reject"
+	// PricePolicyOverride keeps client supplied prices instead of the product price list.
+	PricePolicyOverride PricePolicy = "override"
+)
+
+var ValidPricePolicies = []PricePolicy{
+	PricePolicyReject,
+	PricePolicyOverride,
+}
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+var statusTransitions = map[document.Status][]document.Status{
+	document.StatusDraft:   {document.StatusPosted, document.StatusDeleted},
+	document.StatusPosted:  {document.StatusDraft},
+	document.StatusDeleted: {},
+}
+
+var editableStatuses = []document.Status{
+	document.StatusDraft,
+}
+
+var statusEventTypes = map[document.Status]event.Type{
+	document.StatusDraft:   event.TypeSaleOrderUnposted,
+	document.StatusPosted:  event.TypeSaleOrderPosted,
+	document.StatusDeleted: event.TypeSaleOrderDeleted,
 }
 
 type Service struct {
-	repository        repository
-	productRepository productRepository
+	repository         repository
+	productRepository  productRepository
+	customerRepository customerRepository
+	companyRepository  companyRepository
+	stockService       stockService
+	eventPublisher     eventPublisher
+	pricePolicy        PricePolicy
 }
 
-func NewService(r repository, pr productRepository) *Service {
+func NewService(
+	r repository,
+	pr productRepository,
+	cr customerRepository,
+	cor companyRepository,
+	ss stockService,
+	ep eventPublisher,
+	pp PricePolicy,
+) *Service {
 	return &Service{
-		repository:        r,
-		productRepository: pr,
+		repository:         r,
+		productRepository:  pr,
+		customerRepository: cr,
+		companyRepository:  cor,
+		stockService:       ss,
+		eventPublisher:     ep,
+		pricePolicy:        pp,
 	}
 }
 
+// CreateOrder saves the order and returns it as stored, with names of the products and users.
 func (s *Service) CreateOrder(ctx context.Context, order *document.SaleOrder) (*document.SaleOrder, error) {
 	order, err := s.ValidateOrder(ctx, order)
 	if err != nil {
@@ -39,17 +126,133 @@ func (s *Service) CreateOrder(ctx context.Context, order *document.SaleOrder) (*
 	if err != nil {
 		return nil, err
 	}
-	// TODO: Additinal logic goes here
-	// - Reserve products
-	// - Send emails to customer and manager (via pgq)
-	// - etc
+	savedSaleOrder, err = s.GetOrderByID(ctx, savedSaleOrder.ID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.stockService.Reserve(ctx, savedSaleOrder)
+	if err != nil {
+		return nil, err
+	}
+	err = s.publish(ctx, event.TypeSaleOrderCreated, savedSaleOrder)
+	if err != nil {
+		return nil, err
+	}
+	return savedSaleOrder, nil
+}
+
+// UpdateOrder saves the order which has the version and returns it as stored, with names of the products and users.
+func (s *Service) UpdateOrder(ctx context.Context, order *document.SaleOrder) (*document.SaleOrder, error) {
+	savedSaleOrder, err := s.repository.GetByID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	if savedSaleOrder == nil {
+		return nil, errors.NewErrNotFound(fmt.Sprintf("sale order not found: %d", order.ID), nil)
+	}
+	err = checkVersion(savedSaleOrder, order.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(editableStatuses, savedSaleOrder.Status) {
+		return nil, errors.NewErrValidation(fmt.Sprintf("sale order cannot be edited in status: %s", savedSaleOrder.Status), nil)
+	}
+
+	savedLineIDs := make([]uint64, 0, len(savedSaleOrder.Products))
+	for _, product := range savedSaleOrder.Products {
+		savedLineIDs = append(savedLineIDs, product.ID)
+	}
+	lineIDs := make(map[uint64]struct{}, len(order.Products))
+	for i, product := range order.Products {
+		if product.ID == 0 {
+			continue
+		}
+		if !slices.Contains(savedLineIDs, product.ID) {
+			return nil, errors.NewErrValidation(
+				fmt.Sprintf("bad line id: %d", product.ID),
+				nil,
+				errors.FieldError{Field: fmt.Sprintf("products[%d].id", i), Message: "line does not belong to the order"},
+			)
+		}
+		if _, ok := lineIDs[product.ID]; ok {
+			return nil, errors.NewErrValidation(
+				fmt.Sprintf("duplicate line id: %d", product.ID),
+				nil,
+				errors.FieldError{Field: fmt.Sprintf("products[%d].id", i), Message: "line is already in the order"},
+			)
+		}
+		lineIDs[product.ID] = struct{}{}
+	}
+
+	order.Number = savedSaleOrder.Number
+	order.Date = savedSaleOrder.Date
+	order.Status = savedSaleOrder.Status
+	order.Company = savedSaleOrder.Company
+	order.AppendUser = savedSaleOrder.AppendUser
+	order.CreatedAt = savedSaleOrder.CreatedAt
+
+	order, err = s.ValidateOrder(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	savedSaleOrder, err = s.repository.UpdateOrder(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	savedSaleOrder, err = s.GetOrderByID(ctx, savedSaleOrder.ID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.stockService.Reserve(ctx, savedSaleOrder)
+	if err != nil {
+		return nil, err
+	}
+	err = s.publish(ctx, event.TypeSaleOrderUpdated, savedSaleOrder)
+	if err != nil {
+		return nil, err
+	}
+
 	return savedSaleOrder, nil
 }
 
 func (s *Service) ValidateOrder(ctx context.Context, order *document.SaleOrder) (*document.SaleOrder, error) {
 	if !slices.Contains(document.ValidStatuses, order.Status) {
-		return nil, NewErrValidation(fmt.Sprintf("bad status: %d", order.Status), nil)
+		return nil, errors.NewErrValidation(fmt.Sprintf("bad status: %d", order.Status), nil)
+	}
+
+	company, err := s.companyRepository.GetByID(ctx, order.Company.ID)
+	if err != nil {
+		return nil, err
+	}
+	if company == nil {
+		return nil, errors.NewErrValidation(fmt.Sprintf("bad company id: %d", order.Company.ID), nil)
+	}
+	if company.Status != reference.StatusActive {
+		return nil, errors.NewErrValidation(fmt.Sprintf("company is deleted: %d", order.Company.ID), nil)
+	}
+	order.Company = *company
+
+	customer, err := s.customerRepository.GetByID(ctx, order.Customer.ID)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, errors.NewErrValidation(
+			fmt.Sprintf("bad customer id: %d", order.Customer.ID),
+			nil,
+			errors.FieldError{Field: "customer_id", Message: "customer not found"},
+		)
+	}
+	if customer.Status != reference.StatusActive {
+		return nil, errors.NewErrValidation(
+			fmt.Sprintf("customer is deleted: %d", order.Customer.ID),
+			nil,
+			errors.FieldError{Field: "customer_id", Message: "customer is deleted"},
+		)
 	}
+	order.Customer = *customer
 
 	if len(order.Products) > 0 {
 		productsIDs := make([]uint64, 0, len(order.Products))
@@ -61,11 +264,11 @@ func (s *Service) ValidateOrder(ctx context.Context, order *document.SaleOrder)
 		for _, productID := range slices.Compact(productsIDs) {
 			exists, err := s.productRepository.Exists(ctx, productID)
 			if err != nil {
-				return nil, NewErrValidation("check product existance error", err)
+				return nil, err
 			}
 
 			if !exists {
-				return nil, NewErrValidation(fmt.Sprintf("bad product id: %d", productID), nil)
+				return nil, errors.NewErrValidation(fmt.Sprintf("bad product id: %d", productID), nil)
 			}
 		}
 	}
@@ -73,6 +276,201 @@ func (s *Service) ValidateOrder(ctx context.Context, order *document.SaleOrder)
 	return order, nil
 }
 
+// PriceOrder sets current product prices on the order lines.
+// Lines with a manual price keep it only if the price policy allows overrides.
+func (s *Service) PriceOrder(ctx context.Context, order *document.SaleOrder) (*document.SaleOrder, error) {
+	if order.Currency == "" {
+		order.Currency = money.DefaultCurrency
+	}
+
+	productsIDs := make([]uint64, 0, len(order.Products))
+	for _, product := range order.Products {
+		if !product.ManualPrice {
+			productsIDs = append(productsIDs, product.Product.ID)
+		}
+	}
+	slices.Sort(productsIDs)
+
+	prices, err := s.productRepository.GetPrices(ctx, slices.Compact(productsIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, product := range order.Products {
+		if product.ManualPrice {
+			if s.pricePolicy != PricePolicyOverride {
+				return nil, errors.NewErrValidation(
+					fmt.Sprintf("price override is not allowed: product %d", product.Product.ID),
+					nil,
+					errors.FieldError{Field: fmt.Sprintf("products[%d].price", i), Message: "price override is not allowed"},
+				)
+			}
+			if product.Price.IsNegative() {
+				return nil, errors.NewErrValidation(
+					fmt.Sprintf("bad price: product %d", product.Product.ID),
+					nil,
+					errors.FieldError{Field: fmt.Sprintf("products[%d].price", i), Message: "must not be negative"},
+				)
+			}
+			order.Products[i].Price.Currency = order.Currency
+			continue
+		}
+
+		price, ok := prices[product.Product.ID]
+		if !ok {
+			return nil, errors.NewErrValidation(
+				fmt.Sprintf("bad product id: %d", product.Product.ID),
+				nil,
+				errors.FieldError{Field: fmt.Sprintf("products[%d].product_id", i), Message: "product not found"},
+			)
+		}
+		if price.Currency != order.Currency {
+			return nil, errors.NewErrValidation(
+				fmt.Sprintf("product %d price currency %s differs from order currency %s", product.Product.ID, price.Currency, order.Currency),
+				nil,
+			)
+		}
+		order.Products[i].Price = price
+	}
+
+	return order, nil
+}
+
 func (s *Service) GetOrderByID(ctx context.Context, id uint64) (*document.SaleOrder, error) {
-	return s.repository.GetByID(ctx, id)
+	order, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, errors.NewErrNotFound(fmt.Sprintf("sale order not found: %d", id), nil)
+	}
+	return order, nil
+}
+
+// ChangeStatus changes the status of the order which has the version, the change is made by the user at the time.
+func (s *Service) ChangeStatus(
+	ctx context.Context,
+	id uint64,
+	version uint64,
+	status document.Status,
+	changeUser *reference.User,
+	updatedAt time.Time,
+) (*document.SaleOrder, error) {
+	order, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, errors.NewErrNotFound(fmt.Sprintf("sale order not found: %d", id), nil)
+	}
+	err = checkVersion(order, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(statusTransitions[order.Status], status) {
+		return nil, errors.NewErrValidation(fmt.Sprintf("status change is not allowed: %s -> %s", order.Status, status), nil)
+	}
+
+	if status == document.StatusPosted {
+		order, err = s.ValidateOrder(ctx, order)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	order.ChangeUser = changeUser
+	order.UpdatedAt = updatedAt
+	err = s.repository.UpdateStatus(ctx, order, status)
+	if err != nil {
+		return nil, err
+	}
+	order.Status = status
+	order.Version++
+
+	switch status {
+	case document.StatusPosted:
+		err = s.stockService.Reserve(ctx, order)
+	case document.StatusDeleted:
+		err = s.stockService.Release(ctx, order.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = s.publish(ctx, statusEventTypes[status], order)
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// checkVersion returns ErrVersionConflict when the order has been changed since the client has read the version.
+func checkVersion(order *document.SaleOrder, version uint64) error {
+	if order.Version != version {
+		return errors.NewErrVersionConflict(
+			fmt.Sprintf("sale order %d has version %d, not %d", order.ID, order.Version, version),
+			nil,
+		)
+	}
+	return nil
+}
+
+// publish stores the order event in the outbox, it is delivered after the transaction is committed.
+func (s *Service) publish(ctx context.Context, eventType event.Type, order *document.SaleOrder) error {
+	return s.eventPublisher.Publish(ctx, eventType, order.ID, event.SaleOrderPayload{
+		ID:         order.ID,
+		Number:     order.Number,
+		Date:       order.Date,
+		Status:     order.Status.String(),
+		CompanyID:  order.Company.ID,
+		CustomerID: order.Customer.ID,
+		Currency:   string(order.Currency),
+		Total:      order.Total().Amount,
+	})
+}
+
+func (s *Service) ListOrders(ctx context.Context, query document.SaleOrderListQuery) (*document.SaleOrderList, error) {
+	if query.Sort.Field == "" {
+		query.Sort = document.SaleOrderSort{Field: document.SaleOrderSortByDate, Desc: true}
+	}
+	if !slices.Contains(document.ValidSaleOrderSortFields, query.Sort.Field) {
+		return nil, errors.NewErrValidation(fmt.Sprintf("bad sort field: %s", query.Sort.Field), nil)
+	}
+
+	if query.Limit == 0 {
+		query.Limit = defaultListLimit
+	}
+	if query.Limit < 0 || query.Limit > maxListLimit {
+		return nil, errors.NewErrValidation(fmt.Sprintf("bad limit: %d", query.Limit), nil)
+	}
+
+	if query.Filter.Status != nil && !slices.Contains(document.ValidStatuses, *query.Filter.Status) {
+		return nil, errors.NewErrValidation(fmt.Sprintf("bad status: %d", *query.Filter.Status), nil)
+	}
+
+	var after *document.SaleOrderListCursor
+	if query.Cursor != "" {
+		var err error
+		after, err = decodeListCursor(query.Cursor, query.Sort)
+		if err != nil {
+			return nil, errors.NewErrValidation("bad cursor", err)
+		}
+	}
+
+	// one extra order is requested to find out whether the next page exists
+	orders, err := s.repository.List(ctx, query.Filter, query.Sort, after, query.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &document.SaleOrderList{
+		Items: orders,
+	}
+	if len(orders) > query.Limit {
+		result.Items = orders[:query.Limit]
+		result.NextCursor = encodeListCursor(query.Sort, result.Items[query.Limit-1])
+	}
+
+	return result, nil
 }
@@ -3,6 +3,7 @@ package sale_order
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -10,7 +11,10 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
 	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/service/sale_order/mocks"
 )
 
@@ -21,14 +25,212 @@ func TestCreateOrder_Success(t *testing.T) {
 
 	repositoryMock := mocks.NewMockrepository(ctrl)
 	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
 
-	service := NewService(repositoryMock, productRepositoryMock)
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
 			Date:   time.Now().Truncate(time.Second),
 			Number: "0001",
 		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID:     1,
+				Name:   "Customer",
+				Status: reference.StatusActive,
+			},
+		},
+		Products: []document.SaleOrderProduct{
+			{
+				Product: reference.Product{
+					Reference: reference.Reference{
+						ID: 1,
+					},
+				},
+				Quantity: 1,
+				Price:    money.New(15050, money.DefaultCurrency),
+			},
+		},
+	}
+
+	storedSaleOrder := *saleOrder
+	storedSaleOrder.ID = 1
+	storedSaleOrder.Products = []document.SaleOrderProduct{
+		{
+			ID: 10,
+			Product: reference.Product{
+				Reference: reference.Reference{
+					ID:     1,
+					Name:   "Keyboard",
+					Status: reference.StatusActive,
+				},
+			},
+			Quantity: 1,
+			Price:    money.New(15050, money.DefaultCurrency),
+		},
+	}
+
+	repositoryMock.
+		EXPECT().
+		CreateOrder(ctx, saleOrder).
+		Return(&document.SaleOrder{Document: document.Document{ID: storedSaleOrder.ID}}, nil)
+
+	repositoryMock.
+		EXPECT().
+		GetByID(ctx, storedSaleOrder.ID).
+		Return(&storedSaleOrder, nil)
+
+	productRepositoryMock.
+		EXPECT().
+		Exists(ctx, saleOrder.Products[0].Product.ID).
+		Return(true, nil)
+
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(&saleOrder.Company, nil)
+
+	customerRepositoryMock.
+		EXPECT().
+		GetByID(ctx, saleOrder.Customer.ID).
+		Return(&saleOrder.Customer, nil)
+
+	stockServiceMock.EXPECT().
+		Reserve(ctx, &storedSaleOrder).
+		Return(nil)
+
+	eventPublisherMock.EXPECT().
+		Publish(ctx, event.TypeSaleOrderCreated, storedSaleOrder.ID, event.SaleOrderPayload{
+			ID:         storedSaleOrder.ID,
+			Number:     saleOrder.Number,
+			Date:       saleOrder.Date,
+			Status:     "draft",
+			CustomerID: saleOrder.Customer.ID,
+			Total:      15050,
+		}).
+		Return(nil)
+
+	// act
+	actualSaleOrder, actualErr := service.CreateOrder(ctx, saleOrder)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, &storedSaleOrder, actualSaleOrder)
+	assert.Equal(t, "Keyboard", actualSaleOrder.Products[0].Product.Name)
+}
+
+func TestCreateOrder_PublishError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			Date:   time.Now().Truncate(time.Second),
+			Number: "0001",
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID:     1,
+				Name:   "Customer",
+				Status: reference.StatusActive,
+			},
+		},
+		Products: []document.SaleOrderProduct{
+			{
+				Product: reference.Product{
+					Reference: reference.Reference{
+						ID:     1,
+						Name:   "Keyboard",
+						Status: reference.StatusActive,
+					},
+				},
+				Quantity: 1,
+				Price:    money.New(15050, money.DefaultCurrency),
+			},
+		},
+	}
+
+	repositoryMock.
+		EXPECT().
+		CreateOrder(ctx, saleOrder).
+		Return(saleOrder, nil)
+
+	repositoryMock.
+		EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(saleOrder, nil)
+
+	productRepositoryMock.
+		EXPECT().
+		Exists(ctx, saleOrder.Products[0].Product.ID).
+		Return(true, nil)
+
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(&saleOrder.Company, nil)
+
+	customerRepositoryMock.
+		EXPECT().
+		GetByID(ctx, saleOrder.Customer.ID).
+		Return(&saleOrder.Customer, nil)
+
+	stockServiceMock.EXPECT().
+		Reserve(ctx, saleOrder).
+		Return(nil)
+
+	publishErr := errors.New("outbox error")
+
+	eventPublisherMock.EXPECT().
+		Publish(ctx, event.TypeSaleOrderCreated, saleOrder.ID, gomock.Any()).
+		Return(publishErr)
+
+	// act
+	actualSaleOrder, actualErr := service.CreateOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorIs(t, actualErr, publishErr)
+}
+
+func TestCreateOrder_InsufficientStock(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			Date:   time.Now().Truncate(time.Second),
+			Number: "0001",
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID:     1,
+				Name:   "Customer",
+				Status: reference.StatusActive,
+			},
+		},
 		Products: []document.SaleOrderProduct{
 			{
 				Product: reference.Product{
@@ -39,7 +241,7 @@ func TestCreateOrder_Success(t *testing.T) {
 					},
 				},
 				Quantity: 1,
-				Price:    150.5,
+				Price:    money.New(15050, money.DefaultCurrency),
 			},
 		},
 	}
@@ -49,17 +251,37 @@ func TestCreateOrder_Success(t *testing.T) {
 		CreateOrder(ctx, saleOrder).
 		Return(saleOrder, nil)
 
+	repositoryMock.
+		EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(saleOrder, nil)
+
 	productRepositoryMock.
 		EXPECT().
 		Exists(ctx, saleOrder.Products[0].Product.ID).
 		Return(true, nil)
 
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(&saleOrder.Company, nil)
+
+	customerRepositoryMock.
+		EXPECT().
+		GetByID(ctx, saleOrder.Customer.ID).
+		Return(&saleOrder.Customer, nil)
+
+	stockErr := domainerrors.NewErrValidation("insufficient stock", nil)
+
+	stockServiceMock.EXPECT().
+		Reserve(ctx, saleOrder).
+		Return(stockErr)
+
 	// act
 	actualSaleOrder, actualErr := service.CreateOrder(ctx, saleOrder)
 
 	// assert
-	assert.NoError(t, actualErr)
-	assert.Equal(t, saleOrder, actualSaleOrder)
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorIs(t, actualErr, stockErr)
 }
 
 func TestCreateOrder_ValidateError(t *testing.T) {
@@ -69,8 +291,12 @@ func TestCreateOrder_ValidateError(t *testing.T) {
 
 	repositoryMock := mocks.NewMockrepository(ctrl)
 	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
 
-	service := NewService(repositoryMock, productRepositoryMock)
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
@@ -95,8 +321,12 @@ func TestCreateOrder_ValidateError_BadProductID(t *testing.T) {
 
 	repositoryMock := mocks.NewMockrepository(ctrl)
 	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
 
-	service := NewService(repositoryMock, productRepositoryMock)
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
@@ -104,6 +334,13 @@ func TestCreateOrder_ValidateError_BadProductID(t *testing.T) {
 			Number: "0001",
 			Status: document.StatusDraft,
 		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID:     1,
+				Name:   "Customer",
+				Status: reference.StatusActive,
+			},
+		},
 		Products: []document.SaleOrderProduct{
 			{
 				Product: reference.Product{
@@ -114,7 +351,7 @@ func TestCreateOrder_ValidateError_BadProductID(t *testing.T) {
 					},
 				},
 				Quantity: 1,
-				Price:    150.5,
+				Price:    money.New(15050, money.DefaultCurrency),
 			},
 		},
 	}
@@ -124,6 +361,15 @@ func TestCreateOrder_ValidateError_BadProductID(t *testing.T) {
 		Exists(ctx, saleOrder.Products[0].Product.ID).
 		Return(false, nil)
 
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(&saleOrder.Company, nil)
+
+	customerRepositoryMock.
+		EXPECT().
+		GetByID(ctx, saleOrder.Customer.ID).
+		Return(&saleOrder.Customer, nil)
+
 	// act
 	actualSaleOrder, actualErr := service.CreateOrder(ctx, saleOrder)
 
@@ -139,8 +385,12 @@ func TestCreateOrder_ValidateError_CheckProductIDsError(t *testing.T) {
 
 	repositoryMock := mocks.NewMockrepository(ctrl)
 	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
 
-	service := NewService(repositoryMock, productRepositoryMock)
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
@@ -148,6 +398,13 @@ func TestCreateOrder_ValidateError_CheckProductIDsError(t *testing.T) {
 			Number: "0001",
 			Status: document.StatusDraft,
 		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID:     1,
+				Name:   "Customer",
+				Status: reference.StatusActive,
+			},
+		},
 		Products: []document.SaleOrderProduct{
 			{
 				Product: reference.Product{
@@ -158,7 +415,7 @@ func TestCreateOrder_ValidateError_CheckProductIDsError(t *testing.T) {
 					},
 				},
 				Quantity: 1,
-				Price:    150.5,
+				Price:    money.New(15050, money.DefaultCurrency),
 			},
 		},
 	}
@@ -170,12 +427,23 @@ func TestCreateOrder_ValidateError_CheckProductIDsError(t *testing.T) {
 		Exists(ctx, saleOrder.Products[0].Product.ID).
 		Return(false, checkErr)
 
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(&saleOrder.Company, nil)
+
+	customerRepositoryMock.
+		EXPECT().
+		GetByID(ctx, saleOrder.Customer.ID).
+		Return(&saleOrder.Customer, nil)
+
 	// act
 	actualSaleOrder, actualErr := service.CreateOrder(ctx, saleOrder)
 
 	// assert
+	var errValidation *domainerrors.ErrValidation
 	assert.Nil(t, actualSaleOrder)
-	assert.ErrorContains(t, actualErr, checkErr.Error())
+	assert.ErrorIs(t, actualErr, checkErr)
+	assert.False(t, errors.As(actualErr, &errValidation))
 }
 
 func TestCreateOrder_CreateError(t *testing.T) {
@@ -185,14 +453,25 @@ func TestCreateOrder_CreateError(t *testing.T) {
 
 	repositoryMock := mocks.NewMockrepository(ctrl)
 	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
 
-	service := NewService(repositoryMock, productRepositoryMock)
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
 			Date:   time.Now().Truncate(time.Second),
 			Number: "0001",
 		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID:     1,
+				Name:   "Customer",
+				Status: reference.StatusActive,
+			},
+		},
 	}
 
 	createErr := errors.New("create error")
@@ -201,6 +480,15 @@ func TestCreateOrder_CreateError(t *testing.T) {
 		CreateOrder(ctx, saleOrder).
 		Return(nil, createErr)
 
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(&saleOrder.Company, nil)
+
+	customerRepositoryMock.
+		EXPECT().
+		GetByID(ctx, saleOrder.Customer.ID).
+		Return(&saleOrder.Customer, nil)
+
 	// act
 	actualSaleOrder, actualErr := service.CreateOrder(ctx, saleOrder)
 
@@ -216,8 +504,12 @@ func TestGetOrderByID_Success(t *testing.T) {
 
 	repositoryMock := mocks.NewMockrepository(ctrl)
 	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
 
-	service := NewService(repositoryMock, productRepositoryMock)
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
@@ -238,3 +530,1419 @@ func TestGetOrderByID_Success(t *testing.T) {
 	assert.NoError(t, actualErr)
 	assert.Equal(t, saleOrder, actualSaleOrder)
 }
+
+func TestGetOrderByID_NotFound(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, uint64(1)).
+		Return(nil, nil)
+
+	// act
+	actualSaleOrder, actualErr := service.GetOrderByID(ctx, 1)
+
+	// assert
+	var errTarget *domainerrors.ErrNotFound
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorAs(t, actualErr, &errTarget)
+	assert.EqualError(t, actualErr, "sale order not found: 1")
+}
+
+func TestCreateOrder_ValidateError_BadCustomerID(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			Date:   time.Now().Truncate(time.Second),
+			Number: "0001",
+			Status: document.StatusDraft,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID: 999,
+			},
+		},
+	}
+
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(&saleOrder.Company, nil)
+
+	customerRepositoryMock.
+		EXPECT().
+		GetByID(ctx, saleOrder.Customer.ID).
+		Return(nil, nil)
+
+	// act
+	actualSaleOrder, actualErr := service.CreateOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, actualErr, "bad customer id: 999")
+}
+
+func TestCreateOrder_ValidateError_DeletedCustomer(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			Date:   time.Now().Truncate(time.Second),
+			Number: "0001",
+			Status: document.StatusDraft,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID: 1,
+			},
+		},
+	}
+
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(&saleOrder.Company, nil)
+
+	customerRepositoryMock.
+		EXPECT().
+		GetByID(ctx, saleOrder.Customer.ID).
+		Return(&reference.Customer{
+			Reference: reference.Reference{
+				ID:     1,
+				Name:   "Customer",
+				Status: reference.StatusDeleted,
+			},
+		}, nil)
+
+	// act
+	actualSaleOrder, actualErr := service.CreateOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, actualErr, "customer is deleted: 1")
+}
+
+func TestCreateOrder_ValidateError_CheckCustomerError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			Date:   time.Now().Truncate(time.Second),
+			Number: "0001",
+			Status: document.StatusDraft,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID: 1,
+			},
+		},
+	}
+
+	checkErr := errors.New("some db error")
+
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(&saleOrder.Company, nil)
+
+	customerRepositoryMock.
+		EXPECT().
+		GetByID(ctx, saleOrder.Customer.ID).
+		Return(nil, checkErr)
+
+	// act
+	actualSaleOrder, actualErr := service.CreateOrder(ctx, saleOrder)
+
+	// assert
+	var errValidation *domainerrors.ErrValidation
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorIs(t, actualErr, checkErr)
+	assert.False(t, errors.As(actualErr, &errValidation))
+}
+
+func TestChangeStatus_Post_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	changeUser := &reference.User{Reference: reference.Reference{ID: 5}}
+	now := time.Now()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:      1,
+			Date:    time.Now().Truncate(time.Second),
+			Number:  "0001",
+			Status:  document.StatusDraft,
+			Version: 3,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID:     1,
+				Name:   "Customer",
+				Status: reference.StatusActive,
+			},
+		},
+	}
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(saleOrder, nil)
+
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(&saleOrder.Company, nil)
+
+	customerRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Customer.ID).
+		Return(&saleOrder.Customer, nil)
+
+	repositoryMock.EXPECT().
+		UpdateStatus(ctx, saleOrder, document.StatusPosted).
+		Return(nil)
+
+	stockServiceMock.EXPECT().
+		Reserve(ctx, saleOrder).
+		Return(nil)
+
+	eventPublisherMock.EXPECT().
+		Publish(ctx, event.TypeSaleOrderPosted, saleOrder.ID, gomock.Any()).
+		Return(nil)
+
+	// act
+	actualSaleOrder, actualErr := service.ChangeStatus(ctx, saleOrder.ID, saleOrder.Version, document.StatusPosted, changeUser, now)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, document.StatusPosted, actualSaleOrder.Status)
+	assert.Equal(t, uint64(4), actualSaleOrder.Version)
+	assert.Equal(t, changeUser, actualSaleOrder.ChangeUser)
+	assert.Equal(t, now, actualSaleOrder.UpdatedAt)
+}
+
+func TestChangeStatus_Unpost_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	changeUser := &reference.User{Reference: reference.Reference{ID: 5}}
+	now := time.Now()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:     1,
+			Status: document.StatusPosted,
+		},
+	}
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(saleOrder, nil)
+
+	repositoryMock.EXPECT().
+		UpdateStatus(ctx, saleOrder, document.StatusDraft).
+		Return(nil)
+
+	eventPublisherMock.EXPECT().
+		Publish(ctx, event.TypeSaleOrderUnposted, saleOrder.ID, gomock.Any()).
+		Return(nil)
+
+	// act
+	actualSaleOrder, actualErr := service.ChangeStatus(ctx, saleOrder.ID, saleOrder.Version, document.StatusDraft, changeUser, now)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, document.StatusDraft, actualSaleOrder.Status)
+}
+
+func TestChangeStatus_NotAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   document.Status
+		to     document.Status
+		reason string
+	}{
+		{
+			name:   "post deleted",
+			from:   document.StatusDeleted,
+			to:     document.StatusPosted,
+			reason: "status change is not allowed: deleted -> posted",
+		},
+		{
+			name:   "delete posted",
+			from:   document.StatusPosted,
+			to:     document.StatusDeleted,
+			reason: "status change is not allowed: posted -> deleted",
+		},
+		{
+			name:   "post posted",
+			from:   document.StatusPosted,
+			to:     document.StatusPosted,
+			reason: "status change is not allowed: posted -> posted",
+		},
+		{
+			name:   "unpost draft",
+			from:   document.StatusDraft,
+			to:     document.StatusDraft,
+			reason: "status change is not allowed: draft -> draft",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+			changeUser := &reference.User{Reference: reference.Reference{ID: 5}}
+			now := time.Now()
+
+			repositoryMock := mocks.NewMockrepository(ctrl)
+			productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+			customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+			companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+			stockServiceMock := mocks.NewMockstockService(ctrl)
+			eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+			service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+			saleOrder := &document.SaleOrder{
+				Document: document.Document{
+					ID:     1,
+					Status: tt.from,
+				},
+			}
+
+			repositoryMock.EXPECT().
+				GetByID(ctx, saleOrder.ID).
+				Return(saleOrder, nil)
+
+			// act
+			actualSaleOrder, actualErr := service.ChangeStatus(ctx, saleOrder.ID, saleOrder.Version, tt.to, changeUser, now)
+
+			// assert
+			var errTarget *domainerrors.ErrValidation
+			assert.Nil(t, actualSaleOrder)
+			assert.ErrorAs(t, actualErr, &errTarget)
+			assert.ErrorContains(t, actualErr, tt.reason)
+		})
+	}
+}
+
+func TestChangeStatus_NotFound(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	changeUser := &reference.User{Reference: reference.Reference{ID: 5}}
+	now := time.Now()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	var saleOrderID uint64 = 1
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrderID).
+		Return(nil, nil)
+
+	// act
+	actualSaleOrder, actualErr := service.ChangeStatus(ctx, saleOrderID, 1, document.StatusPosted, changeUser, now)
+
+	// assert
+	var errTarget *domainerrors.ErrNotFound
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorAs(t, actualErr, &errTarget)
+}
+
+func TestChangeStatus_VersionConflict(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	changeUser := &reference.User{Reference: reference.Reference{ID: 5}}
+	now := time.Now()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:      1,
+			Status:  document.StatusDraft,
+			Version: 3,
+		},
+	}
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(saleOrder, nil)
+
+	// act
+	actualSaleOrder, actualErr := service.ChangeStatus(ctx, saleOrder.ID, 2, document.StatusPosted, changeUser, now)
+
+	// assert
+	var errTarget *domainerrors.ErrVersionConflict
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorAs(t, actualErr, &errTarget)
+}
+
+func TestChangeStatus_GetError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	changeUser := &reference.User{Reference: reference.Reference{ID: 5}}
+	now := time.Now()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	var saleOrderID uint64 = 1
+
+	getErr := errors.New("get error")
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrderID).
+		Return(nil, getErr)
+
+	// act
+	actualSaleOrder, actualErr := service.ChangeStatus(ctx, saleOrderID, 1, document.StatusPosted, changeUser, now)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, actualErr, getErr.Error())
+}
+
+func TestChangeStatus_ValidateError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	changeUser := &reference.User{Reference: reference.Reference{ID: 5}}
+	now := time.Now()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:     1,
+			Status: document.StatusDraft,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID: 1,
+			},
+		},
+	}
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(saleOrder, nil)
+
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(&saleOrder.Company, nil)
+
+	customerRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Customer.ID).
+		Return(nil, nil)
+
+	// act
+	actualSaleOrder, actualErr := service.ChangeStatus(ctx, saleOrder.ID, saleOrder.Version, document.StatusPosted, changeUser, now)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, actualErr, "bad customer id: 1")
+}
+
+func TestChangeStatus_UpdateError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	changeUser := &reference.User{Reference: reference.Reference{ID: 5}}
+	now := time.Now()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:     1,
+			Status: document.StatusDraft,
+		},
+	}
+
+	updateErr := errors.New("update error")
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(saleOrder, nil)
+
+	repositoryMock.EXPECT().
+		UpdateStatus(ctx, saleOrder, document.StatusDeleted).
+		Return(updateErr)
+
+	// act
+	actualSaleOrder, actualErr := service.ChangeStatus(ctx, saleOrder.ID, saleOrder.Version, document.StatusDeleted, changeUser, now)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, actualErr, updateErr.Error())
+}
+
+func TestUpdateOrder_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	savedSaleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:     1,
+			Date:   time.Now().Truncate(time.Second),
+			Number: "0001",
+			Status: document.StatusDraft,
+			AppendUser: &reference.User{
+				Reference: reference.Reference{ID: 5},
+			},
+			CreatedAt: time.Now().Add(-time.Hour).Truncate(time.Second),
+		},
+		Products: []document.SaleOrderProduct{
+			{
+				ID: 10,
+				Product: reference.Product{
+					Reference: reference.Reference{
+						ID: 1,
+					},
+				},
+				Quantity: 1,
+			},
+		},
+	}
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID: savedSaleOrder.ID,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID:     1,
+				Name:   "Customer",
+				Status: reference.StatusActive,
+			},
+		},
+		Products: []document.SaleOrderProduct{
+			{
+				ID: 10,
+				Product: reference.Product{
+					Reference: reference.Reference{
+						ID: 1,
+					},
+				},
+				Quantity: 5,
+			},
+		},
+	}
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(savedSaleOrder, nil)
+
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(&saleOrder.Company, nil)
+
+	customerRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Customer.ID).
+		Return(&saleOrder.Customer, nil)
+
+	productRepositoryMock.EXPECT().
+		Exists(ctx, saleOrder.Products[0].Product.ID).
+		Return(true, nil)
+
+	repositoryMock.EXPECT().
+		UpdateOrder(ctx, saleOrder).
+		Return(saleOrder, nil)
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		DoAndReturn(func(context.Context, uint64) (*document.SaleOrder, error) {
+			storedSaleOrder := *saleOrder
+			storedSaleOrder.Products = slices.Clone(saleOrder.Products)
+			storedSaleOrder.Products[0].Product.Name = "Keyboard"
+			return &storedSaleOrder, nil
+		})
+
+	stockServiceMock.EXPECT().
+		Reserve(ctx, gomock.Any()).
+		Return(nil)
+
+	eventPublisherMock.EXPECT().
+		Publish(ctx, event.TypeSaleOrderUpdated, saleOrder.ID, gomock.Any()).
+		Return(nil)
+
+	// act
+	actualSaleOrder, actualErr := service.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, savedSaleOrder.AppendUser, actualSaleOrder.AppendUser)
+	assert.Equal(t, savedSaleOrder.CreatedAt, actualSaleOrder.CreatedAt)
+	assert.Equal(t, savedSaleOrder.Number, actualSaleOrder.Number)
+	assert.Equal(t, savedSaleOrder.Date, actualSaleOrder.Date)
+	assert.Equal(t, 5, actualSaleOrder.Products[0].Quantity)
+	assert.Equal(t, "Keyboard", actualSaleOrder.Products[0].Product.Name)
+}
+
+func TestUpdateOrder_NotFound(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID: 1,
+		},
+	}
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(nil, nil)
+
+	// act
+	actualSaleOrder, actualErr := service.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	var errTarget *domainerrors.ErrNotFound
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorAs(t, actualErr, &errTarget)
+}
+
+func TestUpdateOrder_VersionConflict(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:      1,
+			Version: 2,
+		},
+	}
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(&document.SaleOrder{
+			Document: document.Document{
+				ID:      1,
+				Status:  document.StatusDraft,
+				Version: 3,
+			},
+		}, nil)
+
+	// act
+	actualSaleOrder, actualErr := service.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	var errTarget *domainerrors.ErrVersionConflict
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorAs(t, actualErr, &errTarget)
+}
+
+func TestUpdateOrder_GetError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID: 1,
+		},
+	}
+
+	getErr := errors.New("get error")
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(nil, getErr)
+
+	// act
+	actualSaleOrder, actualErr := service.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, actualErr, getErr.Error())
+}
+
+func TestUpdateOrder_NotEditable(t *testing.T) {
+	for _, status := range []document.Status{document.StatusPosted, document.StatusDeleted} {
+		t.Run(status.String(), func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+
+			repositoryMock := mocks.NewMockrepository(ctrl)
+			productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+			customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+			companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+			stockServiceMock := mocks.NewMockstockService(ctrl)
+			eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+			service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+			saleOrder := &document.SaleOrder{
+				Document: document.Document{
+					ID: 1,
+				},
+			}
+
+			repositoryMock.EXPECT().
+				GetByID(ctx, saleOrder.ID).
+				Return(&document.SaleOrder{Document: document.Document{ID: 1, Status: status}}, nil)
+
+			// act
+			actualSaleOrder, actualErr := service.UpdateOrder(ctx, saleOrder)
+
+			// assert
+			var errTarget *domainerrors.ErrValidation
+			assert.Nil(t, actualSaleOrder)
+			assert.ErrorAs(t, actualErr, &errTarget)
+			assert.ErrorContains(t, actualErr, "sale order cannot be edited in status: "+status.String())
+		})
+	}
+}
+
+func TestUpdateOrder_BadLineID(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID: 1,
+		},
+		Products: []document.SaleOrderProduct{
+			{
+				ID: 999,
+			},
+		},
+	}
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(&document.SaleOrder{Document: document.Document{ID: 1, Status: document.StatusDraft}}, nil)
+
+	// act
+	actualSaleOrder, actualErr := service.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, actualErr, "bad line id: 999")
+}
+
+func TestUpdateOrder_DuplicateLineID(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID: 1,
+		},
+		Products: []document.SaleOrderProduct{
+			{
+				ID: 7,
+			},
+			{
+				ID: 7,
+			},
+		},
+	}
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(&document.SaleOrder{
+			Document: document.Document{ID: 1, Status: document.StatusDraft},
+			Products: []document.SaleOrderProduct{{ID: 7}},
+		}, nil)
+
+	// act
+	actualSaleOrder, actualErr := service.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	var errValidation *domainerrors.ErrValidation
+	assert.ErrorAs(t, actualErr, &errValidation)
+	assert.ErrorContains(t, actualErr, "duplicate line id: 7")
+	assert.Equal(t, []domainerrors.FieldError{{Field: "products[1].id", Message: "line is already in the order"}}, errValidation.Fields())
+}
+
+func TestUpdateOrder_ValidateError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID: 1,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID: 999,
+			},
+		},
+	}
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(&document.SaleOrder{Document: document.Document{ID: 1, Status: document.StatusDraft}}, nil)
+
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(&saleOrder.Company, nil)
+
+	customerRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Customer.ID).
+		Return(nil, nil)
+
+	// act
+	actualSaleOrder, actualErr := service.UpdateOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, actualErr, "bad customer id: 999")
+}
+
+func TestListOrders_Defaults(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrders := []*document.SaleOrder{
+		{Document: document.Document{ID: 1}},
+	}
+
+	repositoryMock.EXPECT().
+		List(
+			ctx,
+			document.SaleOrderFilter{},
+			document.SaleOrderSort{Field: document.SaleOrderSortByDate, Desc: true},
+			nil,
+			defaultListLimit+1,
+		).
+		Return(saleOrders, nil)
+
+	// act
+	actual, actualErr := service.ListOrders(ctx, document.SaleOrderListQuery{})
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, saleOrders, actual.Items)
+	assert.Empty(t, actual.NextCursor)
+}
+
+func TestListOrders_NextPage(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	sort := document.SaleOrderSort{Field: document.SaleOrderSortByNumber}
+	saleOrders := []*document.SaleOrder{
+		{Document: document.Document{ID: 1, Number: "0001"}},
+		{Document: document.Document{ID: 2, Number: "0002"}},
+		{Document: document.Document{ID: 3, Number: "0003"}},
+	}
+
+	repositoryMock.EXPECT().
+		List(ctx, document.SaleOrderFilter{}, sort, nil, 3).
+		Return(saleOrders, nil)
+
+	repositoryMock.EXPECT().
+		List(ctx, document.SaleOrderFilter{}, sort, &document.SaleOrderListCursor{Number: "0002", ID: 2}, 3).
+		Return(saleOrders[2:], nil)
+
+	// act
+	firstPage, firstErr := service.ListOrders(ctx, document.SaleOrderListQuery{Sort: sort, Limit: 2})
+	secondPage, secondErr := service.ListOrders(
+		ctx,
+		document.SaleOrderListQuery{Sort: sort, Limit: 2, Cursor: firstPage.NextCursor},
+	)
+
+	// assert
+	assert.NoError(t, firstErr)
+	assert.Equal(t, saleOrders[:2], firstPage.Items)
+	assert.NotEmpty(t, firstPage.NextCursor)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, saleOrders[2:], secondPage.Items)
+	assert.Empty(t, secondPage.NextCursor)
+}
+
+func TestListOrders_ValidationError(t *testing.T) {
+	badStatus := document.Status(999)
+	otherSortCursor := encodeListCursor(
+		document.SaleOrderSort{Field: document.SaleOrderSortByNumber},
+		&document.SaleOrder{Document: document.Document{ID: 1}},
+	)
+
+	tests := []struct {
+		name   string
+		query  document.SaleOrderListQuery
+		reason string
+	}{
+		{
+			name:   "bad sort field",
+			query:  document.SaleOrderListQuery{Sort: document.SaleOrderSort{Field: "customer"}},
+			reason: "bad sort field: customer",
+		},
+		{
+			name:   "negative limit",
+			query:  document.SaleOrderListQuery{Limit: -1},
+			reason: "bad limit: -1",
+		},
+		{
+			name:   "too big limit",
+			query:  document.SaleOrderListQuery{Limit: maxListLimit + 1},
+			reason: "bad limit: 101",
+		},
+		{
+			name:   "bad status",
+			query:  document.SaleOrderListQuery{Filter: document.SaleOrderFilter{Status: &badStatus}},
+			reason: "bad status: 999",
+		},
+		{
+			name:   "malformed cursor",
+			query:  document.SaleOrderListQuery{Cursor: "!!!"},
+			reason: "bad cursor",
+		},
+		{
+			name:   "cursor for another sort order",
+			query:  document.SaleOrderListQuery{Cursor: otherSortCursor},
+			reason: "cursor does not match sort order",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+
+			repositoryMock := mocks.NewMockrepository(ctrl)
+			productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+			customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+			companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+			stockServiceMock := mocks.NewMockstockService(ctrl)
+			eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+			service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+			// act
+			actual, actualErr := service.ListOrders(ctx, tt.query)
+
+			// assert
+			var errTarget *domainerrors.ErrValidation
+			assert.Nil(t, actual)
+			assert.ErrorAs(t, actualErr, &errTarget)
+			assert.ErrorContains(t, actualErr, tt.reason)
+		})
+	}
+}
+
+func TestListOrders_ListError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	listErr := errors.New("list error")
+
+	repositoryMock.EXPECT().
+		List(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, listErr)
+
+	// act
+	actual, actualErr := service.ListOrders(ctx, document.SaleOrderListQuery{})
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, actualErr, listErr.Error())
+}
+
+func TestPriceOrder_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Products: []document.SaleOrderProduct{
+			{Product: reference.Product{Reference: reference.Reference{ID: 2}}, Quantity: 1},
+			{Product: reference.Product{Reference: reference.Reference{ID: 1}}, Quantity: 2},
+			{Product: reference.Product{Reference: reference.Reference{ID: 2}}, Quantity: 3},
+		},
+	}
+
+	productRepositoryMock.
+		EXPECT().
+		GetPrices(ctx, []uint64{1, 2}).
+		Return(map[uint64]money.Money{1: money.New(1000, money.DefaultCurrency), 2: money.New(2050, money.DefaultCurrency)}, nil)
+
+	// act
+	actualSaleOrder, actualErr := service.PriceOrder(ctx, saleOrder)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, money.DefaultCurrency, actualSaleOrder.Currency)
+	assert.Equal(t, money.New(2050, money.DefaultCurrency), actualSaleOrder.Products[0].Price)
+	assert.Equal(t, money.New(1000, money.DefaultCurrency), actualSaleOrder.Products[1].Price)
+	assert.Equal(t, money.New(2050, money.DefaultCurrency), actualSaleOrder.Products[2].Price)
+	assert.Equal(t, money.New(10200, money.DefaultCurrency), actualSaleOrder.Total())
+}
+
+func TestPriceOrder_ManualPriceOverride(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyOverride)
+
+	saleOrder := &document.SaleOrder{
+		Currency: "USD",
+		Products: []document.SaleOrderProduct{
+			{Product: reference.Product{Reference: reference.Reference{ID: 1}}, Quantity: 1},
+			{Product: reference.Product{Reference: reference.Reference{ID: 2}}, Quantity: 1, Price: money.New(500, ""), ManualPrice: true},
+		},
+	}
+
+	productRepositoryMock.
+		EXPECT().
+		GetPrices(ctx, []uint64{1}).
+		Return(map[uint64]money.Money{1: money.New(1000, "USD")}, nil)
+
+	// act
+	actualSaleOrder, actualErr := service.PriceOrder(ctx, saleOrder)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, money.New(1000, "USD"), actualSaleOrder.Products[0].Price)
+	assert.Equal(t, money.New(500, "USD"), actualSaleOrder.Products[1].Price)
+	assert.Equal(t, money.New(1500, "USD"), actualSaleOrder.Total())
+}
+
+func TestPriceOrder_ValidationError(t *testing.T) {
+	tests := []struct {
+		name        string
+		pricePolicy PricePolicy
+		product     document.SaleOrderProduct
+		reason      string
+	}{
+		{
+			name:        "manual price rejected",
+			pricePolicy: PricePolicyReject,
+			product:     document.SaleOrderProduct{Product: reference.Product{Reference: reference.Reference{ID: 1}}, Price: money.New(500, ""), ManualPrice: true},
+			reason:      "price override is not allowed: product 1",
+		},
+		{
+			name:        "negative manual price",
+			pricePolicy: PricePolicyOverride,
+			product:     document.SaleOrderProduct{Product: reference.Product{Reference: reference.Reference{ID: 1}}, Price: money.New(-500, ""), ManualPrice: true},
+			reason:      "bad price: product 1",
+		},
+		{
+			name:        "product without price",
+			pricePolicy: PricePolicyReject,
+			product:     document.SaleOrderProduct{Product: reference.Product{Reference: reference.Reference{ID: 3}}},
+			reason:      "bad product id: 3",
+		},
+		{
+			name:        "price currency differs",
+			pricePolicy: PricePolicyReject,
+			product:     document.SaleOrderProduct{Product: reference.Product{Reference: reference.Reference{ID: 2}}},
+			reason:      "product 2 price currency USD differs from order currency RUB",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+
+			repositoryMock := mocks.NewMockrepository(ctrl)
+			productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+			customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+			companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+			stockServiceMock := mocks.NewMockstockService(ctrl)
+			eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+			service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, tt.pricePolicy)
+
+			saleOrder := &document.SaleOrder{
+				Products: []document.SaleOrderProduct{tt.product},
+			}
+
+			productRepositoryMock.
+				EXPECT().
+				GetPrices(ctx, gomock.Any()).
+				Return(map[uint64]money.Money{1: money.New(1000, "RUB"), 2: money.New(1000, "USD")}, nil)
+
+			// act
+			actualSaleOrder, actualErr := service.PriceOrder(ctx, saleOrder)
+
+			// assert
+			var errTarget *domainerrors.ErrValidation
+			assert.Nil(t, actualSaleOrder)
+			assert.ErrorAs(t, actualErr, &errTarget)
+			assert.ErrorContains(t, actualErr, tt.reason)
+		})
+	}
+}
+
+func TestPriceOrder_RepositoryError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Products: []document.SaleOrderProduct{
+			{Product: reference.Product{Reference: reference.Reference{ID: 1}}, Quantity: 1},
+		},
+	}
+
+	pricesErr := errors.New("prices error")
+
+	productRepositoryMock.
+		EXPECT().
+		GetPrices(ctx, []uint64{1}).
+		Return(nil, pricesErr)
+
+	// act
+	actualSaleOrder, actualErr := service.PriceOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorIs(t, actualErr, pricesErr)
+}
+
+func TestCreateOrder_ValidateError_DeletedCompany(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			Date:   time.Now().Truncate(time.Second),
+			Number: "0001",
+			Status: document.StatusDraft,
+			Company: reference.Company{
+				Reference: reference.Reference{
+					ID:     2,
+					Status: reference.StatusDeleted,
+				},
+			},
+		},
+	}
+
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(&saleOrder.Company, nil)
+
+	// act
+	actualSaleOrder, actualErr := service.CreateOrder(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, actualErr, "company is deleted: 2")
+}
+
+func TestChangeStatus_Delete_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	changeUser := &reference.User{Reference: reference.Reference{ID: 5}}
+	now := time.Now()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:     1,
+			Status: document.StatusDraft,
+		},
+	}
+
+	repositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.ID).
+		Return(saleOrder, nil)
+
+	repositoryMock.EXPECT().
+		UpdateStatus(ctx, saleOrder, document.StatusDeleted).
+		Return(nil)
+
+	stockServiceMock.EXPECT().
+		Release(ctx, saleOrder.ID).
+		Return(nil)
+
+	eventPublisherMock.EXPECT().
+		Publish(ctx, event.TypeSaleOrderDeleted, saleOrder.ID, gomock.Any()).
+		Return(nil)
+
+	// act
+	actualSaleOrder, actualErr := service.ChangeStatus(ctx, saleOrder.ID, saleOrder.Version, document.StatusDeleted, changeUser, now)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, document.StatusDeleted, actualSaleOrder.Status)
+}
+
+func TestCreateOrder_ValidateError_CheckCompanyError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	productRepositoryMock := mocks.NewMockproductRepository(ctrl)
+	customerRepositoryMock := mocks.NewMockcustomerRepository(ctrl)
+	companyRepositoryMock := mocks.NewMockcompanyRepository(ctrl)
+	stockServiceMock := mocks.NewMockstockService(ctrl)
+	eventPublisherMock := mocks.NewMockeventPublisher(ctrl)
+
+	service := NewService(repositoryMock, productRepositoryMock, customerRepositoryMock, companyRepositoryMock, stockServiceMock, eventPublisherMock, PricePolicyReject)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			Date:   time.Now().Truncate(time.Second),
+			Number: "0001",
+			Status: document.StatusDraft,
+			Company: reference.Company{
+				Reference: reference.Reference{
+					ID: 1,
+				},
+			},
+		},
+	}
+
+	checkErr := errors.New("some db error")
+
+	companyRepositoryMock.EXPECT().
+		GetByID(ctx, saleOrder.Company.ID).
+		Return(nil, checkErr)
+
+	// act
+	actualSaleOrder, actualErr := service.CreateOrder(ctx, saleOrder)
+
+	// assert
+	var errValidation *domainerrors.ErrValidation
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorIs(t, actualErr, checkErr)
+	assert.False(t, errors.As(actualErr, &errValidation))
+}
@@ -0,0 +1,74 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package stock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+)
+
+type repository interface {
+	Reserve(ctx context.Context, saleOrderID uint64, productID uint64, quantity int) (bool, error)
+	Release(ctx context.Context, saleOrderID uint64) error
+}
+
+// Service keeps stock reservations in line with sale orders.
+// It must be called in the transaction that saves the sale order.
+type Service struct {
+	repository repository
+}
+
+func NewService(r repository) *Service {
+	return &Service{
+		repository: r,
+	}
+}
+
+// Reserve replaces reservations of the order with its current lines.
+// Lines with a non-positive quantity, which would decrease the reserved stock, and lines of products
+// without enough free stock are reported as ErrValidation.
+func (s *Service) Reserve(ctx context.Context, order *document.SaleOrder) error {
+	var fieldErrors []errors.FieldError
+	for i, product := range order.Products {
+		if product.Quantity <= 0 {
+			fieldErrors = append(fieldErrors, errors.FieldError{
+				Field:   fmt.Sprintf("products[%d].quantity", i),
+				Message: "must be positive",
+			})
+		}
+	}
+	if len(fieldErrors) > 0 {
+		return errors.NewErrValidation("bad quantity", nil, fieldErrors...)
+	}
+
+	err := s.repository.Release(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	for i, product := range order.Products {
+		reserved, err := s.repository.Reserve(ctx, order.ID, product.Product.ID, product.Quantity)
+		if err != nil {
+			return err
+		}
+		if !reserved {
+			fieldErrors = append(fieldErrors, errors.FieldError{
+				Field:   fmt.Sprintf("products[%d].quantity", i),
+				Message: fmt.Sprintf("insufficient stock of product %d", product.Product.ID),
+			})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return errors.NewErrValidation("insufficient stock", nil, fieldErrors...)
+	}
+
+	return nil
+}
+
+// Release removes all reservations of the order.
+func (s *Service) Release(ctx context.Context, orderID uint64) error {
+	return s.repository.Release(ctx, orderID)
+}
@@ -0,0 +1,169 @@
+package stock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/service/stock/mocks"
+)
+
+func newSaleOrder() *document.SaleOrder {
+	return &document.SaleOrder{
+		Document: document.Document{
+			ID: 100,
+		},
+		Products: []document.SaleOrderProduct{
+			{
+				Product:  reference.Product{Reference: reference.Reference{ID: 1}},
+				Quantity: 3,
+			},
+			{
+				Product:  reference.Product{Reference: reference.Reference{ID: 2}},
+				Quantity: 1,
+			},
+		},
+	}
+}
+
+func TestReserve_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+
+	service := NewService(repositoryMock)
+
+	saleOrder := newSaleOrder()
+
+	gomock.InOrder(
+		repositoryMock.EXPECT().Release(ctx, saleOrder.ID).Return(nil),
+		repositoryMock.EXPECT().Reserve(ctx, saleOrder.ID, uint64(1), 3).Return(true, nil),
+		repositoryMock.EXPECT().Reserve(ctx, saleOrder.ID, uint64(2), 1).Return(true, nil),
+	)
+
+	// act
+	err := service.Reserve(ctx, saleOrder)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestReserve_InsufficientStock(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+
+	service := NewService(repositoryMock)
+
+	saleOrder := newSaleOrder()
+
+	repositoryMock.EXPECT().Release(ctx, saleOrder.ID).Return(nil)
+	repositoryMock.EXPECT().Reserve(ctx, saleOrder.ID, uint64(1), 3).Return(true, nil)
+	repositoryMock.EXPECT().Reserve(ctx, saleOrder.ID, uint64(2), 1).Return(false, nil)
+
+	// act
+	err := service.Reserve(ctx, saleOrder)
+
+	// assert
+	var errValidation *domainerrors.ErrValidation
+	assert.ErrorAs(t, err, &errValidation)
+	assert.Equal(t, []domainerrors.FieldError{
+		{Field: "products[1].quantity", Message: "insufficient stock of product 2"},
+	}, errValidation.Fields())
+}
+
+func TestReserve_NonPositiveQuantity(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+
+	service := NewService(repositoryMock)
+
+	saleOrder := newSaleOrder()
+	saleOrder.Products[0].Quantity = 0
+	saleOrder.Products[1].Quantity = -1
+
+	// act
+	err := service.Reserve(ctx, saleOrder)
+
+	// assert
+	var errValidation *domainerrors.ErrValidation
+	assert.ErrorAs(t, err, &errValidation)
+	assert.Equal(t, []domainerrors.FieldError{
+		{Field: "products[0].quantity", Message: "must be positive"},
+		{Field: "products[1].quantity", Message: "must be positive"},
+	}, errValidation.Fields())
+}
+
+func TestReserve_ReleaseError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+
+	service := NewService(repositoryMock)
+
+	saleOrder := newSaleOrder()
+	releaseErr := errors.New("release error")
+
+	repositoryMock.EXPECT().Release(ctx, saleOrder.ID).Return(releaseErr)
+
+	// act
+	err := service.Reserve(ctx, saleOrder)
+
+	// assert
+	assert.ErrorIs(t, err, releaseErr)
+}
+
+func TestReserve_ReserveError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+
+	service := NewService(repositoryMock)
+
+	saleOrder := newSaleOrder()
+	reserveErr := errors.New("reserve error")
+
+	repositoryMock.EXPECT().Release(ctx, saleOrder.ID).Return(nil)
+	repositoryMock.EXPECT().Reserve(ctx, saleOrder.ID, uint64(1), 3).Return(false, reserveErr)
+
+	// act
+	err := service.Reserve(ctx, saleOrder)
+
+	// assert
+	assert.ErrorIs(t, err, reserveErr)
+}
+
+func TestRelease(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+
+	service := NewService(repositoryMock)
+
+	repositoryMock.EXPECT().Release(ctx, uint64(100)).Return(nil)
+
+	// act
+	err := service.Release(ctx, 100)
+
+	// assert
+	assert.NoError(t, err)
+}
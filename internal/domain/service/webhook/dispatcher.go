@@ -0,0 +1,120 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package webhook
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+	"github.com/kiaplayer/clean-architecture-example/pkg/helpers"
+	webhookclient "github.com/kiaplayer/clean-architecture-example/pkg/webhook"
+)
+
+type dispatcherRepository interface {
+	GetPendingDeliveries(ctx context.Context, now time.Time, limit int) ([]*webhook.Delivery, error)
+	UpdateDelivery(ctx context.Context, delivery *webhook.Delivery) error
+}
+
+type sender interface {
+	Send(ctx context.Context, req webhookclient.Request) (int, error)
+}
+
+type DispatcherConfig struct {
+	// PollInterval is the pause between delivery log polls.
+	PollInterval time.Duration
+	// BatchSize is the max number of deliveries attempted per poll.
+	BatchSize int
+	// MaxAttempts is the number of attempts after which the delivery fails, it can be replayed then.
+	MaxAttempts int
+	// RetryDelay is the delay after the first failed attempt, it doubles after every next one up to MaxRetryDelay.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+}
+
+var DefaultDispatcherConfig = DispatcherConfig{
+	PollInterval:  time.Second,
+	BatchSize:     100,
+	MaxAttempts:   8,
+	RetryDelay:    10 * time.Second,
+	MaxRetryDelay: time.Hour,
+}
+
+// Dispatcher makes pending webhook deliveries and records their results in the delivery log.
+type Dispatcher struct {
+	repository    dispatcherRepository
+	sender        sender
+	timeGenerator timeGenerator
+	config        DispatcherConfig
+}
+
+func NewDispatcher(r dispatcherRepository, s sender, tg timeGenerator, config DispatcherConfig) *Dispatcher {
+	return &Dispatcher{
+		repository:    r,
+		sender:        s,
+		timeGenerator: tg,
+		config:        config,
+	}
+}
+
+// Run makes pending deliveries every poll interval until the context is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := d.Dispatch(ctx)
+			if err != nil {
+				log.Printf("Webhook dispatch error: %v", err)
+			}
+		}
+	}
+}
+
+// Dispatch attempts one batch of pending deliveries and returns the number of successful ones.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	deliveries, err := d.repository.GetPendingDeliveries(ctx, d.timeGenerator.NowDate(), d.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		responseStatus, sendErr := d.sender.Send(ctx, webhookclient.Request{
+			URL:        delivery.Subscription.URL,
+			Secret:     delivery.Subscription.Secret,
+			Event:      string(delivery.EventType),
+			DeliveryID: delivery.ID,
+			Body:       delivery.Payload,
+		})
+
+		now := d.timeGenerator.NowDate()
+		delivery.Attempts++
+		delivery.ResponseStatus = responseStatus
+		delivery.UpdatedAt = now
+
+		switch {
+		case sendErr == nil:
+			delivery.Status = webhook.DeliveryStatusDelivered
+			delivery.LastError = ""
+			delivered++
+		case delivery.Attempts >= d.config.MaxAttempts:
+			delivery.Status = webhook.DeliveryStatusFailed
+			delivery.LastError = sendErr.Error()
+		default:
+			delivery.LastError = sendErr.Error()
+			delivery.NextAttemptAt = now.Add(helpers.Backoff(d.config.RetryDelay, d.config.MaxRetryDelay, delivery.Attempts-1))
+		}
+
+		err = d.repository.UpdateDelivery(ctx, delivery)
+		if err != nil {
+			return delivered, err
+		}
+	}
+
+	return delivered, nil
+}
@@ -0,0 +1,180 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/service/webhook/mocks"
+	webhookclient "github.com/kiaplayer/clean-architecture-example/pkg/webhook"
+)
+
+func newDelivery(url string, attempts int) *webhook.Delivery {
+	return &webhook.Delivery{
+		ID: 1,
+		Subscription: webhook.Subscription{
+			ID:     1,
+			URL:    url,
+			Secret: "secret",
+		},
+		EventID:   10,
+		EventType: event.TypeSaleOrderCreated,
+		Payload:   []byte(`{"id":10}`),
+		Status:    webhook.DeliveryStatusPending,
+		Attempts:  attempts,
+	}
+}
+
+func TestDispatch_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	var signature, timestamp string
+	var receivedBody []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		signature = request.Header.Get(webhookclient.HeaderSignature)
+		timestamp = request.Header.Get(webhookclient.HeaderTimestamp)
+		receivedBody, _ = io.ReadAll(request.Body)
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	repositoryMock := mocks.NewMockdispatcherRepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	dispatcher := NewDispatcher(repositoryMock, webhookclient.NewClient(receiver.Client()), timeGeneratorMock, DefaultDispatcherConfig)
+
+	now := time.Now()
+	delivery := newDelivery(receiver.URL, 0)
+
+	timeGeneratorMock.EXPECT().NowDate().Return(now).AnyTimes()
+	repositoryMock.EXPECT().
+		GetPendingDeliveries(ctx, now, DefaultDispatcherConfig.BatchSize).
+		Return([]*webhook.Delivery{delivery}, nil)
+	repositoryMock.EXPECT().
+		UpdateDelivery(ctx, delivery).
+		Return(nil)
+
+	// act
+	delivered, err := dispatcher.Dispatch(ctx)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, webhook.DeliveryStatusDelivered, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusOK, delivery.ResponseStatus)
+	assert.Equal(t, delivery.Payload, receivedBody)
+	assert.Equal(t, "sha256="+webhookclient.Sign("secret", timestamp, receivedBody), signature)
+}
+
+func TestDispatch_Retry(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	repositoryMock := mocks.NewMockdispatcherRepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	dispatcher := NewDispatcher(repositoryMock, webhookclient.NewClient(receiver.Client()), timeGeneratorMock, DefaultDispatcherConfig)
+
+	now := time.Now()
+	delivery := newDelivery(receiver.URL, 2)
+
+	timeGeneratorMock.EXPECT().NowDate().Return(now).AnyTimes()
+	repositoryMock.EXPECT().
+		GetPendingDeliveries(ctx, now, DefaultDispatcherConfig.BatchSize).
+		Return([]*webhook.Delivery{delivery}, nil)
+	repositoryMock.EXPECT().
+		UpdateDelivery(ctx, delivery).
+		Return(nil)
+
+	// act
+	delivered, err := dispatcher.Dispatch(ctx)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Equal(t, webhook.DeliveryStatusPending, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, delivery.ResponseStatus)
+	assert.Equal(t, "unexpected response status: 503", delivery.LastError)
+	assert.Equal(t, now.Add(4*DefaultDispatcherConfig.RetryDelay), delivery.NextAttemptAt)
+}
+
+func TestDispatch_LastAttemptFails(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusBadRequest)
+	}))
+	defer receiver.Close()
+
+	repositoryMock := mocks.NewMockdispatcherRepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	dispatcher := NewDispatcher(repositoryMock, webhookclient.NewClient(receiver.Client()), timeGeneratorMock, DefaultDispatcherConfig)
+
+	now := time.Now()
+	delivery := newDelivery(receiver.URL, DefaultDispatcherConfig.MaxAttempts-1)
+
+	timeGeneratorMock.EXPECT().NowDate().Return(now).AnyTimes()
+	repositoryMock.EXPECT().
+		GetPendingDeliveries(ctx, now, DefaultDispatcherConfig.BatchSize).
+		Return([]*webhook.Delivery{delivery}, nil)
+	repositoryMock.EXPECT().
+		UpdateDelivery(ctx, delivery).
+		Return(nil)
+
+	// act
+	delivered, err := dispatcher.Dispatch(ctx)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Equal(t, webhook.DeliveryStatusFailed, delivery.Status)
+	assert.Equal(t, DefaultDispatcherConfig.MaxAttempts, delivery.Attempts)
+}
+
+func TestDispatch_RepositoryError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockdispatcherRepository(ctrl)
+	senderMock := mocks.NewMocksender(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	dispatcher := NewDispatcher(repositoryMock, senderMock, timeGeneratorMock, DefaultDispatcherConfig)
+
+	expectedErr := errors.New("db error")
+
+	timeGeneratorMock.EXPECT().NowDate().Return(time.Now())
+	repositoryMock.EXPECT().
+		GetPendingDeliveries(ctx, gomock.Any(), gomock.Any()).
+		Return(nil, expectedErr)
+
+	// act
+	delivered, err := dispatcher.Dispatch(ctx)
+
+	// assert
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Equal(t, 0, delivered)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dispatcher.go
+//
+// Generated by this command:
+//
+//	mockgen -package=webhook -source=dispatcher.go -destination=mocks/dispatcher.go
+//
+
+// Package webhook is a generated GoMock package.
+package webhook
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	webhook "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+	webhook0 "github.com/kiaplayer/clean-architecture-example/pkg/webhook"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockdispatcherRepository is a mock of dispatcherRepository interface.
+type MockdispatcherRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockdispatcherRepositoryMockRecorder
+}
+
+// MockdispatcherRepositoryMockRecorder is the mock recorder for MockdispatcherRepository.
+type MockdispatcherRepositoryMockRecorder struct {
+	mock *MockdispatcherRepository
+}
+
+// NewMockdispatcherRepository creates a new mock instance.
+func NewMockdispatcherRepository(ctrl *gomock.Controller) *MockdispatcherRepository {
+	mock := &MockdispatcherRepository{ctrl: ctrl}
+	mock.recorder = &MockdispatcherRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdispatcherRepository) EXPECT() *MockdispatcherRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPendingDeliveries mocks base method.
+func (m *MockdispatcherRepository) GetPendingDeliveries(ctx context.Context, now time.Time, limit int) ([]*webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingDeliveries", ctx, now, limit)
+	ret0, _ := ret[0].([]*webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingDeliveries indicates an expected call of GetPendingDeliveries.
+func (mr *MockdispatcherRepositoryMockRecorder) GetPendingDeliveries(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingDeliveries", reflect.TypeOf((*MockdispatcherRepository)(nil).GetPendingDeliveries), ctx, now, limit)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockdispatcherRepository) UpdateDelivery(ctx context.Context, delivery *webhook.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockdispatcherRepositoryMockRecorder) UpdateDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockdispatcherRepository)(nil).UpdateDelivery), ctx, delivery)
+}
+
+// Mocksender is a mock of sender interface.
+type Mocksender struct {
+	ctrl     *gomock.Controller
+	recorder *MocksenderMockRecorder
+}
+
+// MocksenderMockRecorder is the mock recorder for Mocksender.
+type MocksenderMockRecorder struct {
+	mock *Mocksender
+}
+
+// NewMocksender creates a new mock instance.
+func NewMocksender(ctrl *gomock.Controller) *Mocksender {
+	mock := &Mocksender{ctrl: ctrl}
+	mock.recorder = &MocksenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocksender) EXPECT() *MocksenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *Mocksender) Send(ctx context.Context, req webhook0.Request) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, req)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MocksenderMockRecorder) Send(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*Mocksender)(nil).Send), ctx, req)
+}
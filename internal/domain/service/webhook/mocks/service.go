@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -package=webhook -source=service.go -destination=mocks/service.go
+//
+
+// Package webhook is a generated GoMock package.
+package webhook
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	webhook "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockrepository is a mock of repository interface.
+type Mockrepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockrepositoryMockRecorder
+}
+
+// MockrepositoryMockRecorder is the mock recorder for Mockrepository.
+type MockrepositoryMockRecorder struct {
+	mock *Mockrepository
+}
+
+// NewMockrepository creates a new mock instance.
+func NewMockrepository(ctrl *gomock.Controller) *Mockrepository {
+	mock := &Mockrepository{ctrl: ctrl}
+	mock.recorder = &MockrepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockrepository) EXPECT() *MockrepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddDelivery mocks base method.
+func (m *Mockrepository) AddDelivery(ctx context.Context, delivery *webhook.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDelivery indicates an expected call of AddDelivery.
+func (mr *MockrepositoryMockRecorder) AddDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDelivery", reflect.TypeOf((*Mockrepository)(nil).AddDelivery), ctx, delivery)
+}
+
+// GetActiveSubscriptions mocks base method.
+func (m *Mockrepository) GetActiveSubscriptions(ctx context.Context, companyID uint64) ([]*webhook.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSubscriptions", ctx, companyID)
+	ret0, _ := ret[0].([]*webhook.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSubscriptions indicates an expected call of GetActiveSubscriptions.
+func (mr *MockrepositoryMockRecorder) GetActiveSubscriptions(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSubscriptions", reflect.TypeOf((*Mockrepository)(nil).GetActiveSubscriptions), ctx, companyID)
+}
+
+// GetDeliveryByID mocks base method.
+func (m *Mockrepository) GetDeliveryByID(ctx context.Context, id uint64) (*webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryByID", ctx, id)
+	ret0, _ := ret[0].(*webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryByID indicates an expected call of GetDeliveryByID.
+func (mr *MockrepositoryMockRecorder) GetDeliveryByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryByID", reflect.TypeOf((*Mockrepository)(nil).GetDeliveryByID), ctx, id)
+}
+
+// ListDeliveries mocks base method.
+func (m *Mockrepository) ListDeliveries(ctx context.Context, status webhook.DeliveryStatus, limit int) ([]*webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, status, limit)
+	ret0, _ := ret[0].([]*webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockrepositoryMockRecorder) ListDeliveries(ctx, status, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*Mockrepository)(nil).ListDeliveries), ctx, status, limit)
+}
+
+// UpdateDelivery mocks base method.
+func (m *Mockrepository) UpdateDelivery(ctx context.Context, delivery *webhook.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockrepositoryMockRecorder) UpdateDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*Mockrepository)(nil).UpdateDelivery), ctx, delivery)
+}
+
+// MocktimeGenerator is a mock of timeGenerator interface.
+type MocktimeGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MocktimeGeneratorMockRecorder
+}
+
+// MocktimeGeneratorMockRecorder is the mock recorder for MocktimeGenerator.
+type MocktimeGeneratorMockRecorder struct {
+	mock *MocktimeGenerator
+}
+
+// NewMocktimeGenerator creates a new mock instance.
+func NewMocktimeGenerator(ctrl *gomock.Controller) *MocktimeGenerator {
+	mock := &MocktimeGenerator{ctrl: ctrl}
+	mock.recorder = &MocktimeGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktimeGenerator) EXPECT() *MocktimeGeneratorMockRecorder {
+	return m.recorder
+}
+
+// NowDate mocks base method.
+func (m *MocktimeGenerator) NowDate() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NowDate")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// NowDate indicates an expected call of NowDate.
+func (mr *MocktimeGeneratorMockRecorder) NowDate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NowDate", reflect.TypeOf((*MocktimeGenerator)(nil).NowDate))
+}
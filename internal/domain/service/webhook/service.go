@@ -0,0 +1,134 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+)
+
+type repository interface {
+	GetActiveSubscriptions(ctx context.Context, companyID uint64) ([]*webhook.Subscription, error)
+	AddDelivery(ctx context.Context, delivery *webhook.Delivery) error
+	ListDeliveries(ctx context.Context, status webhook.DeliveryStatus, limit int) ([]*webhook.Delivery, error)
+	GetDeliveryByID(ctx context.Context, id uint64) (*webhook.Delivery, error)
+	UpdateDelivery(ctx context.Context, delivery *webhook.Delivery) error
+}
+
+type timeGenerator interface {
+	NowDate() time.Time
+}
+
+const listDeliveriesLimit = 100
+
+// body is the webhook request body.
+type body struct {
+	ID        uint64          `json:"id"`
+	Type      event.Type      `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Service queues events for webhook subscriptions and manages the delivery log.
+type Service struct {
+	repository    repository
+	timeGenerator timeGenerator
+}
+
+func NewService(r repository, tg timeGenerator) *Service {
+	return &Service{
+		repository:    r,
+		timeGenerator: tg,
+	}
+}
+
+// Handle queues deliveries of the event to the subscriptions of the event company.
+// It is an outbox sink, deliveries themselves are made by the Dispatcher.
+func (s *Service) Handle(ctx context.Context, e *event.Event) error {
+	var payload event.SaleOrderPayload
+	err := json.Unmarshal(e.Payload, &payload)
+	if err != nil {
+		return fmt.Errorf("bad event payload: %w", err)
+	}
+
+	subscriptions, err := s.repository.GetActiveSubscriptions(ctx, payload.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(body{
+		ID:        e.ID,
+		Type:      e.Type,
+		CreatedAt: e.CreatedAt,
+		Data:      e.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := s.timeGenerator.NowDate()
+	for _, subscription := range subscriptions {
+		if !slices.Contains(subscription.EventTypes, e.Type) {
+			continue
+		}
+		err = s.repository.AddDelivery(ctx, &webhook.Delivery{
+			Subscription:  *subscription,
+			EventID:       e.ID,
+			EventType:     e.Type,
+			Payload:       data,
+			Status:        webhook.DeliveryStatusPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListDeliveries returns the latest deliveries of the user company in the status.
+func (s *Service) ListDeliveries(ctx context.Context, status webhook.DeliveryStatus) ([]*webhook.Delivery, error) {
+	if !slices.Contains(webhook.ValidDeliveryStatuses, status) {
+		return nil, errors.NewErrValidation(fmt.Sprintf("bad delivery status: %d", status), nil)
+	}
+	return s.repository.ListDeliveries(ctx, status, listDeliveriesLimit)
+}
+
+// ReplayDelivery makes the failed delivery pending again with a fresh attempt budget.
+func (s *Service) ReplayDelivery(ctx context.Context, id uint64) (*webhook.Delivery, error) {
+	delivery, err := s.repository.GetDeliveryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, errors.NewErrNotFound(fmt.Sprintf("webhook delivery not found: %d", id), nil)
+	}
+	if delivery.Status != webhook.DeliveryStatusFailed {
+		return nil, errors.NewErrConflict(
+			fmt.Sprintf("webhook delivery %d cannot be replayed in status: %s", id, delivery.Status),
+			nil,
+		)
+	}
+
+	now := s.timeGenerator.NowDate()
+	delivery.Status = webhook.DeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = now
+	delivery.UpdatedAt = now
+
+	err = s.repository.UpdateDelivery(ctx, delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
@@ -0,0 +1,225 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/service/webhook/mocks"
+)
+
+func TestHandle_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service := NewService(repositoryMock, timeGeneratorMock)
+
+	now := time.Now().Truncate(time.Second)
+	createdAt := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	e := &event.Event{
+		ID:          10,
+		Type:        event.TypeSaleOrderPosted,
+		AggregateID: 100,
+		Payload:     []byte(`{"id":100,"company_id":1}`),
+		CreatedAt:   createdAt,
+	}
+	subscriptions := []*webhook.Subscription{
+		{ID: 1, CompanyID: 1, EventTypes: []event.Type{event.TypeSaleOrderCreated, event.TypeSaleOrderPosted}},
+		{ID: 2, CompanyID: 1, EventTypes: []event.Type{event.TypeSaleOrderCreated}},
+	}
+	expectedBody := []byte(`{"id":10,"type":"SaleOrderPosted","created_at":"2024-03-15T10:30:00Z","data":{"id":100,"company_id":1}}`)
+
+	timeGeneratorMock.EXPECT().NowDate().Return(now)
+
+	repositoryMock.EXPECT().
+		GetActiveSubscriptions(ctx, uint64(1)).
+		Return(subscriptions, nil)
+
+	repositoryMock.EXPECT().
+		AddDelivery(ctx, &webhook.Delivery{
+			Subscription:  *subscriptions[0],
+			EventID:       10,
+			EventType:     event.TypeSaleOrderPosted,
+			Payload:       expectedBody,
+			Status:        webhook.DeliveryStatusPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}).
+		Return(nil)
+
+	// act
+	err := service.Handle(ctx, e)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestHandle_BadPayload(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service := NewService(repositoryMock, timeGeneratorMock)
+
+	// act
+	err := service.Handle(ctx, &event.Event{Payload: []byte(`bad`)})
+
+	// assert
+	assert.ErrorContains(t, err, "bad event payload")
+}
+
+func TestListDeliveries_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service := NewService(repositoryMock, timeGeneratorMock)
+
+	deliveries := []*webhook.Delivery{{ID: 1, Status: webhook.DeliveryStatusFailed}}
+
+	repositoryMock.EXPECT().
+		ListDeliveries(ctx, webhook.DeliveryStatusFailed, listDeliveriesLimit).
+		Return(deliveries, nil)
+
+	// act
+	actual, err := service.ListDeliveries(ctx, webhook.DeliveryStatusFailed)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, deliveries, actual)
+}
+
+func TestListDeliveries_BadStatus(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service := NewService(repositoryMock, timeGeneratorMock)
+
+	// act
+	actual, err := service.ListDeliveries(ctx, webhook.DeliveryStatus(99))
+
+	// assert
+	assert.Nil(t, actual)
+	var errTarget *domainerrors.ErrValidation
+	assert.ErrorAs(t, err, &errTarget)
+}
+
+func TestReplayDelivery_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service := NewService(repositoryMock, timeGeneratorMock)
+
+	now := time.Now()
+	delivery := &webhook.Delivery{
+		ID:        1,
+		Status:    webhook.DeliveryStatusFailed,
+		Attempts:  8,
+		LastError: "unexpected response status: 500",
+	}
+
+	repositoryMock.EXPECT().GetDeliveryByID(ctx, delivery.ID).Return(delivery, nil)
+	timeGeneratorMock.EXPECT().NowDate().Return(now)
+	repositoryMock.EXPECT().UpdateDelivery(ctx, delivery).Return(nil)
+
+	// act
+	actual, err := service.ReplayDelivery(ctx, delivery.ID)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, webhook.DeliveryStatusPending, actual.Status)
+	assert.Equal(t, 0, actual.Attempts)
+	assert.Equal(t, now, actual.NextAttemptAt)
+}
+
+func TestReplayDelivery_NotFound(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service := NewService(repositoryMock, timeGeneratorMock)
+
+	repositoryMock.EXPECT().GetDeliveryByID(ctx, uint64(1)).Return(nil, nil)
+
+	// act
+	actual, err := service.ReplayDelivery(ctx, 1)
+
+	// assert
+	assert.Nil(t, actual)
+	var errTarget *domainerrors.ErrNotFound
+	assert.ErrorAs(t, err, &errTarget)
+}
+
+func TestReplayDelivery_NotFailed(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service := NewService(repositoryMock, timeGeneratorMock)
+
+	repositoryMock.EXPECT().
+		GetDeliveryByID(ctx, uint64(1)).
+		Return(&webhook.Delivery{ID: 1, Status: webhook.DeliveryStatusDelivered}, nil)
+
+	// act
+	actual, err := service.ReplayDelivery(ctx, 1)
+
+	// assert
+	assert.Nil(t, actual)
+	var errTarget *domainerrors.ErrConflict
+	assert.ErrorAs(t, err, &errTarget)
+}
+
+func TestReplayDelivery_RepositoryError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	service := NewService(repositoryMock, timeGeneratorMock)
+
+	expectedErr := errors.New("db error")
+
+	repositoryMock.EXPECT().GetDeliveryByID(ctx, uint64(1)).Return(nil, expectedErr)
+
+	// act
+	actual, err := service.ReplayDelivery(ctx, 1)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorIs(t, err, expectedErr)
+}
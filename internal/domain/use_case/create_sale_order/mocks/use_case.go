@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	access "github.com/kiaplayer/clean-architecture-example/internal/domain/access"
 	document "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
 	reference "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
 	gomock "go.uber.org/mock/gomock"
@@ -80,17 +81,18 @@ func (m *MocknumberGenerator) EXPECT() *MocknumberGeneratorMockRecorder {
 }
 
 // GenerateNumber mocks base method.
-func (m *MocknumberGenerator) GenerateNumber(date time.Time, company reference.Company) string {
+func (m *MocknumberGenerator) GenerateNumber(ctx context.Context, date time.Time, company reference.Company) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateNumber", date, company)
+	ret := m.ctrl.Call(m, "GenerateNumber", ctx, date, company)
 	ret0, _ := ret[0].(string)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GenerateNumber indicates an expected call of GenerateNumber.
-func (mr *MocknumberGeneratorMockRecorder) GenerateNumber(date, company any) *gomock.Call {
+func (mr *MocknumberGeneratorMockRecorder) GenerateNumber(ctx, date, company any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateNumber", reflect.TypeOf((*MocknumberGenerator)(nil).GenerateNumber), date, company)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateNumber", reflect.TypeOf((*MocknumberGenerator)(nil).GenerateNumber), ctx, date, company)
 }
 
 // MocksaleOrderService is a mock of saleOrderService interface.
@@ -130,3 +132,55 @@ func (mr *MocksaleOrderServiceMockRecorder) CreateOrder(ctx, order any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MocksaleOrderService)(nil).CreateOrder), ctx, order)
 }
+
+// PriceOrder mocks base method.
+func (m *MocksaleOrderService) PriceOrder(ctx context.Context, order *document.SaleOrder) (*document.SaleOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PriceOrder", ctx, order)
+	ret0, _ := ret[0].(*document.SaleOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PriceOrder indicates an expected call of PriceOrder.
+func (mr *MocksaleOrderServiceMockRecorder) PriceOrder(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PriceOrder", reflect.TypeOf((*MocksaleOrderService)(nil).PriceOrder), ctx, order)
+}
+
+// Mockpolicy is a mock of policy interface.
+type Mockpolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockpolicyMockRecorder
+}
+
+// MockpolicyMockRecorder is the mock recorder for Mockpolicy.
+type MockpolicyMockRecorder struct {
+	mock *Mockpolicy
+}
+
+// NewMockpolicy creates a new mock instance.
+func NewMockpolicy(ctrl *gomock.Controller) *Mockpolicy {
+	mock := &Mockpolicy{ctrl: ctrl}
+	mock.recorder = &MockpolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpolicy) EXPECT() *MockpolicyMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *Mockpolicy) Authorize(ctx context.Context, permission access.Permission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockpolicyMockRecorder) Authorize(ctx, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*Mockpolicy)(nil).Authorize), ctx, permission)
+}
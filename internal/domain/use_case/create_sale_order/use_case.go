@@ -5,6 +5,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
 )
@@ -14,24 +15,31 @@ type timeGenerator interface {
 }
 
 type numberGenerator interface {
-	GenerateNumber(date time.Time, company reference.Company) string
+	GenerateNumber(ctx context.Context, date time.Time, company reference.Company) (string, error)
 }
 
 type saleOrderService interface {
+	PriceOrder(ctx context.Context, order *document.SaleOrder) (*document.SaleOrder, error)
 	CreateOrder(ctx context.Context, order *document.SaleOrder) (*document.SaleOrder, error)
 }
 
+type policy interface {
+	Authorize(ctx context.Context, permission access.Permission) error
+}
+
 type UseCase struct {
 	timeGenerator    timeGenerator
 	numberGenerator  numberGenerator
 	saleOrderService saleOrderService
+	policy           policy
 }
 
-func NewUseCase(tg timeGenerator, ng numberGenerator, sos saleOrderService) *UseCase {
+func NewUseCase(tg timeGenerator, ng numberGenerator, sos saleOrderService, p policy) *UseCase {
 	return &UseCase{
 		timeGenerator:    tg,
 		numberGenerator:  ng,
 		saleOrderService: sos,
+		policy:           p,
 	}
 }
 
@@ -39,8 +47,27 @@ func (u *UseCase) Handle(
 	ctx context.Context,
 	saleOrder *document.SaleOrder,
 ) (saleOrderUpdated *document.SaleOrder, err error) {
+	err = u.policy.Authorize(ctx, access.PermissionCreateOrders)
+	if err != nil {
+		return nil, err
+	}
+	user := access.UserFromContext(ctx)
+	if user != nil {
+		saleOrder.Company = user.Company
+	}
 	saleOrder.Date = u.timeGenerator.NowDate()
-	saleOrder.Number = u.numberGenerator.GenerateNumber(saleOrder.Date, saleOrder.Company)
+	saleOrder.AppendUser = user
+	saleOrder.ChangeUser = user
+	saleOrder.CreatedAt = saleOrder.Date
+	saleOrder.UpdatedAt = saleOrder.Date
+	saleOrder.Number, err = u.numberGenerator.GenerateNumber(ctx, saleOrder.Date, saleOrder.Company)
+	if err != nil {
+		return nil, err
+	}
+	saleOrder, err = u.saleOrderService.PriceOrder(ctx, saleOrder)
+	if err != nil {
+		return nil, err
+	}
 	saleOrderUpdated, err = u.saleOrderService.CreateOrder(ctx, saleOrder)
 	if err != nil {
 		return nil, err
@@ -9,7 +9,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
 	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/create_sale_order/mocks"
 )
@@ -17,20 +19,28 @@ import (
 func TestHandle_Success(t *testing.T) {
 	// arrange
 	ctrl := gomock.NewController(t)
-	ctx := context.Background()
+	user := &reference.User{
+		Reference: reference.Reference{ID: 5},
+		Company:   reference.Company{Reference: reference.Reference{ID: 2}},
+	}
+	ctx := access.WithUser(context.Background(), user)
 
 	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
 	numberGeneratorMock := mocks.NewMocknumberGenerator(ctrl)
 	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(timeGeneratorMock, numberGeneratorMock, saleOrderServiceMock, policyMock)
 
-	useCase := NewUseCase(timeGeneratorMock, numberGeneratorMock, saleOrderServiceMock)
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionCreateOrders).
+		Return(nil)
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
-			Date:       time.Now().Truncate(time.Second),
-			Number:     "2000-01-01-11-001",
-			Company:    reference.Company{},
-			AppendUser: nil,
+			Date:    time.Now().Truncate(time.Second),
+			Number:  "2000-01-01-11-001",
+			Company: user.Company,
 		},
 		Customer: reference.Customer{},
 		Products: []document.SaleOrderProduct{
@@ -43,7 +53,7 @@ func TestHandle_Success(t *testing.T) {
 					},
 				},
 				Quantity: 1,
-				Price:    100,
+				Price:    money.New(10000, money.DefaultCurrency),
 			},
 		},
 	}
@@ -53,8 +63,12 @@ func TestHandle_Success(t *testing.T) {
 		Return(saleOrder.Date)
 
 	numberGeneratorMock.EXPECT().
-		GenerateNumber(saleOrder.Date, saleOrder.Company).
-		Return(saleOrder.Number)
+		GenerateNumber(ctx, saleOrder.Date, saleOrder.Company).
+		Return(saleOrder.Number, nil)
+
+	saleOrderServiceMock.EXPECT().
+		PriceOrder(ctx, saleOrder).
+		Return(saleOrder, nil)
 
 	saleOrderServiceMock.EXPECT().
 		CreateOrder(ctx, saleOrder).
@@ -66,6 +80,10 @@ func TestHandle_Success(t *testing.T) {
 	// assert
 	assert.NoError(t, actualErr)
 	assert.Equal(t, saleOrder, actualSaleOrder)
+	assert.Equal(t, user, actualSaleOrder.AppendUser)
+	assert.Equal(t, user, actualSaleOrder.ChangeUser)
+	assert.Equal(t, saleOrder.Date, actualSaleOrder.CreatedAt)
+	assert.Equal(t, saleOrder.Date, actualSaleOrder.UpdatedAt)
 }
 
 func TestHandle_Error(t *testing.T) {
@@ -76,8 +94,13 @@ func TestHandle_Error(t *testing.T) {
 	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
 	numberGeneratorMock := mocks.NewMocknumberGenerator(ctrl)
 	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
 
-	useCase := NewUseCase(timeGeneratorMock, numberGeneratorMock, saleOrderServiceMock)
+	useCase := NewUseCase(timeGeneratorMock, numberGeneratorMock, saleOrderServiceMock, policyMock)
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionCreateOrders).
+		Return(nil)
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
@@ -93,8 +116,12 @@ func TestHandle_Error(t *testing.T) {
 		Return(saleOrder.Date)
 
 	numberGeneratorMock.EXPECT().
-		GenerateNumber(saleOrder.Date, saleOrder.Company).
-		Return(saleOrder.Number)
+		GenerateNumber(ctx, saleOrder.Date, saleOrder.Company).
+		Return(saleOrder.Number, nil)
+
+	saleOrderServiceMock.EXPECT().
+		PriceOrder(ctx, saleOrder).
+		Return(saleOrder, nil)
 
 	saleOrderServiceMock.EXPECT().
 		CreateOrder(ctx, saleOrder).
@@ -107,3 +134,114 @@ func TestHandle_Error(t *testing.T) {
 	assert.Nil(t, actualSaleOrder)
 	assert.ErrorContains(t, actualErr, createErr.Error())
 }
+
+func TestHandle_NumberError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+	numberGeneratorMock := mocks.NewMocknumberGenerator(ctrl)
+	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(timeGeneratorMock, numberGeneratorMock, saleOrderServiceMock, policyMock)
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionCreateOrders).
+		Return(nil)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			Date: time.Now().Truncate(time.Second),
+		},
+	}
+
+	numberErr := errors.New("number error")
+
+	timeGeneratorMock.EXPECT().
+		NowDate().
+		Return(saleOrder.Date)
+
+	numberGeneratorMock.EXPECT().
+		GenerateNumber(ctx, saleOrder.Date, saleOrder.Company).
+		Return("", numberErr)
+
+	// act
+	actualSaleOrder, actualErr := useCase.Handle(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, actualErr, numberErr.Error())
+}
+
+func TestHandle_PriceError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+	numberGeneratorMock := mocks.NewMocknumberGenerator(ctrl)
+	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(timeGeneratorMock, numberGeneratorMock, saleOrderServiceMock, policyMock)
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionCreateOrders).
+		Return(nil)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			Date:   time.Now().Truncate(time.Second),
+			Number: "0001",
+		},
+	}
+
+	priceErr := errors.New("price error")
+
+	timeGeneratorMock.EXPECT().
+		NowDate().
+		Return(saleOrder.Date)
+
+	numberGeneratorMock.EXPECT().
+		GenerateNumber(ctx, saleOrder.Date, saleOrder.Company).
+		Return(saleOrder.Number, nil)
+
+	saleOrderServiceMock.EXPECT().
+		PriceOrder(ctx, saleOrder).
+		Return(nil, priceErr)
+
+	// act
+	actualSaleOrder, actualErr := useCase.Handle(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, actualErr, priceErr.Error())
+}
+
+func TestHandle_AccessDenied(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+	numberGeneratorMock := mocks.NewMocknumberGenerator(ctrl)
+	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(timeGeneratorMock, numberGeneratorMock, saleOrderServiceMock, policyMock)
+
+	accessErr := errors.New("access denied")
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionCreateOrders).
+		Return(accessErr)
+
+	// act
+	actual, actualErr := useCase.Handle(ctx, &document.SaleOrder{})
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorIs(t, actualErr, accessErr)
+}
@@ -0,0 +1,59 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package delete_sale_order
+
+import (
+	"context"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+)
+
+type timeGenerator interface {
+	NowDate() time.Time
+}
+
+type saleOrderService interface {
+	ChangeStatus(
+		ctx context.Context,
+		id uint64,
+		version uint64,
+		status document.Status,
+		changeUser *reference.User,
+		updatedAt time.Time,
+	) (*document.SaleOrder, error)
+}
+
+type policy interface {
+	Authorize(ctx context.Context, permission access.Permission) error
+}
+
+type UseCase struct {
+	timeGenerator    timeGenerator
+	saleOrderService saleOrderService
+	policy           policy
+}
+
+func NewUseCase(tg timeGenerator, sos saleOrderService, p policy) *UseCase {
+	return &UseCase{
+		timeGenerator:    tg,
+		saleOrderService: sos,
+		policy:           p,
+	}
+}
+
+func (u *UseCase) Handle(ctx context.Context, id uint64, version uint64) (saleOrder *document.SaleOrder, err error) {
+	err = u.policy.Authorize(ctx, access.PermissionDeleteOrders)
+	if err != nil {
+		return nil, err
+	}
+	return u.saleOrderService.ChangeStatus(
+		ctx,
+		id,
+		version,
+		document.StatusDeleted,
+		access.UserFromContext(ctx),
+		u.timeGenerator.NowDate(),
+	)
+}
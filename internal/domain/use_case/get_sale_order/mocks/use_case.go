@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	access "github.com/kiaplayer/clean-architecture-example/internal/domain/access"
 	document "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -54,3 +55,40 @@ func (mr *MocksaleOrderServiceMockRecorder) GetOrderByID(ctx, id any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByID", reflect.TypeOf((*MocksaleOrderService)(nil).GetOrderByID), ctx, id)
 }
+
+// Mockpolicy is a mock of policy interface.
+type Mockpolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockpolicyMockRecorder
+}
+
+// MockpolicyMockRecorder is the mock recorder for Mockpolicy.
+type MockpolicyMockRecorder struct {
+	mock *Mockpolicy
+}
+
+// NewMockpolicy creates a new mock instance.
+func NewMockpolicy(ctrl *gomock.Controller) *Mockpolicy {
+	mock := &Mockpolicy{ctrl: ctrl}
+	mock.recorder = &MockpolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpolicy) EXPECT() *MockpolicyMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *Mockpolicy) Authorize(ctx context.Context, permission access.Permission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockpolicyMockRecorder) Authorize(ctx, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*Mockpolicy)(nil).Authorize), ctx, permission)
+}
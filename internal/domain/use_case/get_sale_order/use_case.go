@@ -4,6 +4,7 @@ package get_sale_order
 import (
 	"context"
 
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
 )
 
@@ -11,16 +12,26 @@ type saleOrderService interface {
 	GetOrderByID(ctx context.Context, id uint64) (*document.SaleOrder, error)
 }
 
+type policy interface {
+	Authorize(ctx context.Context, permission access.Permission) error
+}
+
 type UseCase struct {
 	saleOrderService saleOrderService
+	policy           policy
 }
 
-func NewUseCase(sos saleOrderService) *UseCase {
+func NewUseCase(sos saleOrderService, p policy) *UseCase {
 	return &UseCase{
 		saleOrderService: sos,
+		policy:           p,
 	}
 }
 
 func (u *UseCase) Handle(ctx context.Context, id uint64) (saleOrder *document.SaleOrder, err error) {
+	err = u.policy.Authorize(ctx, access.PermissionReadOrders)
+	if err != nil {
+		return nil, err
+	}
 	return u.saleOrderService.GetOrderByID(ctx, id)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
 	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/get_sale_order/mocks"
 )
@@ -18,8 +19,13 @@ func TestHandle_Success(t *testing.T) {
 	ctx := context.Background()
 
 	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
 
-	useCase := NewUseCase(saleOrderServiceMock)
+	useCase := NewUseCase(saleOrderServiceMock, policyMock)
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionReadOrders).
+		Return(nil)
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
@@ -45,8 +51,13 @@ func TestHandle_Error(t *testing.T) {
 	ctx := context.Background()
 
 	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(saleOrderServiceMock, policyMock)
 
-	useCase := NewUseCase(saleOrderServiceMock)
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionReadOrders).
+		Return(nil)
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
@@ -67,3 +78,27 @@ func TestHandle_Error(t *testing.T) {
 	assert.Nil(t, actualSaleOrder)
 	assert.ErrorContains(t, actualErr, getErr.Error())
 }
+
+func TestHandle_AccessDenied(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(saleOrderServiceMock, policyMock)
+
+	accessErr := errors.New("access denied")
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionReadOrders).
+		Return(accessErr)
+
+	// act
+	actual, actualErr := useCase.Handle(ctx, 1)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorIs(t, actualErr, accessErr)
+}
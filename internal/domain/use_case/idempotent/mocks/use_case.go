@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: use_case.go
+//
+// Generated by this command:
+//
+//	mockgen -package=idempotent -source=use_case.go -destination=mocks/use_case.go
+//
+
+// Package idempotent is a generated GoMock package.
+package idempotent
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	idempotency "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/idempotency"
+	db "github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mocktransactor is a mock of transactor interface.
+type Mocktransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactorMockRecorder
+}
+
+// MocktransactorMockRecorder is the mock recorder for Mocktransactor.
+type MocktransactorMockRecorder struct {
+	mock *Mocktransactor
+}
+
+// NewMocktransactor creates a new mock instance.
+func NewMocktransactor(ctrl *gomock.Controller) *Mocktransactor {
+	mock := &Mocktransactor{ctrl: ctrl}
+	mock.recorder = &MocktransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocktransactor) EXPECT() *MocktransactorMockRecorder {
+	return m.recorder
+}
+
+// RunInTx mocks base method.
+func (m *Mocktransactor) RunInTx(ctx context.Context, fn func(context.Context) (any, error), opts ...db.TxOption) (any, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, fn}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RunInTx", varargs...)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunInTx indicates an expected call of RunInTx.
+func (mr *MocktransactorMockRecorder) RunInTx(ctx, fn any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, fn}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInTx", reflect.TypeOf((*Mocktransactor)(nil).RunInTx), varargs...)
+}
+
+// Mockrepository is a mock of repository interface.
+type Mockrepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockrepositoryMockRecorder
+}
+
+// MockrepositoryMockRecorder is the mock recorder for Mockrepository.
+type MockrepositoryMockRecorder struct {
+	mock *Mockrepository
+}
+
+// NewMockrepository creates a new mock instance.
+func NewMockrepository(ctrl *gomock.Controller) *Mockrepository {
+	mock := &Mockrepository{ctrl: ctrl}
+	mock.recorder = &MockrepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockrepository) EXPECT() *MockrepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *Mockrepository) Get(ctx context.Context, key string) (*idempotency.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(*idempotency.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockrepositoryMockRecorder) Get(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockrepository)(nil).Get), ctx, key)
+}
+
+// Save mocks base method.
+func (m *Mockrepository) Save(ctx context.Context, record *idempotency.Record) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, record)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockrepositoryMockRecorder) Save(ctx, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*Mockrepository)(nil).Save), ctx, record)
+}
+
+// MocktimeGenerator is a mock of timeGenerator interface.
+type MocktimeGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MocktimeGeneratorMockRecorder
+}
+
+// MocktimeGeneratorMockRecorder is the mock recorder for MocktimeGenerator.
+type MocktimeGeneratorMockRecorder struct {
+	mock *MocktimeGenerator
+}
+
+// NewMocktimeGenerator creates a new mock instance.
+func NewMocktimeGenerator(ctrl *gomock.Controller) *MocktimeGenerator {
+	mock := &MocktimeGenerator{ctrl: ctrl}
+	mock.recorder = &MocktimeGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktimeGenerator) EXPECT() *MocktimeGeneratorMockRecorder {
+	return m.recorder
+}
+
+// NowDate mocks base method.
+func (m *MocktimeGenerator) NowDate() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NowDate")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// NowDate indicates an expected call of NowDate.
+func (mr *MocktimeGeneratorMockRecorder) NowDate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NowDate", reflect.TypeOf((*MocktimeGenerator)(nil).NowDate))
+}
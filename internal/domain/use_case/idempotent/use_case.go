@@ -0,0 +1,140 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package idempotent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/idempotency"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+// MaxKeyLength is the max length of an idempotency key.
+const MaxKeyLength = 255
+
+type transactor interface {
+	RunInTx(ctx context.Context, fn func(ctx context.Context) (any, error), opts ...db.TxOption) (any, error)
+}
+
+type repository interface {
+	Get(ctx context.Context, key string) (*idempotency.Record, error)
+	Save(ctx context.Context, record *idempotency.Record) (bool, error)
+}
+
+type timeGenerator interface {
+	NowDate() time.Time
+}
+
+// storedResult is the JSON stored as the result of a record, the result itself is loaded by the ID.
+type storedResult struct {
+	ID uint64 `json:"id"`
+}
+
+// UseCase decorates a use case so that a call with an idempotency key is processed once:
+// the ID of the result is stored in the transaction the use case runs in, and every repeat of the call
+// with the same key and input returns the result loaded by the ID. Calls without a key are not recorded.
+type UseCase[In, Out any] struct {
+	transactor    transactor
+	repository    repository
+	timeGenerator timeGenerator
+	handle        func(ctx context.Context, in In) (Out, error)
+	resultID      func(out Out) uint64
+	load          func(ctx context.Context, id uint64) (Out, error)
+}
+
+func New[In, Out any](
+	t transactor,
+	r repository,
+	tg timeGenerator,
+	handle func(ctx context.Context, in In) (Out, error),
+	resultID func(out Out) uint64,
+	load func(ctx context.Context, id uint64) (Out, error),
+) *UseCase[In, Out] {
+	return &UseCase[In, Out]{
+		transactor:    t,
+		repository:    r,
+		timeGenerator: tg,
+		handle:        handle,
+		resultID:      resultID,
+		load:          load,
+	}
+}
+
+// Handle runs the use case with the key, replayed is set when the result is loaded by the stored record.
+func (u *UseCase[In, Out]) Handle(ctx context.Context, key string, in In) (out Out, replayed bool, err error) {
+	if key == "" {
+		out, err = u.handle(ctx, in)
+		return out, false, err
+	}
+	if len(key) > MaxKeyLength {
+		return out, false, domainerrors.NewErrValidation(
+			fmt.Sprintf("bad idempotency key: must be at most %d characters", MaxKeyLength),
+			nil,
+		)
+	}
+
+	input, err := json.Marshal(in)
+	if err != nil {
+		return out, false, err
+	}
+	requestHash := sha256.Sum256(input)
+	newRecord := &idempotency.Record{
+		Key:         key,
+		RequestHash: hex.EncodeToString(requestHash[:]),
+	}
+
+	out, err = db.RunInTx(ctx, u.transactor, func(ctx context.Context) (Out, error) {
+		var zero Out
+		// the transaction can be retried
+		replayed = false
+
+		record, err := u.repository.Get(ctx, key)
+		if err != nil {
+			return zero, err
+		}
+		if record != nil {
+			if record.RequestHash != newRecord.RequestHash {
+				return zero, domainerrors.NewErrUnprocessable("idempotency key is reused with a different request", nil)
+			}
+			var stored storedResult
+			err = json.Unmarshal(record.Result, &stored)
+			if err != nil {
+				return zero, fmt.Errorf("bad idempotency record result: %w", err)
+			}
+			replayed = true
+			return u.load(ctx, stored.ID)
+		}
+
+		out, err := u.handle(ctx, in)
+		if err != nil {
+			return zero, err
+		}
+
+		newRecord.Result, err = json.Marshal(storedResult{ID: u.resultID(out)})
+		if err != nil {
+			return zero, err
+		}
+		newRecord.CreatedAt = u.timeGenerator.NowDate()
+
+		saved, err := u.repository.Save(ctx, newRecord)
+		if err != nil {
+			return zero, err
+		}
+		if !saved {
+			return zero, domainerrors.NewErrConflict("request with the idempotency key is already processed", nil)
+		}
+
+		return out, nil
+	})
+	if err != nil {
+		var zero Out
+		return zero, false, err
+	}
+
+	return out, replayed, nil
+}
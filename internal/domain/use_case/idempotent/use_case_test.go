@@ -0,0 +1,304 @@
+package idempotent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/idempotency"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/idempotent/mocks"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+type order struct {
+	ID         uint64
+	CustomerID uint64
+}
+
+func inputHash() string {
+	hash := sha256.Sum256([]byte(`{"ID":0,"CustomerID":1}`))
+	return hex.EncodeToString(hash[:])
+}
+
+func orderID(out *order) uint64 {
+	return out.ID
+}
+
+func loadFn(t *testing.T) func(ctx context.Context, id uint64) (*order, error) {
+	return func(ctx context.Context, id uint64) (*order, error) {
+		t.Fatal("result must not be loaded")
+		return nil, nil
+	}
+}
+
+func runFn(ctx context.Context, fn func(context.Context) (any, error), _ ...db.TxOption) (any, error) {
+	return fn(ctx)
+}
+
+func TestHandle_NoKey(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	useCase := New(
+		mocks.NewMocktransactor(ctrl),
+		mocks.NewMockrepository(ctrl),
+		mocks.NewMocktimeGenerator(ctrl),
+		func(ctx context.Context, in order) (*order, error) {
+			return &order{ID: 10, CustomerID: in.CustomerID}, nil
+		},
+		orderID,
+		loadFn(t),
+	)
+
+	// act
+	actualResult, actualReplayed, actualErr := useCase.Handle(ctx, "", order{CustomerID: 1})
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.False(t, actualReplayed)
+	assert.Equal(t, &order{ID: 10, CustomerID: 1}, actualResult)
+}
+
+func TestHandle_Saved(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local)
+
+	transactorMock := mocks.NewMocktransactor(ctrl)
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	created := &order{ID: 10, CustomerID: 1}
+	useCase := New(transactorMock, repositoryMock, timeGeneratorMock, func(ctx context.Context, in order) (*order, error) {
+		return created, nil
+	}, orderID, loadFn(t))
+
+	transactorMock.EXPECT().
+		RunInTx(ctx, gomock.Any()).
+		DoAndReturn(runFn)
+	repositoryMock.EXPECT().
+		Get(ctx, "key-1").
+		Return(nil, nil)
+	timeGeneratorMock.EXPECT().
+		NowDate().
+		Return(now)
+	repositoryMock.EXPECT().
+		Save(ctx, &idempotency.Record{
+			Key:         "key-1",
+			RequestHash: inputHash(),
+			Result:      []byte(`{"id":10}`),
+			CreatedAt:   now,
+		}).
+		Return(true, nil)
+
+	// act
+	actualResult, actualReplayed, actualErr := useCase.Handle(ctx, "key-1", order{CustomerID: 1})
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.False(t, actualReplayed)
+	assert.Same(t, created, actualResult)
+}
+
+func TestHandle_Replayed(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	transactorMock := mocks.NewMocktransactor(ctrl)
+	repositoryMock := mocks.NewMockrepository(ctrl)
+
+	loaded := &order{ID: 10, CustomerID: 1}
+	useCase := New(transactorMock, repositoryMock, mocks.NewMocktimeGenerator(ctrl), func(ctx context.Context, in order) (*order, error) {
+		t.Fatal("use case must not be called")
+		return nil, nil
+	}, orderID, func(ctx context.Context, id uint64) (*order, error) {
+		assert.Equal(t, uint64(10), id)
+		return loaded, nil
+	})
+
+	transactorMock.EXPECT().
+		RunInTx(ctx, gomock.Any()).
+		DoAndReturn(runFn)
+	repositoryMock.EXPECT().
+		Get(ctx, "key-1").
+		Return(&idempotency.Record{
+			Key:         "key-1",
+			RequestHash: inputHash(),
+			Result:      []byte(`{"id":10}`),
+		}, nil)
+
+	// act
+	actualResult, actualReplayed, actualErr := useCase.Handle(ctx, "key-1", order{CustomerID: 1})
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.True(t, actualReplayed)
+	assert.Same(t, loaded, actualResult)
+}
+
+func TestHandle_ReusedWithDifferentInput(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	transactorMock := mocks.NewMocktransactor(ctrl)
+	repositoryMock := mocks.NewMockrepository(ctrl)
+
+	useCase := New(transactorMock, repositoryMock, mocks.NewMocktimeGenerator(ctrl), func(ctx context.Context, in order) (*order, error) {
+		t.Fatal("use case must not be called")
+		return nil, nil
+	}, orderID, loadFn(t))
+
+	transactorMock.EXPECT().
+		RunInTx(ctx, gomock.Any()).
+		DoAndReturn(runFn)
+	repositoryMock.EXPECT().
+		Get(ctx, "key-1").
+		Return(&idempotency.Record{
+			Key:         "key-1",
+			RequestHash: "other",
+			Result:      []byte(`{"id":10}`),
+		}, nil)
+
+	// act
+	actualResult, actualReplayed, actualErr := useCase.Handle(ctx, "key-1", order{CustomerID: 1})
+
+	// assert
+	var errUnprocessable *domainerrors.ErrUnprocessable
+	assert.ErrorAs(t, actualErr, &errUnprocessable)
+	assert.False(t, actualReplayed)
+	assert.Nil(t, actualResult)
+}
+
+func TestHandle_ProcessedConcurrently(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	transactorMock := mocks.NewMocktransactor(ctrl)
+	repositoryMock := mocks.NewMockrepository(ctrl)
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+
+	useCase := New(transactorMock, repositoryMock, timeGeneratorMock, func(ctx context.Context, in order) (*order, error) {
+		return &order{ID: 10, CustomerID: 1}, nil
+	}, orderID, loadFn(t))
+
+	transactorMock.EXPECT().
+		RunInTx(ctx, gomock.Any()).
+		DoAndReturn(runFn)
+	repositoryMock.EXPECT().
+		Get(ctx, "key-1").
+		Return(nil, nil)
+	timeGeneratorMock.EXPECT().
+		NowDate().
+		Return(time.Now())
+	repositoryMock.EXPECT().
+		Save(ctx, gomock.Any()).
+		Return(false, nil)
+
+	// act
+	actualResult, _, actualErr := useCase.Handle(ctx, "key-1", order{CustomerID: 1})
+
+	// assert
+	var errConflict *domainerrors.ErrConflict
+	assert.ErrorAs(t, actualErr, &errConflict)
+	assert.Nil(t, actualResult)
+}
+
+func TestHandle_UseCaseError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	transactorMock := mocks.NewMocktransactor(ctrl)
+	repositoryMock := mocks.NewMockrepository(ctrl)
+
+	expectedErr := errors.New("some error")
+	useCase := New(transactorMock, repositoryMock, mocks.NewMocktimeGenerator(ctrl), func(ctx context.Context, in order) (*order, error) {
+		return nil, expectedErr
+	}, orderID, loadFn(t))
+
+	transactorMock.EXPECT().
+		RunInTx(ctx, gomock.Any()).
+		DoAndReturn(runFn)
+	repositoryMock.EXPECT().
+		Get(ctx, "key-1").
+		Return(nil, nil)
+
+	// act
+	actualResult, _, actualErr := useCase.Handle(ctx, "key-1", order{CustomerID: 1})
+
+	// assert
+	assert.ErrorIs(t, actualErr, expectedErr)
+	assert.Nil(t, actualResult)
+}
+
+func TestHandle_KeyTooLong(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	useCase := New(
+		mocks.NewMocktransactor(ctrl),
+		mocks.NewMockrepository(ctrl),
+		mocks.NewMocktimeGenerator(ctrl),
+		func(ctx context.Context, in order) (*order, error) {
+			t.Fatal("use case must not be called")
+			return nil, nil
+		},
+		orderID,
+		loadFn(t),
+	)
+
+	// act
+	actualResult, _, actualErr := useCase.Handle(ctx, strings.Repeat("k", MaxKeyLength+1), order{CustomerID: 1})
+
+	// assert
+	var errValidation *domainerrors.ErrValidation
+	assert.ErrorAs(t, actualErr, &errValidation)
+	assert.Nil(t, actualResult)
+}
+
+func TestHandle_BadStoredResult(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	transactorMock := mocks.NewMocktransactor(ctrl)
+	repositoryMock := mocks.NewMockrepository(ctrl)
+
+	useCase := New(transactorMock, repositoryMock, mocks.NewMocktimeGenerator(ctrl), func(ctx context.Context, in order) (*order, error) {
+		t.Fatal("use case must not be called")
+		return nil, nil
+	}, orderID, loadFn(t))
+
+	transactorMock.EXPECT().
+		RunInTx(ctx, gomock.Any()).
+		DoAndReturn(runFn)
+	repositoryMock.EXPECT().
+		Get(ctx, "key-1").
+		Return(&idempotency.Record{
+			Key:         "key-1",
+			RequestHash: inputHash(),
+			Result:      []byte("SaleOrder ID = 10"),
+		}, nil)
+
+	// act
+	actualResult, actualReplayed, actualErr := useCase.Handle(ctx, "key-1", order{CustomerID: 1})
+
+	// assert
+	assert.ErrorContains(t, actualErr, "bad idempotency record result")
+	assert.False(t, actualReplayed)
+	assert.Nil(t, actualResult)
+}
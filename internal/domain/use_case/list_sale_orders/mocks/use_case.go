@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: use_case.go
+//
+// Generated by this command:
+//
+//	mockgen -package=list_sale_orders -source=use_case.go -destination=mocks/use_case.go
+//
+
+// Package list_sale_orders is a generated GoMock package.
+package list_sale_orders
+
+import (
+	context "context"
+	reflect "reflect"
+
+	access "github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	document "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocksaleOrderService is a mock of saleOrderService interface.
+type MocksaleOrderService struct {
+	ctrl     *gomock.Controller
+	recorder *MocksaleOrderServiceMockRecorder
+}
+
+// MocksaleOrderServiceMockRecorder is the mock recorder for MocksaleOrderService.
+type MocksaleOrderServiceMockRecorder struct {
+	mock *MocksaleOrderService
+}
+
+// NewMocksaleOrderService creates a new mock instance.
+func NewMocksaleOrderService(ctrl *gomock.Controller) *MocksaleOrderService {
+	mock := &MocksaleOrderService{ctrl: ctrl}
+	mock.recorder = &MocksaleOrderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksaleOrderService) EXPECT() *MocksaleOrderServiceMockRecorder {
+	return m.recorder
+}
+
+// ListOrders mocks base method.
+func (m *MocksaleOrderService) ListOrders(ctx context.Context, query document.SaleOrderListQuery) (*document.SaleOrderList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", ctx, query)
+	ret0, _ := ret[0].(*document.SaleOrderList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MocksaleOrderServiceMockRecorder) ListOrders(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MocksaleOrderService)(nil).ListOrders), ctx, query)
+}
+
+// Mockpolicy is a mock of policy interface.
+type Mockpolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockpolicyMockRecorder
+}
+
+// MockpolicyMockRecorder is the mock recorder for Mockpolicy.
+type MockpolicyMockRecorder struct {
+	mock *Mockpolicy
+}
+
+// NewMockpolicy creates a new mock instance.
+func NewMockpolicy(ctrl *gomock.Controller) *Mockpolicy {
+	mock := &Mockpolicy{ctrl: ctrl}
+	mock.recorder = &MockpolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpolicy) EXPECT() *MockpolicyMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *Mockpolicy) Authorize(ctx context.Context, permission access.Permission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockpolicyMockRecorder) Authorize(ctx, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*Mockpolicy)(nil).Authorize), ctx, permission)
+}
@@ -0,0 +1,40 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package list_sale_orders
+
+import (
+	"context"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+)
+
+type saleOrderService interface {
+	ListOrders(ctx context.Context, query document.SaleOrderListQuery) (*document.SaleOrderList, error)
+}
+
+type policy interface {
+	Authorize(ctx context.Context, permission access.Permission) error
+}
+
+type UseCase struct {
+	saleOrderService saleOrderService
+	policy           policy
+}
+
+func NewUseCase(sos saleOrderService, p policy) *UseCase {
+	return &UseCase{
+		saleOrderService: sos,
+		policy:           p,
+	}
+}
+
+func (u *UseCase) Handle(
+	ctx context.Context,
+	query document.SaleOrderListQuery,
+) (saleOrders *document.SaleOrderList, err error) {
+	err = u.policy.Authorize(ctx, access.PermissionReadOrders)
+	if err != nil {
+		return nil, err
+	}
+	return u.saleOrderService.ListOrders(ctx, query)
+}
@@ -0,0 +1,107 @@
+package list_sale_orders
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/list_sale_orders/mocks"
+)
+
+func TestHandle_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(saleOrderServiceMock, policyMock)
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionReadOrders).
+		Return(nil)
+
+	query := document.SaleOrderListQuery{
+		Filter: document.SaleOrderFilter{
+			CustomerID: 1,
+		},
+		Limit: 10,
+	}
+
+	saleOrders := &document.SaleOrderList{
+		Items: []*document.SaleOrder{
+			{Document: document.Document{ID: 1}},
+		},
+	}
+
+	saleOrderServiceMock.EXPECT().
+		ListOrders(ctx, query).
+		Return(saleOrders, nil)
+
+	// act
+	actual, actualErr := useCase.Handle(ctx, query)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, saleOrders, actual)
+}
+
+func TestHandle_Error(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(saleOrderServiceMock, policyMock)
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionReadOrders).
+		Return(nil)
+
+	query := document.SaleOrderListQuery{}
+
+	listErr := errors.New("list error")
+
+	saleOrderServiceMock.EXPECT().
+		ListOrders(ctx, query).
+		Return(nil, listErr)
+
+	// act
+	actual, actualErr := useCase.Handle(ctx, query)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, actualErr, listErr.Error())
+}
+
+func TestHandle_AccessDenied(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(saleOrderServiceMock, policyMock)
+
+	accessErr := errors.New("access denied")
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionReadOrders).
+		Return(accessErr)
+
+	// act
+	actual, actualErr := useCase.Handle(ctx, document.SaleOrderListQuery{})
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorIs(t, actualErr, accessErr)
+}
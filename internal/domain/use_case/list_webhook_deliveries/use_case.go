@@ -0,0 +1,37 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package list_webhook_deliveries
+
+import (
+	"context"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+)
+
+type webhookService interface {
+	ListDeliveries(ctx context.Context, status webhook.DeliveryStatus) ([]*webhook.Delivery, error)
+}
+
+type policy interface {
+	Authorize(ctx context.Context, permission access.Permission) error
+}
+
+type UseCase struct {
+	webhookService webhookService
+	policy         policy
+}
+
+func NewUseCase(ws webhookService, p policy) *UseCase {
+	return &UseCase{
+		webhookService: ws,
+		policy:         p,
+	}
+}
+
+func (u *UseCase) Handle(ctx context.Context, status webhook.DeliveryStatus) ([]*webhook.Delivery, error) {
+	err := u.policy.Authorize(ctx, access.PermissionManageWebhooks)
+	if err != nil {
+		return nil, err
+	}
+	return u.webhookService.ListDeliveries(ctx, status)
+}
@@ -0,0 +1,99 @@
+package list_webhook_deliveries
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/list_webhook_deliveries/mocks"
+)
+
+func TestHandle_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	webhookServiceMock := mocks.NewMockwebhookService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(webhookServiceMock, policyMock)
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionManageWebhooks).
+		Return(nil)
+
+	expected := []*webhook.Delivery{
+		{
+			ID:     1,
+			Status: webhook.DeliveryStatusFailed,
+		},
+	}
+
+	webhookServiceMock.EXPECT().
+		ListDeliveries(ctx, webhook.DeliveryStatusFailed).
+		Return(expected, nil)
+
+	// act
+	actual, actualErr := useCase.Handle(ctx, webhook.DeliveryStatusFailed)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, expected, actual)
+}
+
+func TestHandle_Error(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	webhookServiceMock := mocks.NewMockwebhookService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(webhookServiceMock, policyMock)
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionManageWebhooks).
+		Return(nil)
+
+	serviceErr := errors.New("service error")
+
+	webhookServiceMock.EXPECT().
+		ListDeliveries(ctx, webhook.DeliveryStatusFailed).
+		Return(nil, serviceErr)
+
+	// act
+	actual, actualErr := useCase.Handle(ctx, webhook.DeliveryStatusFailed)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, actualErr, serviceErr.Error())
+}
+
+func TestHandle_AccessDenied(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	webhookServiceMock := mocks.NewMockwebhookService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(webhookServiceMock, policyMock)
+
+	accessErr := errors.New("access denied")
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionManageWebhooks).
+		Return(accessErr)
+
+	// act
+	actual, actualErr := useCase.Handle(ctx, webhook.DeliveryStatusFailed)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorIs(t, actualErr, accessErr)
+}
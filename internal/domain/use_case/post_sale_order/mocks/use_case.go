@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: use_case.go
+//
+// Generated by this command:
+//
+//	mockgen -package=post_sale_order -source=use_case.go -destination=mocks/use_case.go
+//
+
+// Package post_sale_order is a generated GoMock package.
+package post_sale_order
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	access "github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	document "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	reference "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocktimeGenerator is a mock of timeGenerator interface.
+type MocktimeGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MocktimeGeneratorMockRecorder
+}
+
+// MocktimeGeneratorMockRecorder is the mock recorder for MocktimeGenerator.
+type MocktimeGeneratorMockRecorder struct {
+	mock *MocktimeGenerator
+}
+
+// NewMocktimeGenerator creates a new mock instance.
+func NewMocktimeGenerator(ctrl *gomock.Controller) *MocktimeGenerator {
+	mock := &MocktimeGenerator{ctrl: ctrl}
+	mock.recorder = &MocktimeGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktimeGenerator) EXPECT() *MocktimeGeneratorMockRecorder {
+	return m.recorder
+}
+
+// NowDate mocks base method.
+func (m *MocktimeGenerator) NowDate() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NowDate")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// NowDate indicates an expected call of NowDate.
+func (mr *MocktimeGeneratorMockRecorder) NowDate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NowDate", reflect.TypeOf((*MocktimeGenerator)(nil).NowDate))
+}
+
+// MocksaleOrderService is a mock of saleOrderService interface.
+type MocksaleOrderService struct {
+	ctrl     *gomock.Controller
+	recorder *MocksaleOrderServiceMockRecorder
+}
+
+// MocksaleOrderServiceMockRecorder is the mock recorder for MocksaleOrderService.
+type MocksaleOrderServiceMockRecorder struct {
+	mock *MocksaleOrderService
+}
+
+// NewMocksaleOrderService creates a new mock instance.
+func NewMocksaleOrderService(ctrl *gomock.Controller) *MocksaleOrderService {
+	mock := &MocksaleOrderService{ctrl: ctrl}
+	mock.recorder = &MocksaleOrderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksaleOrderService) EXPECT() *MocksaleOrderServiceMockRecorder {
+	return m.recorder
+}
+
+// ChangeStatus mocks base method.
+func (m *MocksaleOrderService) ChangeStatus(ctx context.Context, id, version uint64, status document.Status, changeUser *reference.User, updatedAt time.Time) (*document.SaleOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeStatus", ctx, id, version, status, changeUser, updatedAt)
+	ret0, _ := ret[0].(*document.SaleOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeStatus indicates an expected call of ChangeStatus.
+func (mr *MocksaleOrderServiceMockRecorder) ChangeStatus(ctx, id, version, status, changeUser, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeStatus", reflect.TypeOf((*MocksaleOrderService)(nil).ChangeStatus), ctx, id, version, status, changeUser, updatedAt)
+}
+
+// Mockpolicy is a mock of policy interface.
+type Mockpolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockpolicyMockRecorder
+}
+
+// MockpolicyMockRecorder is the mock recorder for Mockpolicy.
+type MockpolicyMockRecorder struct {
+	mock *Mockpolicy
+}
+
+// NewMockpolicy creates a new mock instance.
+func NewMockpolicy(ctrl *gomock.Controller) *Mockpolicy {
+	mock := &Mockpolicy{ctrl: ctrl}
+	mock.recorder = &MockpolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpolicy) EXPECT() *MockpolicyMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *Mockpolicy) Authorize(ctx context.Context, permission access.Permission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockpolicyMockRecorder) Authorize(ctx, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*Mockpolicy)(nil).Authorize), ctx, permission)
+}
@@ -0,0 +1,117 @@
+package post_sale_order
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/post_sale_order/mocks"
+)
+
+func TestHandle_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	user := &reference.User{Reference: reference.Reference{ID: 5}}
+	ctx := access.WithUser(context.Background(), user)
+	now := time.Now()
+
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(timeGeneratorMock, saleOrderServiceMock, policyMock)
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionPostOrders).
+		Return(nil)
+
+	timeGeneratorMock.EXPECT().
+		NowDate().
+		Return(now)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:     1,
+			Status: document.StatusPosted,
+		},
+	}
+
+	saleOrderServiceMock.EXPECT().
+		ChangeStatus(ctx, saleOrder.ID, uint64(1), document.StatusPosted, user, now).
+		Return(saleOrder, nil)
+
+	// act
+	actualSaleOrder, actualErr := useCase.Handle(ctx, saleOrder.ID, 1)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, saleOrder, actualSaleOrder)
+}
+
+func TestHandle_Error(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	user := &reference.User{Reference: reference.Reference{ID: 5}}
+	ctx := access.WithUser(context.Background(), user)
+	now := time.Now()
+
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(timeGeneratorMock, saleOrderServiceMock, policyMock)
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionPostOrders).
+		Return(nil)
+
+	timeGeneratorMock.EXPECT().
+		NowDate().
+		Return(now)
+
+	var saleOrderID uint64 = 1
+
+	changeErr := errors.New("change status error")
+
+	saleOrderServiceMock.EXPECT().
+		ChangeStatus(ctx, saleOrderID, uint64(1), document.StatusPosted, user, now).
+		Return(nil, changeErr)
+
+	// act
+	actualSaleOrder, actualErr := useCase.Handle(ctx, saleOrderID, 1)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, actualErr, changeErr.Error())
+}
+
+func TestHandle_AccessDenied(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(mocks.NewMocktimeGenerator(ctrl), saleOrderServiceMock, policyMock)
+
+	accessErr := errors.New("access denied")
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionPostOrders).
+		Return(accessErr)
+
+	// act
+	actual, actualErr := useCase.Handle(ctx, 1, 1)
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorIs(t, actualErr, accessErr)
+}
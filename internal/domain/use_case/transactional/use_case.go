@@ -0,0 +1,82 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package transactional
+
+import (
+	"context"
+
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+type transactor interface {
+	RunInTx(ctx context.Context, fn func(ctx context.Context) (any, error), opts ...db.TxOption) (any, error)
+}
+
+// UseCase decorates a use case with one argument: every call is run in a transaction.
+// A call made in an outer transaction joins it, so decorated use cases can be composed.
+type UseCase[In, Out any] struct {
+	transactor transactor
+	handle     func(ctx context.Context, in In) (Out, error)
+	opts       []db.TxOption
+}
+
+// New decorates handle, opts set up the transaction.
+func New[In, Out any](
+	t transactor,
+	handle func(ctx context.Context, in In) (Out, error),
+	opts ...db.TxOption,
+) *UseCase[In, Out] {
+	return &UseCase[In, Out]{
+		transactor: t,
+		handle:     handle,
+		opts:       opts,
+	}
+}
+
+// NewReadOnly decorates handle of a query use case, it is run in a read-only transaction,
+// so writes made by it fail.
+func NewReadOnly[In, Out any](
+	t transactor,
+	handle func(ctx context.Context, in In) (Out, error),
+) *UseCase[In, Out] {
+	return New(t, handle, db.ReadOnly())
+}
+
+func (u *UseCase[In, Out]) Handle(ctx context.Context, in In) (Out, error) {
+	return db.RunInTx(ctx, u.transactor, func(ctx context.Context) (Out, error) {
+		return u.handle(ctx, in)
+	}, u.opts...)
+}
+
+// UseCase2 is UseCase for use cases with two arguments.
+type UseCase2[In1, In2, Out any] struct {
+	transactor transactor
+	handle     func(ctx context.Context, in1 In1, in2 In2) (Out, error)
+	opts       []db.TxOption
+}
+
+// New2 is New for use cases with two arguments.
+func New2[In1, In2, Out any](
+	t transactor,
+	handle func(ctx context.Context, in1 In1, in2 In2) (Out, error),
+	opts ...db.TxOption,
+) *UseCase2[In1, In2, Out] {
+	return &UseCase2[In1, In2, Out]{
+		transactor: t,
+		handle:     handle,
+		opts:       opts,
+	}
+}
+
+// NewReadOnly2 is NewReadOnly for use cases with two arguments.
+func NewReadOnly2[In1, In2, Out any](
+	t transactor,
+	handle func(ctx context.Context, in1 In1, in2 In2) (Out, error),
+) *UseCase2[In1, In2, Out] {
+	return New2(t, handle, db.ReadOnly())
+}
+
+func (u *UseCase2[In1, In2, Out]) Handle(ctx context.Context, in1 In1, in2 In2) (Out, error) {
+	return db.RunInTx(ctx, u.transactor, func(ctx context.Context) (Out, error) {
+		return u.handle(ctx, in1, in2)
+	}, u.opts...)
+}
@@ -0,0 +1,108 @@
+//go:build integration
+
+package transactional
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+const testDBFilePath = "sqlite_test.db"
+
+type TestUseCaseSuite struct {
+	suite.Suite
+	db         *sql.DB
+	transactor *db.Transactor
+	repository *db.TransactionalRepository
+}
+
+func TestUseCaseByTestSuite(t *testing.T) {
+	suite.Run(t, new(TestUseCaseSuite))
+}
+
+func (us *TestUseCaseSuite) SetupSuite() {
+	_ = os.Remove(testDBFilePath)
+
+	dbConn, err := sql.Open("sqlite3", testDBFilePath)
+	if err != nil {
+		us.Failf("cannot open db connection before tests: %s", err.Error())
+	}
+
+	_, err = dbConn.Exec("CREATE TABLE item (name TEXT NOT NULL)")
+	if err != nil {
+		us.Failf("cannot create table before tests: %s", err.Error())
+	}
+
+	us.db = dbConn
+	us.transactor = db.NewTransactor(dbConn, db.DefaultTransactorConfig)
+	us.repository = db.NewTransactionalRepository(dbConn)
+}
+
+func (us *TestUseCaseSuite) TearDownSuite() {
+	err := us.db.Close()
+	if err != nil {
+		us.Failf("tear down suite: %s", err.Error())
+	}
+	_ = os.Remove(testDBFilePath)
+}
+
+func (us *TestUseCaseSuite) SetupTest() {
+	_, err := us.db.Exec("DELETE FROM item")
+	us.NoError(err)
+}
+
+func (us *TestUseCaseSuite) insert(ctx context.Context, name string) (string, error) {
+	_, err := us.repository.DB(ctx).ExecContext(ctx, "INSERT INTO item (name) VALUES (?)", name)
+	return name, err
+}
+
+func (us *TestUseCaseSuite) count() int {
+	var count int
+	us.NoError(us.db.QueryRow("SELECT COUNT(*) FROM item").Scan(&count))
+	return count
+}
+
+func (us *TestUseCaseSuite) TestNew_Writes() {
+	// arrange
+	useCase := New(us.transactor, us.insert)
+
+	// act
+	_, err := useCase.Handle(context.Background(), "written")
+
+	// assert
+	us.NoError(err)
+	us.Equal(1, us.count())
+}
+
+func (us *TestUseCaseSuite) TestNewReadOnly_WriteFails() {
+	// arrange
+	useCase := NewReadOnly(us.transactor, us.insert)
+
+	// act
+	_, err := useCase.Handle(context.Background(), "written")
+
+	// assert
+	us.ErrorContains(err, "attempt to write a readonly database")
+	us.Equal(0, us.count())
+}
+
+func (us *TestUseCaseSuite) TestNewReadOnly2_WriteFails() {
+	// arrange
+	useCase := NewReadOnly2(us.transactor, func(ctx context.Context, first string, second string) (string, error) {
+		return us.insert(ctx, first+second)
+	})
+
+	// act
+	_, err := useCase.Handle(context.Background(), "written", "twice")
+
+	// assert
+	us.ErrorContains(err, "attempt to write a readonly database")
+	us.Equal(0, us.count())
+}
@@ -0,0 +1,139 @@
+package transactional
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/transactional/mocks"
+	"github.com/kiaplayer/clean-architecture-example/pkg/storage/db"
+)
+
+type txKey struct{}
+
+func TestHandle_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	transactorMock := mocks.NewMocktransactor(ctrl)
+	transactorMock.EXPECT().
+		RunInTx(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) (any, error), _ ...db.TxOption) (any, error) {
+			return fn(context.WithValue(ctx, txKey{}, true))
+		})
+
+	useCase := New(transactorMock, func(ctx context.Context, id uint64) (string, error) {
+		assert.Equal(t, true, ctx.Value(txKey{}))
+		assert.Equal(t, uint64(1), id)
+		return "done", nil
+	})
+
+	// act
+	actualResult, actualErr := useCase.Handle(ctx, 1)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, "done", actualResult)
+}
+
+func TestHandle_Error(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	expectedErr := errors.New("some error")
+
+	transactorMock := mocks.NewMocktransactor(ctrl)
+	transactorMock.EXPECT().
+		RunInTx(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) (any, error), _ ...db.TxOption) (any, error) {
+			return fn(ctx)
+		})
+
+	useCase := New(transactorMock, func(ctx context.Context, id uint64) (*string, error) {
+		return nil, expectedErr
+	})
+
+	// act
+	actualResult, actualErr := useCase.Handle(ctx, 1)
+
+	// assert
+	assert.ErrorIs(t, actualErr, expectedErr)
+	assert.Nil(t, actualResult)
+}
+
+func TestHandle_ReadOnly(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	transactorMock := mocks.NewMocktransactor(ctrl)
+	transactorMock.EXPECT().
+		RunInTx(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) (any, error), _ ...db.TxOption) (any, error) {
+			return fn(ctx)
+		})
+
+	useCase := NewReadOnly(transactorMock, func(ctx context.Context, id uint64) (uint64, error) {
+		return id, nil
+	})
+
+	// act
+	actualResult, actualErr := useCase.Handle(ctx, 1)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, uint64(1), actualResult)
+}
+
+func TestHandle2_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	transactorMock := mocks.NewMocktransactor(ctrl)
+	transactorMock.EXPECT().
+		RunInTx(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) (any, error), _ ...db.TxOption) (any, error) {
+			return fn(context.WithValue(ctx, txKey{}, true))
+		})
+
+	useCase := New2(transactorMock, func(ctx context.Context, id uint64, version uint64) (uint64, error) {
+		assert.Equal(t, true, ctx.Value(txKey{}))
+		return id + version, nil
+	})
+
+	// act
+	actualResult, actualErr := useCase.Handle(ctx, 1, 2)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, uint64(3), actualResult)
+}
+
+func TestHandle2_ReadOnly(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	expectedErr := errors.New("some error")
+
+	transactorMock := mocks.NewMocktransactor(ctrl)
+	transactorMock.EXPECT().
+		RunInTx(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) (any, error), _ ...db.TxOption) (any, error) {
+			return fn(ctx)
+		})
+
+	useCase := NewReadOnly2(transactorMock, func(ctx context.Context, id uint64, version uint64) (uint64, error) {
+		return 0, expectedErr
+	})
+
+	// act
+	_, actualErr := useCase.Handle(ctx, 1, 2)
+
+	// assert
+	assert.ErrorIs(t, actualErr, expectedErr)
+}
@@ -0,0 +1,58 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package update_sale_order
+
+import (
+	"context"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+)
+
+type timeGenerator interface {
+	NowDate() time.Time
+}
+
+type saleOrderService interface {
+	PriceOrder(ctx context.Context, order *document.SaleOrder) (*document.SaleOrder, error)
+	UpdateOrder(ctx context.Context, order *document.SaleOrder) (*document.SaleOrder, error)
+}
+
+type policy interface {
+	Authorize(ctx context.Context, permission access.Permission) error
+}
+
+type UseCase struct {
+	timeGenerator    timeGenerator
+	saleOrderService saleOrderService
+	policy           policy
+}
+
+func NewUseCase(tg timeGenerator, sos saleOrderService, p policy) *UseCase {
+	return &UseCase{
+		timeGenerator:    tg,
+		saleOrderService: sos,
+		policy:           p,
+	}
+}
+
+func (u *UseCase) Handle(
+	ctx context.Context,
+	saleOrder *document.SaleOrder,
+) (saleOrderUpdated *document.SaleOrder, err error) {
+	err = u.policy.Authorize(ctx, access.PermissionUpdateOrders)
+	if err != nil {
+		return nil, err
+	}
+	saleOrder.ChangeUser = access.UserFromContext(ctx)
+	saleOrder.UpdatedAt = u.timeGenerator.NowDate()
+	saleOrder, err = u.saleOrderService.PriceOrder(ctx, saleOrder)
+	if err != nil {
+		return nil, err
+	}
+	saleOrderUpdated, err = u.saleOrderService.UpdateOrder(ctx, saleOrder)
+	if err != nil {
+		return nil, err
+	}
+	return saleOrderUpdated, nil
+}
@@ -0,0 +1,173 @@
+package update_sale_order
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/update_sale_order/mocks"
+)
+
+func TestHandle_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	user := &reference.User{Reference: reference.Reference{ID: 5}}
+	ctx := access.WithUser(context.Background(), user)
+	now := time.Now()
+
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(timeGeneratorMock, saleOrderServiceMock, policyMock)
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionUpdateOrders).
+		Return(nil)
+
+	timeGeneratorMock.EXPECT().
+		NowDate().
+		Return(now)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID: 1,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID: 1,
+			},
+		},
+	}
+
+	saleOrderServiceMock.EXPECT().
+		PriceOrder(ctx, saleOrder).
+		Return(saleOrder, nil)
+
+	saleOrderServiceMock.EXPECT().
+		UpdateOrder(ctx, saleOrder).
+		Return(saleOrder, nil)
+
+	// act
+	actualSaleOrder, actualErr := useCase.Handle(ctx, saleOrder)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, saleOrder, actualSaleOrder)
+	assert.Equal(t, user, actualSaleOrder.ChangeUser)
+	assert.Equal(t, now, actualSaleOrder.UpdatedAt)
+}
+
+func TestHandle_Error(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(timeGeneratorMock, saleOrderServiceMock, policyMock)
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionUpdateOrders).
+		Return(nil)
+
+	timeGeneratorMock.EXPECT().
+		NowDate().
+		Return(time.Now())
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID: 1,
+		},
+	}
+
+	updateErr := errors.New("update error")
+
+	saleOrderServiceMock.EXPECT().
+		PriceOrder(ctx, saleOrder).
+		Return(saleOrder, nil)
+
+	saleOrderServiceMock.EXPECT().
+		UpdateOrder(ctx, saleOrder).
+		Return(nil, updateErr)
+
+	// act
+	actualSaleOrder, actualErr := useCase.Handle(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, actualErr, updateErr.Error())
+}
+
+func TestHandle_PriceError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(timeGeneratorMock, saleOrderServiceMock, policyMock)
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionUpdateOrders).
+		Return(nil)
+
+	timeGeneratorMock.EXPECT().
+		NowDate().
+		Return(time.Now())
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID: 1,
+		},
+	}
+
+	priceErr := errors.New("price error")
+
+	saleOrderServiceMock.EXPECT().
+		PriceOrder(ctx, saleOrder).
+		Return(nil, priceErr)
+
+	// act
+	actualSaleOrder, actualErr := useCase.Handle(ctx, saleOrder)
+
+	// assert
+	assert.Nil(t, actualSaleOrder)
+	assert.ErrorContains(t, actualErr, priceErr.Error())
+}
+
+func TestHandle_AccessDenied(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	timeGeneratorMock := mocks.NewMocktimeGenerator(ctrl)
+	saleOrderServiceMock := mocks.NewMocksaleOrderService(ctrl)
+	policyMock := mocks.NewMockpolicy(ctrl)
+
+	useCase := NewUseCase(timeGeneratorMock, saleOrderServiceMock, policyMock)
+
+	accessErr := errors.New("access denied")
+
+	policyMock.EXPECT().
+		Authorize(ctx, access.PermissionUpdateOrders).
+		Return(accessErr)
+
+	// act
+	actual, actualErr := useCase.Handle(ctx, &document.SaleOrder{})
+
+	// assert
+	assert.Nil(t, actual)
+	assert.ErrorIs(t, actualErr, accessErr)
+}
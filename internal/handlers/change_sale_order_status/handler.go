@@ -0,0 +1,65 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package change_sale_order_status
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/etag"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/get_sale_order/dto"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/response"
+)
+
+type useCase interface {
+	Handle(ctx context.Context, id uint64, version uint64) (*document.SaleOrder, error)
+}
+
+type Handler struct {
+	useCase useCase
+}
+
+func NewHandler(u useCase) *Handler {
+	return &Handler{
+		useCase: u,
+	}
+}
+
+func (h *Handler) Handle(writer http.ResponseWriter, request *http.Request) {
+	saleOrderID, err := h.validateAndPrepare(request)
+	if err != nil {
+		response.Error(writer, err)
+		return
+	}
+	version, err := etag.IfMatch(request)
+	if err != nil {
+		response.Error(writer, err)
+		return
+	}
+
+	saleOrder, err := h.useCase.Handle(request.Context(), saleOrderID, version)
+	if err != nil {
+		response.Error(writer, err)
+		return
+	}
+
+	etag.Set(writer, saleOrder.Version)
+	writer.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(writer).Encode(dto.SaleOrderToSaleOrderDto(saleOrder))
+}
+
+func (h *Handler) validateAndPrepare(request *http.Request) (uint64, error) {
+	id, err := strconv.ParseInt(request.URL.Query().Get("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, domainerrors.NewErrValidation(
+			"bad id",
+			err,
+			domainerrors.FieldError{Field: "id", Message: "must be a positive integer"},
+		)
+	}
+
+	return uint64(id), nil
+}
@@ -0,0 +1,176 @@
+package change_sale_order_status
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/handlers/change_sale_order_status/mocks"
+)
+
+func TestHandle_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	saleOrder := &document.SaleOrder{
+		Document: document.Document{
+			ID:      123,
+			Status:  document.StatusPosted,
+			Version: 2,
+		},
+	}
+
+	useCaseMock.EXPECT().
+		Handle(ctx, saleOrder.ID, uint64(1)).
+		Return(saleOrder, nil)
+
+	bodyReader := bytes.NewReader([]byte(``))
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPost, fmt.Sprintf("?id=%d", saleOrder.ID), bodyReader)
+	request.Header.Set("If-Match", `"1"`)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+	assert.Equal(t, `"2"`, response.Header().Get("ETag"))
+	assert.Contains(t, response.Body.String(), `"status":"posted"`)
+}
+
+func TestHandle_NoIfMatch(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPost, "?id=123", nil)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusPreconditionRequired, response.Code)
+	assert.Contains(t, response.Body.String(), `"detail":"no version, If-Match header is required"`)
+}
+
+func TestHandle_validateAndPrepareError_BadID(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	bodyReader := bytes.NewReader([]byte(``))
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPost, "?id=bad", bodyReader)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Contains(t, response.Body.String(), "bad id")
+}
+
+func TestHandle_validateAndPrepareError_NegativeID(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	bodyReader := bytes.NewReader([]byte(``))
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPost, "?id=-1", bodyReader)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Contains(t, response.Body.String(), "bad id")
+}
+
+func TestHandle_UseCaseError(t *testing.T) {
+	tests := []struct {
+		name         string
+		useCaseErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "not found",
+			useCaseErr:   domainerrors.NewErrNotFound("sale order not found: 123", nil),
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"type": "about:blank", "title": "Not Found", "status": 404, "detail": "sale order not found: 123"}`,
+		},
+		{
+			name:         "validation",
+			useCaseErr:   domainerrors.NewErrValidation("status change is not allowed: deleted -> posted", nil),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type": "about:blank", "title": "Bad Request", "status": 400, "detail": "status change is not allowed: deleted -> posted"}`,
+		},
+		{
+			name:         "version conflict",
+			useCaseErr:   domainerrors.NewErrVersionConflict("sale order 123 has version 2, not 1", nil),
+			expectedCode: http.StatusPreconditionFailed,
+			expectedBody: `{"type": "about:blank", "title": "Precondition Failed", "status": 412, "detail": "sale order 123 has version 2, not 1"}`,
+		},
+		{
+			name:         "internal",
+			useCaseErr:   errors.New("some db error"),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type": "about:blank", "title": "Internal Server Error", "status": 500}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+
+			useCaseMock := mocks.NewMockuseCase(ctrl)
+			handler := NewHandler(useCaseMock)
+
+			var saleOrderID uint64 = 123
+
+			useCaseMock.EXPECT().
+				Handle(ctx, saleOrderID, uint64(1)).
+				Return(nil, tt.useCaseErr)
+
+			bodyReader := bytes.NewReader([]byte(``))
+			response := httptest.NewRecorder()
+			request, requestErr := http.NewRequest(http.MethodPost, fmt.Sprintf("?id=%d", saleOrderID), bodyReader)
+			request.Header.Set("If-Match", `"1"`)
+
+			// act
+			handler.Handle(response, request)
+
+			// assert
+			assert.NoError(t, requestErr)
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.JSONEq(t, tt.expectedBody, response.Body.String())
+		})
+	}
+}
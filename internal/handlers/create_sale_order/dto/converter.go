@@ -1,11 +1,15 @@
 package dto
 
 import (
+	"fmt"
+
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
 )
 
-func SaleOrderDtoToSaleOrder(saleOrderDTO SaleOrder) *document.SaleOrder {
+func SaleOrderDtoToSaleOrder(saleOrderDTO SaleOrder) (*document.SaleOrder, error) {
 	saleOrder := document.SaleOrder{
 		Document: document.Document{},
 		Customer: reference.Customer{
@@ -13,16 +17,29 @@ func SaleOrderDtoToSaleOrder(saleOrderDTO SaleOrder) *document.SaleOrder {
 				ID: saleOrderDTO.CustomerID,
 			},
 		},
+		Currency: money.Currency(saleOrderDTO.Currency),
 	}
-	for _, product := range saleOrderDTO.Products {
-		saleOrder.Products = append(saleOrder.Products, document.SaleOrderProduct{
+	for i, product := range saleOrderDTO.Products {
+		saleOrderProduct := document.SaleOrderProduct{
 			Product: reference.Product{
 				Reference: reference.Reference{
 					ID: product.ProductID,
 				},
 			},
 			Quantity: int(product.Quantity),
-		})
+		}
+		if product.Price != nil {
+			price, err := money.Parse(product.Price.String(), saleOrder.Currency)
+			if err != nil {
+				return nil, errors.NewErrValidation("bad order data", err, errors.FieldError{
+					Field:   fmt.Sprintf("products[%d].price", i),
+					Message: err.Error(),
+				})
+			}
+			saleOrderProduct.Price = price
+			saleOrderProduct.ManualPrice = true
+		}
+		saleOrder.Products = append(saleOrder.Products, saleOrderProduct)
 	}
-	return &saleOrder
+	return &saleOrder, nil
 }
@@ -1,10 +1,12 @@
 package dto
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
 )
 
@@ -12,6 +14,8 @@ const (
 	defaultCustomerID = 1
 	defaultProductID  = 2
 	defaultQuantity   = 10
+	defaultPrice      = "99.90"
+	defaultCurrency   = "USD"
 )
 
 func TestSaleOrderDtoToSaleOrder(t *testing.T) {
@@ -19,9 +23,10 @@ func TestSaleOrderDtoToSaleOrder(t *testing.T) {
 		saleOrderDTO SaleOrder
 	}
 	tests := []struct {
-		name string
-		args args
-		want *document.SaleOrder
+		name    string
+		args    args
+		want    *document.SaleOrder
+		wantErr bool
 	}{
 		{
 			name: "with products",
@@ -50,11 +55,63 @@ func TestSaleOrderDtoToSaleOrder(t *testing.T) {
 							},
 						},
 						Quantity: defaultQuantity,
-						Price:    0,
+						Price:    money.Money{},
 					},
 				},
 			},
 		},
+		{
+			name: "with manual price",
+			args: args{
+				saleOrderDTO: SaleOrder{
+					CustomerID: defaultCustomerID,
+					Currency:   defaultCurrency,
+					Products: []SaleOrderProduct{
+						{
+							ProductID: defaultProductID,
+							Quantity:  defaultQuantity,
+							Price:     func() *json.Number { price := json.Number(defaultPrice); return &price }(),
+						},
+					},
+				},
+			},
+			want: &document.SaleOrder{
+				Customer: reference.Customer{
+					Reference: reference.Reference{
+						ID: defaultCustomerID,
+					},
+				},
+				Currency: defaultCurrency,
+				Products: []document.SaleOrderProduct{
+					{
+						Product: reference.Product{
+							Reference: reference.Reference{
+								ID: defaultProductID,
+							},
+						},
+						Quantity:    defaultQuantity,
+						Price:       money.New(9990, defaultCurrency),
+						ManualPrice: true,
+					},
+				},
+			},
+		},
+		{
+			name: "with bad manual price",
+			args: args{
+				saleOrderDTO: SaleOrder{
+					CustomerID: defaultCustomerID,
+					Products: []SaleOrderProduct{
+						{
+							ProductID: defaultProductID,
+							Quantity:  defaultQuantity,
+							Price:     func() *json.Number { price := json.Number("99.999"); return &price }(),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "without products",
 			args: args{
@@ -73,7 +130,11 @@ func TestSaleOrderDtoToSaleOrder(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := SaleOrderDtoToSaleOrder(tt.args.saleOrderDTO)
+			got, err := SaleOrderDtoToSaleOrder(tt.args.saleOrderDTO)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SaleOrderDtoToSaleOrder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("SaleOrderDtoToSaleOrder() got = %v, want %v", got, tt.want)
 			}
@@ -1,11 +1,15 @@
 package dto
 
+import "encoding/json"
+
 type SaleOrder struct {
 	CustomerID uint64             `json:"customer_id"`
+	Currency   string             `json:"currency,omitempty"`
 	Products   []SaleOrderProduct `json:"products"`
 }
 
 type SaleOrderProduct struct {
-	ProductID uint64 `json:"product_id"`
-	Quantity  uint64 `json:"quantity"`
+	ProductID uint64       `json:"product_id"`
+	Quantity  uint64       `json:"quantity"`
+	Price     *json.Number `json:"price,omitempty"`
 }
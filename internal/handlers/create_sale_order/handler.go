@@ -2,98 +2,118 @@
 package create_sale_order
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
-	"github.com/kiaplayer/clean-architecture-example/internal/domain/service/sale_order"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/use_case/idempotent"
 	"github.com/kiaplayer/clean-architecture-example/internal/handlers/create_sale_order/dto"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/response"
 )
 
 type useCase interface {
-	Handle(context.Context, *document.SaleOrder) (*document.SaleOrder, error)
+	Handle(ctx context.Context, key string, saleOrder *document.SaleOrder) (*document.SaleOrder, bool, error)
 }
 
-type transactor interface {
-	RunInTx(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error)
-}
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
 
 type Handler struct {
-	useCase    useCase
-	transactor transactor
+	useCase useCase
 }
 
-func NewHandler(u useCase, t transactor) *Handler {
+func NewHandler(u useCase) *Handler {
 	return &Handler{
-		useCase:    u,
-		transactor: t,
+		useCase: u,
 	}
 }
 
-func (h *Handler) validateAndPrepare(request *http.Request) (*document.SaleOrder, error) {
+func (h *Handler) validateAndPrepare(body []byte) (*document.SaleOrder, error) {
 	var saleOrderDTO dto.SaleOrder
-	err := json.NewDecoder(request.Body).Decode(&saleOrderDTO)
+	err := json.NewDecoder(bytes.NewReader(body)).Decode(&saleOrderDTO)
 	if err != nil {
-		return nil, err
+		return nil, domainerrors.NewErrValidation("bad json", err)
 	}
 
-	isValid := saleOrderDTO.CustomerID > 0 && len(saleOrderDTO.Products) > 0
-	if isValid {
-		for _, product := range saleOrderDTO.Products {
-			if (product.ProductID == 0) || (product.Quantity == 0) {
-				isValid = false
-				break
-			}
+	var fieldErrors []domainerrors.FieldError
+	if saleOrderDTO.CustomerID == 0 {
+		fieldErrors = append(fieldErrors, domainerrors.FieldError{Field: "customer_id", Message: "is required"})
+	}
+	if len(saleOrderDTO.Products) == 0 {
+		fieldErrors = append(fieldErrors, domainerrors.FieldError{Field: "products", Message: "must not be empty"})
+	}
+	for i, product := range saleOrderDTO.Products {
+		if product.ProductID == 0 {
+			fieldErrors = append(fieldErrors, domainerrors.FieldError{
+				Field:   fmt.Sprintf("products[%d].product_id", i),
+				Message: "is required",
+			})
+		}
+		if product.Quantity == 0 {
+			fieldErrors = append(fieldErrors, domainerrors.FieldError{
+				Field:   fmt.Sprintf("products[%d].quantity", i),
+				Message: "must be positive",
+			})
+		} else if product.Quantity > document.MaxLineQuantity {
+			fieldErrors = append(fieldErrors, domainerrors.FieldError{
+				Field:   fmt.Sprintf("products[%d].quantity", i),
+				Message: fmt.Sprintf("must be at most %d", document.MaxLineQuantity),
+			})
 		}
 	}
 
-	if !isValid {
-		return nil, errors.New("bad order data")
+	if len(fieldErrors) > 0 {
+		return nil, domainerrors.NewErrValidation("bad order data", nil, fieldErrors...)
 	}
 
-	return dto.SaleOrderDtoToSaleOrder(saleOrderDTO), nil
+	return dto.SaleOrderDtoToSaleOrder(saleOrderDTO)
 }
 
+// Handle creates the sale order.
+// Requests with an Idempotency-Key header are processed once by the use case,
+// a repeat with the same key and order returns the same order with the Idempotent-Replayed header.
 func (h *Handler) Handle(writer http.ResponseWriter, request *http.Request) {
-	err := h.checkAccess(request)
-	if err != nil {
-		writer.WriteHeader(http.StatusForbidden)
-		_, _ = writer.Write([]byte(err.Error()))
+	idempotencyKey := request.Header.Get(idempotencyKeyHeader)
+	if len(idempotencyKey) > idempotent.MaxKeyLength {
+		response.Error(writer, domainerrors.NewErrValidation(
+			"bad idempotency key",
+			nil,
+			domainerrors.FieldError{
+				Field:   idempotencyKeyHeader,
+				Message: fmt.Sprintf("must be at most %d characters", idempotent.MaxKeyLength),
+			},
+		))
 		return
 	}
 
-	saleOrder, err := h.validateAndPrepare(request)
+	body, err := io.ReadAll(request.Body)
 	if err != nil {
-		http.Error(writer, err.Error(), http.StatusBadRequest)
+		response.Error(writer, domainerrors.NewErrValidation("bad request body", err))
 		return
 	}
 
-	saleOrderUpdated, err := h.transactor.RunInTx(request.Context(), func(ctx context.Context) (any, error) {
-		return h.useCase.Handle(ctx, saleOrder)
-	})
+	saleOrder, err := h.validateAndPrepare(body)
 	if err != nil {
-		var errTarget *sale_order.ErrValidation
-		if errors.As(err, &errTarget) {
-			http.Error(writer, errTarget.Error(), http.StatusBadRequest)
-		} else {
-			http.Error(writer, "internal error", http.StatusInternalServerError)
-		}
+		response.Error(writer, err)
 		return
 	}
 
-	saleOrder = saleOrderUpdated.(*document.SaleOrder)
-
-	_, _ = writer.Write([]byte(fmt.Sprintf("SaleOrder ID = %d", saleOrder.ID)))
-
-	return
-}
+	saleOrderCreated, replayed, err := h.useCase.Handle(request.Context(), idempotencyKey, saleOrder)
+	if err != nil {
+		response.Error(writer, err)
+		return
+	}
 
-func (h *Handler) checkAccess(request *http.Request) error {
-	if request.Method == http.MethodDelete {
-		return errors.New("access denied") // demo only
+	if replayed {
+		writer.Header().Set(idempotentReplayedHeader, "true")
 	}
-	return nil
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write([]byte(fmt.Sprintf("SaleOrder ID = %d", saleOrderCreated.ID)))
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,7 +15,7 @@ import (
 
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
-	"github.com/kiaplayer/clean-architecture-example/internal/domain/service/sale_order"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
 	mocks "github.com/kiaplayer/clean-architecture-example/internal/handlers/create_sale_order/mocks"
 )
 
@@ -24,8 +25,7 @@ func TestHandle_Success(t *testing.T) {
 	ctx := context.Background()
 
 	useCaseMock := mocks.NewMockuseCase(ctrl)
-	transactorMock := mocks.NewMocktransactor(ctrl)
-	handler := NewHandler(useCaseMock, transactorMock)
+	handler := NewHandler(useCaseMock)
 
 	saleOrder := &document.SaleOrder{
 		Customer: reference.Customer{
@@ -46,16 +46,8 @@ func TestHandle_Success(t *testing.T) {
 	}
 
 	useCaseMock.EXPECT().
-		Handle(ctx, saleOrder).
-		Return(saleOrder, nil)
-
-	transactorMock.EXPECT().
-		RunInTx(ctx, gomock.Any()).
-		DoAndReturn(
-			func(ctx context.Context, fn func(context.Context) (any, error)) (any, error) {
-				return fn(ctx)
-			},
-		)
+		Handle(ctx, "", saleOrder).
+		Return(saleOrder, false, nil)
 
 	bodyReader := bytes.NewReader([]byte(`{"customer_id": 1, "products": [{"product_id": 1, "quantity": 1}]}`))
 	response := httptest.NewRecorder()
@@ -70,17 +62,21 @@ func TestHandle_Success(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("SaleOrder ID = %d", saleOrder.ID), response.Body.String())
 }
 
-func TestHandle_checkAccessError(t *testing.T) {
+func TestHandle_useCaseForbidden(t *testing.T) {
 	// arrange
 	ctrl := gomock.NewController(t)
+	ctx := context.Background()
 
 	useCaseMock := mocks.NewMockuseCase(ctrl)
-	transactorMock := mocks.NewMocktransactor(ctrl)
-	handler := NewHandler(useCaseMock, transactorMock)
+	handler := NewHandler(useCaseMock)
 
-	bodyReader := bytes.NewReader([]byte(`{}`))
+	useCaseMock.EXPECT().
+		Handle(ctx, "", gomock.Any()).
+		Return(nil, false, domainerrors.NewErrForbidden("role viewer has no permission orders:create", nil))
+
+	bodyReader := bytes.NewReader([]byte(`{"customer_id": 1, "products": [{"product_id": 1, "quantity": 1}]}`))
 	response := httptest.NewRecorder()
-	request, requestErr := http.NewRequest(http.MethodDelete, "", bodyReader)
+	request, requestErr := http.NewRequest(http.MethodPost, "", bodyReader)
 
 	// act
 	handler.Handle(response, request)
@@ -95,8 +91,7 @@ func TestHandle_validateError_emptyRequest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	useCaseMock := mocks.NewMockuseCase(ctrl)
-	transactorMock := mocks.NewMocktransactor(ctrl)
-	handler := NewHandler(useCaseMock, transactorMock)
+	handler := NewHandler(useCaseMock)
 
 	bodyReader := bytes.NewReader([]byte(`{}`))
 	response := httptest.NewRecorder()
@@ -115,8 +110,7 @@ func TestHandle_validateError_zeroProductID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	useCaseMock := mocks.NewMockuseCase(ctrl)
-	transactorMock := mocks.NewMocktransactor(ctrl)
-	handler := NewHandler(useCaseMock, transactorMock)
+	handler := NewHandler(useCaseMock)
 
 	bodyReader := bytes.NewReader([]byte(`{"customer_id": 1, "products": [{"product_id": 0, "quantity": 1}]}`))
 	response := httptest.NewRecorder()
@@ -130,13 +124,111 @@ func TestHandle_validateError_zeroProductID(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, response.Code)
 }
 
+func TestHandle_validateError_fieldErrors(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	bodyReader := bytes.NewReader([]byte(`{"products": [{"product_id": 1, "quantity": 1}, {"product_id": 0, "quantity": 0}]}`))
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPost, "", bodyReader)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.JSONEq(
+		t,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "bad order data",
+			"errors": [
+				{"field": "customer_id", "message": "is required"},
+				{"field": "products[1].product_id", "message": "is required"},
+				{"field": "products[1].quantity", "message": "must be positive"}
+			]
+		}`,
+		response.Body.String(),
+	)
+}
+
+func TestHandle_validateError_quantityTooLarge(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	bodyReader := bytes.NewReader([]byte(`{"customer_id": 1, "products": [{"product_id": 2, "quantity": 18446744073709551615}]}`))
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPost, "", bodyReader)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.JSONEq(
+		t,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "bad order data",
+			"errors": [
+				{"field": "products[0].quantity", "message": "must be at most 1000000"}
+			]
+		}`,
+		response.Body.String(),
+	)
+}
+
+func TestHandle_validateError_badPrice(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	bodyReader := bytes.NewReader([]byte(`{"customer_id": 1, "products": [{"product_id": 1, "quantity": 1, "price": 10.005}]}`))
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPost, "", bodyReader)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Equal(t, "application/problem+json", response.Header().Get("Content-Type"))
+	assert.JSONEq(
+		t,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "bad order data",
+			"errors": [
+				{"field": "products[0].price", "message": "bad amount: 10.005: too many decimal places"}
+			]
+		}`,
+		response.Body.String(),
+	)
+}
+
 func TestHandle_validateError_invalidJSON(t *testing.T) {
 	// arrange
 	ctrl := gomock.NewController(t)
 
 	useCaseMock := mocks.NewMockuseCase(ctrl)
-	transactorMock := mocks.NewMocktransactor(ctrl)
-	handler := NewHandler(useCaseMock, transactorMock)
+	handler := NewHandler(useCaseMock)
 
 	bodyReader := bytes.NewReader([]byte(`invalid_json`))
 	response := httptest.NewRecorder()
@@ -156,8 +248,7 @@ func TestHandle_UseCaseError(t *testing.T) {
 	ctx := context.Background()
 
 	useCaseMock := mocks.NewMockuseCase(ctrl)
-	transactorMock := mocks.NewMocktransactor(ctrl)
-	handler := NewHandler(useCaseMock, transactorMock)
+	handler := NewHandler(useCaseMock)
 
 	saleOrder := &document.SaleOrder{
 		Customer: reference.Customer{
@@ -180,16 +271,8 @@ func TestHandle_UseCaseError(t *testing.T) {
 	createErr := errors.New("some error while creating sale order")
 
 	useCaseMock.EXPECT().
-		Handle(ctx, saleOrder).
-		Return(nil, createErr)
-
-	transactorMock.EXPECT().
-		RunInTx(ctx, gomock.Any()).
-		DoAndReturn(
-			func(ctx context.Context, fn func(context.Context) (any, error)) (any, error) {
-				return fn(ctx)
-			},
-		)
+		Handle(ctx, "", saleOrder).
+		Return(nil, false, createErr)
 
 	bodyReader := bytes.NewReader([]byte(`{"customer_id": 1, "products": [{"product_id": 1, "quantity": 1}]}`))
 	response := httptest.NewRecorder()
@@ -209,8 +292,7 @@ func TestHandle_UseCaseValidationError(t *testing.T) {
 	ctx := context.Background()
 
 	useCaseMock := mocks.NewMockuseCase(ctrl)
-	transactorMock := mocks.NewMocktransactor(ctrl)
-	handler := NewHandler(useCaseMock, transactorMock)
+	handler := NewHandler(useCaseMock)
 
 	saleOrder := &document.SaleOrder{
 		Customer: reference.Customer{
@@ -230,23 +312,119 @@ func TestHandle_UseCaseValidationError(t *testing.T) {
 		},
 	}
 
-	validationErr := sale_order.NewErrValidation("validation error", nil)
+	validationErr := domainerrors.NewErrValidation("validation error", nil)
 
 	useCaseMock.EXPECT().
-		Handle(ctx, saleOrder).
-		Return(nil, validationErr)
-
-	transactorMock.EXPECT().
-		RunInTx(ctx, gomock.Any()).
-		DoAndReturn(
-			func(ctx context.Context, fn func(context.Context) (any, error)) (any, error) {
-				return fn(ctx)
-			},
-		)
+		Handle(ctx, "", saleOrder).
+		Return(nil, false, validationErr)
+
+	bodyReader := bytes.NewReader([]byte(`{"customer_id": 1, "products": [{"product_id": 1, "quantity": 1}]}`))
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPost, "", bodyReader)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.JSONEq(
+		t,
+		`{"type": "about:blank", "title": "Bad Request", "status": 400, "detail": "validation error"}`,
+		response.Body.String(),
+	)
+}
+
+func TestHandle_IdempotencyKey(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	useCaseMock.EXPECT().
+		Handle(ctx, "key-1", gomock.Any()).
+		Return(&document.SaleOrder{Document: document.Document{ID: 10}}, false, nil)
+
+	bodyReader := bytes.NewReader([]byte(`{"customer_id": 1, "products": [{"product_id": 1, "quantity": 1}]}`))
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPost, "", bodyReader)
+	request.Header.Set("Idempotency-Key", "key-1")
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "SaleOrder ID = 10", response.Body.String())
+	assert.Empty(t, response.Header().Get("Idempotent-Replayed"))
+}
+
+func TestHandle_IdempotencyKeyReplayed(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	useCaseMock.EXPECT().
+		Handle(ctx, "key-1", gomock.Any()).
+		Return(&document.SaleOrder{Document: document.Document{ID: 10}}, true, nil)
+
+	bodyReader := bytes.NewReader([]byte(`{"customer_id": 1, "products": [{"product_id": 1, "quantity": 1}]}`))
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPost, "", bodyReader)
+	request.Header.Set("Idempotency-Key", "key-1")
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "SaleOrder ID = 10", response.Body.String())
+	assert.Equal(t, "true", response.Header().Get("Idempotent-Replayed"))
+}
+
+func TestHandle_IdempotencyKeyReusedWithDifferentRequest(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	useCaseMock.EXPECT().
+		Handle(ctx, "key-1", gomock.Any()).
+		Return(nil, false, domainerrors.NewErrUnprocessable("idempotency key is reused with a different request", nil))
+
+	bodyReader := bytes.NewReader([]byte(`{"customer_id": 1, "products": [{"product_id": 1, "quantity": 1}]}`))
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPost, "", bodyReader)
+	request.Header.Set("Idempotency-Key", "key-1")
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+}
+
+func TestHandle_IdempotencyKeyTooLong(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
 
 	bodyReader := bytes.NewReader([]byte(`{"customer_id": 1, "products": [{"product_id": 1, "quantity": 1}]}`))
 	response := httptest.NewRecorder()
 	request, requestErr := http.NewRequest(http.MethodPost, "", bodyReader)
+	request.Header.Set("Idempotency-Key", strings.Repeat("k", 256))
 
 	// act
 	handler.Handle(response, request)
@@ -254,5 +432,4 @@ func TestHandle_UseCaseValidationError(t *testing.T) {
 	// assert
 	assert.NoError(t, requestErr)
 	assert.Equal(t, http.StatusBadRequest, response.Code)
-	assert.Equal(t, "validation error\n", response.Body.String())
 }
@@ -41,54 +41,17 @@ func (m *MockuseCase) EXPECT() *MockuseCaseMockRecorder {
 }
 
 // Handle mocks base method.
-func (m *MockuseCase) Handle(arg0 context.Context, arg1 *document.SaleOrder) (*document.SaleOrder, error) {
+func (m *MockuseCase) Handle(ctx context.Context, key string, saleOrder *document.SaleOrder) (*document.SaleOrder, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Handle", arg0, arg1)
+	ret := m.ctrl.Call(m, "Handle", ctx, key, saleOrder)
 	ret0, _ := ret[0].(*document.SaleOrder)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // Handle indicates an expected call of Handle.
-func (mr *MockuseCaseMockRecorder) Handle(arg0, arg1 any) *gomock.Call {
+func (mr *MockuseCaseMockRecorder) Handle(ctx, key, saleOrder any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockuseCase)(nil).Handle), arg0, arg1)
-}
-
-// Mocktransactor is a mock of transactor interface.
-type Mocktransactor struct {
-	ctrl     *gomock.Controller
-	recorder *MocktransactorMockRecorder
-}
-
-// MocktransactorMockRecorder is the mock recorder for Mocktransactor.
-type MocktransactorMockRecorder struct {
-	mock *Mocktransactor
-}
-
-// NewMocktransactor creates a new mock instance.
-func NewMocktransactor(ctrl *gomock.Controller) *Mocktransactor {
-	mock := &Mocktransactor{ctrl: ctrl}
-	mock.recorder = &MocktransactorMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mocktransactor) EXPECT() *MocktransactorMockRecorder {
-	return m.recorder
-}
-
-// RunInTx mocks base method.
-func (m *Mocktransactor) RunInTx(ctx context.Context, fn func(context.Context) (any, error)) (any, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RunInTx", ctx, fn)
-	ret0, _ := ret[0].(any)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// RunInTx indicates an expected call of RunInTx.
-func (mr *MocktransactorMockRecorder) RunInTx(ctx, fn any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInTx", reflect.TypeOf((*Mocktransactor)(nil).RunInTx), ctx, fn)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockuseCase)(nil).Handle), ctx, key, saleOrder)
 }
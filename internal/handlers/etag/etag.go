@@ -0,0 +1,50 @@
+package etag
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+)
+
+const ifMatchHeader = "If-Match"
+
+// Format returns the entity version as a strong ETag.
+func Format(version uint64) string {
+	return `"` + strconv.FormatUint(version, 10) + `"`
+}
+
+// Set writes the entity version to the ETag header.
+func Set(writer http.ResponseWriter, version uint64) {
+	writer.Header().Set("ETag", Format(version))
+}
+
+// IfMatch returns the entity version from the If-Match header.
+// The header is required and must contain a single ETag returned by the API,
+// a missing header is ErrPreconditionRequired and a malformed one is ErrValidation.
+func IfMatch(request *http.Request) (uint64, error) {
+	value := strings.TrimSpace(request.Header.Get(ifMatchHeader))
+	if value == "" {
+		return 0, domainerrors.NewErrPreconditionRequired("no version, If-Match header is required", nil)
+	}
+
+	unquoted, err := strconv.Unquote(value)
+	if err != nil || !strings.HasPrefix(value, `"`) {
+		return 0, badIfMatch(err)
+	}
+	version, err := strconv.ParseUint(unquoted, 10, 64)
+	if err != nil || version == 0 {
+		return 0, badIfMatch(err)
+	}
+
+	return version, nil
+}
+
+func badIfMatch(cause error) error {
+	return domainerrors.NewErrValidation(
+		"bad version",
+		cause,
+		domainerrors.FieldError{Field: ifMatchHeader, Message: "must be an ETag of the entity"},
+	)
+}
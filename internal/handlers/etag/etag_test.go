@@ -0,0 +1,76 @@
+package etag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+)
+
+func TestSet(t *testing.T) {
+	// arrange
+	response := httptest.NewRecorder()
+
+	// act
+	Set(response, 3)
+
+	// assert
+	assert.Equal(t, `"3"`, response.Header().Get("ETag"))
+}
+
+func TestIfMatch_Missing(t *testing.T) {
+	// arrange
+	request, requestErr := http.NewRequest(http.MethodPut, "", nil)
+
+	// act
+	version, err := IfMatch(request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Zero(t, version)
+	var errPreconditionRequired *domainerrors.ErrPreconditionRequired
+	assert.ErrorAs(t, err, &errPreconditionRequired)
+}
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		name            string
+		header          string
+		expectedVersion uint64
+		expectedErr     bool
+	}{
+		{name: "strong etag", header: `"3"`, expectedVersion: 3},
+		{name: "spaces", header: ` "12" `, expectedVersion: 12},
+		{name: "unquoted", header: "3", expectedErr: true},
+		{name: "weak etag", header: `W/"3"`, expectedErr: true},
+		{name: "any", header: "*", expectedErr: true},
+		{name: "list", header: `"3", "4"`, expectedErr: true},
+		{name: "zero", header: `"0"`, expectedErr: true},
+		{name: "not a number", header: `"abc"`, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			request, requestErr := http.NewRequest(http.MethodPut, "", nil)
+			request.Header.Set("If-Match", tt.header)
+
+			// act
+			version, err := IfMatch(request)
+
+			// assert
+			assert.NoError(t, requestErr)
+			if tt.expectedErr {
+				var errValidation *domainerrors.ErrValidation
+				assert.ErrorAs(t, err, &errValidation)
+				assert.Equal(t, "If-Match", errValidation.Fields()[0].Field)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedVersion, version)
+		})
+	}
+}
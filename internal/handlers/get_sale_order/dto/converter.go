@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+)
+
+func SaleOrderToSaleOrderDto(saleOrder *document.SaleOrder) SaleOrder {
+	saleOrderDTO := SaleOrder{
+		ID:     saleOrder.ID,
+		Number: saleOrder.Number,
+		Date:   saleOrder.Date.Format(time.RFC3339),
+		Status: saleOrder.Status.String(),
+		Company: Company{
+			ID:   saleOrder.Company.ID,
+			Name: saleOrder.Company.Name,
+		},
+		Customer: Customer{
+			ID:   saleOrder.Customer.ID,
+			Name: saleOrder.Customer.Name,
+		},
+		Currency: string(saleOrder.Currency),
+		Products: make([]SaleOrderProduct, 0, len(saleOrder.Products)),
+		Total:    json.Number(saleOrder.Total().String()),
+	}
+	saleOrderDTO.AppendUser = userToUserDto(saleOrder.AppendUser)
+	saleOrderDTO.ChangeUser = userToUserDto(saleOrder.ChangeUser)
+	if !saleOrder.CreatedAt.IsZero() {
+		saleOrderDTO.CreatedAt = saleOrder.CreatedAt.Format(time.RFC3339)
+	}
+	if !saleOrder.UpdatedAt.IsZero() {
+		saleOrderDTO.UpdatedAt = saleOrder.UpdatedAt.Format(time.RFC3339)
+	}
+	for _, product := range saleOrder.Products {
+		saleOrderDTO.Products = append(saleOrderDTO.Products, SaleOrderProduct{
+			ID:          product.ID,
+			ProductID:   product.Product.ID,
+			ProductName: product.Product.Name,
+			Quantity:    product.Quantity,
+			Price:       json.Number(product.Price.String()),
+		})
+	}
+	return saleOrderDTO
+}
+
+func userToUserDto(user *reference.User) *User {
+	if user == nil {
+		return nil
+	}
+	return &User{
+		ID:   user.ID,
+		Name: user.Name,
+	}
+}
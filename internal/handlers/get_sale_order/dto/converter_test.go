@@ -0,0 +1,152 @@
+package dto
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+)
+
+const (
+	defaultSaleOrderID        = 1
+	defaultSaleOrderNumber    = "0001"
+	defaultCustomerID         = 2
+	defaultCustomerName       = "Customer"
+	defaultSaleOrderProductID = 3
+	defaultProductID          = 4
+	defaultProductName        = "Keyboard"
+	defaultQuantity           = 10
+	defaultPrice              = 15050
+	defaultCurrency           = "RUB"
+	defaultUserID             = 5
+	defaultCompanyID          = 6
+	defaultCompanyName        = "Company"
+	defaultUserName           = "Manager"
+)
+
+func TestSaleOrderToSaleOrderDto(t *testing.T) {
+	date := time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC)
+
+	type args struct {
+		saleOrder *document.SaleOrder
+	}
+	tests := []struct {
+		name string
+		args args
+		want SaleOrder
+	}{
+		{
+			name: "with products",
+			args: args{
+				saleOrder: &document.SaleOrder{
+					Document: document.Document{
+						ID:     defaultSaleOrderID,
+						Number: defaultSaleOrderNumber,
+						Date:   date,
+						Status: document.StatusPosted,
+						Company: reference.Company{
+							Reference: reference.Reference{ID: defaultCompanyID, Name: defaultCompanyName},
+						},
+						AppendUser: &reference.User{
+							Reference: reference.Reference{ID: defaultUserID, Name: defaultUserName},
+						},
+						ChangeUser: &reference.User{
+							Reference: reference.Reference{ID: defaultUserID, Name: defaultUserName},
+						},
+						CreatedAt: date,
+						UpdatedAt: date.Add(time.Hour),
+					},
+					Customer: reference.Customer{
+						Reference: reference.Reference{
+							ID:   defaultCustomerID,
+							Name: defaultCustomerName,
+						},
+					},
+					Currency: defaultCurrency,
+					Products: []document.SaleOrderProduct{
+						{
+							ID: defaultSaleOrderProductID,
+							Product: reference.Product{
+								Reference: reference.Reference{
+									ID:   defaultProductID,
+									Name: defaultProductName,
+								},
+							},
+							Quantity: defaultQuantity,
+							Price:    money.New(defaultPrice, defaultCurrency),
+						},
+					},
+				},
+			},
+			want: SaleOrder{
+				ID:     defaultSaleOrderID,
+				Number: defaultSaleOrderNumber,
+				Date:   "2024-05-01T10:20:30Z",
+				Status: "posted",
+				Company: Company{
+					ID:   defaultCompanyID,
+					Name: defaultCompanyName,
+				},
+				Customer: Customer{
+					ID:   defaultCustomerID,
+					Name: defaultCustomerName,
+				},
+				Currency: defaultCurrency,
+				Products: []SaleOrderProduct{
+					{
+						ID:          defaultSaleOrderProductID,
+						ProductID:   defaultProductID,
+						ProductName: defaultProductName,
+						Quantity:    defaultQuantity,
+						Price:       "150.50",
+					},
+				},
+				Total:      "1505.00",
+				AppendUser: &User{ID: defaultUserID, Name: defaultUserName},
+				ChangeUser: &User{ID: defaultUserID, Name: defaultUserName},
+				CreatedAt:  "2024-05-01T10:20:30Z",
+				UpdatedAt:  "2024-05-01T11:20:30Z",
+			},
+		},
+		{
+			name: "without products",
+			args: args{
+				saleOrder: &document.SaleOrder{
+					Document: document.Document{
+						ID:     defaultSaleOrderID,
+						Number: defaultSaleOrderNumber,
+						Date:   date,
+						Status: document.StatusDraft,
+					},
+					Customer: reference.Customer{
+						Reference: reference.Reference{
+							ID: defaultCustomerID,
+						},
+					},
+				},
+			},
+			want: SaleOrder{
+				ID:     defaultSaleOrderID,
+				Number: defaultSaleOrderNumber,
+				Date:   "2024-05-01T10:20:30Z",
+				Status: "draft",
+				Customer: Customer{
+					ID: defaultCustomerID,
+				},
+				Products: []SaleOrderProduct{},
+				Total:    "0.00",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SaleOrderToSaleOrderDto(tt.args.saleOrder)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SaleOrderToSaleOrderDto() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package dto
+
+import "encoding/json"
+
+type SaleOrder struct {
+	ID         uint64             `json:"id"`
+	Number     string             `json:"number"`
+	Date       string             `json:"date"`
+	Status     string             `json:"status"`
+	Company    Company            `json:"company"`
+	Customer   Customer           `json:"customer"`
+	Currency   string             `json:"currency"`
+	Products   []SaleOrderProduct `json:"products"`
+	Total      json.Number        `json:"total"`
+	AppendUser *User              `json:"append_user"`
+	ChangeUser *User              `json:"change_user"`
+	CreatedAt  string             `json:"created_at,omitempty"`
+	UpdatedAt  string             `json:"updated_at,omitempty"`
+}
+
+type Company struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+}
+
+type Customer struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+}
+
+type User struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+}
+
+type SaleOrderProduct struct {
+	ID          uint64      `json:"id"`
+	ProductID   uint64      `json:"product_id"`
+	ProductName string      `json:"product_name"`
+	Quantity    int         `json:"quantity"`
+	Price       json.Number `json:"price"`
+}
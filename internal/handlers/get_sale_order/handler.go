@@ -3,12 +3,15 @@ package get_sale_order
 
 import (
 	"context"
-	"errors"
-	"fmt"
+	"encoding/json"
 	"net/http"
 	"strconv"
 
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/etag"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/get_sale_order/dto"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/response"
 )
 
 type useCase interface {
@@ -29,50 +32,33 @@ func NewHandler(u useCase) *Handler {
 }
 
 func (h *Handler) Handle(writer http.ResponseWriter, request *http.Request) {
-	err := h.checkAccess(request)
-	if err != nil {
-		writer.WriteHeader(http.StatusForbidden)
-		_, _ = writer.Write([]byte(err.Error()))
-		return
-	}
-
 	saleOrderID, err := h.validateAndPrepare(request)
 	if err != nil {
-		writer.WriteHeader(http.StatusBadRequest)
-		_, _ = writer.Write([]byte(err.Error()))
+		response.Error(writer, err)
 		return
 	}
 
 	saleOrder, err := h.useCase.Handle(request.Context(), saleOrderID)
 	if err != nil {
-		writer.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	if saleOrder == nil {
-		writer.WriteHeader(http.StatusNotFound)
-		_, _ = writer.Write([]byte(fmt.Sprint("sale order not found")))
+		response.Error(writer, err)
 		return
 	}
 
-	_, _ = writer.Write([]byte(fmt.Sprintf("SaleOrder ID = %d", saleOrder.ID)))
+	etag.Set(writer, saleOrder.Version)
+	writer.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(writer).Encode(dto.SaleOrderToSaleOrderDto(saleOrder))
 
 	return
 }
 
-func (h *Handler) checkAccess(request *http.Request) error {
-	if request.Method == http.MethodDelete {
-		return errors.New("access denied") // demo only
-	}
-	return nil
-}
-
 func (h *Handler) validateAndPrepare(request *http.Request) (uint64, error) {
 	id, err := strconv.ParseInt(request.URL.Query().Get("id"), 10, 64)
-	if err != nil {
-		return 0, fmt.Errorf("bad id: %w", err)
-	}
-	if id <= 0 {
-		return 0, errors.New("bad id")
+	if err != nil || id <= 0 {
+		return 0, domainerrors.NewErrValidation(
+			"bad id",
+			err,
+			domainerrors.FieldError{Field: "id", Message: "must be a positive integer"},
+		)
 	}
 
 	return uint64(id), nil
@@ -8,11 +8,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
 	mocks "github.com/kiaplayer/clean-architecture-example/internal/handlers/get_sale_order/mocks"
 )
 
@@ -26,7 +30,31 @@ func TestHandle_Success(t *testing.T) {
 
 	saleOrder := &document.SaleOrder{
 		Document: document.Document{
-			ID: 123,
+			ID:      123,
+			Number:  "0001",
+			Date:    time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC),
+			Status:  document.StatusDraft,
+			Version: 4,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID:   1,
+				Name: "Customer",
+			},
+		},
+		Currency: money.DefaultCurrency,
+		Products: []document.SaleOrderProduct{
+			{
+				ID: 10,
+				Product: reference.Product{
+					Reference: reference.Reference{
+						ID:   2,
+						Name: "Keyboard",
+					},
+				},
+				Quantity: 3,
+				Price:    money.New(15050, money.DefaultCurrency),
+			},
 		},
 	}
 
@@ -44,19 +72,43 @@ func TestHandle_Success(t *testing.T) {
 	// assert
 	assert.NoError(t, requestErr)
 	assert.Equal(t, http.StatusOK, response.Code)
-	assert.Equal(t, fmt.Sprintf("SaleOrder ID = %d", saleOrder.ID), response.Body.String())
+	assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+	assert.Equal(t, `"4"`, response.Header().Get("ETag"))
+	assert.JSONEq(
+		t,
+		`{
+			"id": 123,
+			"number": "0001",
+			"date": "2024-05-01T10:20:30Z",
+			"status": "draft",
+			"currency": "RUB",
+			"total": 451.50,
+			"company": {"id": 0, "name": ""},
+			"customer": {"id": 1, "name": "Customer"},
+			"append_user": null,
+			"change_user": null,
+			"products": [
+				{"id": 10, "product_id": 2, "product_name": "Keyboard", "quantity": 3, "price": 150.5}
+			]
+		}`,
+		response.Body.String(),
+	)
 }
 
-func TestHandle_checkAccessError(t *testing.T) {
+func TestHandle_useCaseForbidden(t *testing.T) {
 	// arrange
 	ctrl := gomock.NewController(t)
+	ctx := context.Background()
 
 	useCaseMock := mocks.NewMockuseCase(ctrl)
 	handler := NewHandler(useCaseMock)
 
-	bodyReader := bytes.NewReader([]byte(`{}`))
+	useCaseMock.EXPECT().
+		Handle(ctx, uint64(1)).
+		Return(nil, domainerrors.NewErrForbidden("role viewer has no permission orders:read", nil))
+
 	response := httptest.NewRecorder()
-	request, requestErr := http.NewRequest(http.MethodDelete, "", bodyReader)
+	request, requestErr := http.NewRequest(http.MethodGet, "?id=1", nil)
 
 	// act
 	handler.Handle(response, request)
@@ -64,7 +116,11 @@ func TestHandle_checkAccessError(t *testing.T) {
 	// assert
 	assert.NoError(t, requestErr)
 	assert.Equal(t, http.StatusForbidden, response.Code)
-	assert.Equal(t, "access denied", response.Body.String())
+	assert.JSONEq(
+		t,
+		`{"type": "about:blank", "title": "Forbidden", "status": 403, "detail": "role viewer has no permission orders:read"}`,
+		response.Body.String(),
+	)
 }
 
 func TestHandle_validateAndPrepareError_BadID(t *testing.T) {
@@ -135,7 +191,11 @@ func TestHandle_UseCaseError(t *testing.T) {
 	// assert
 	assert.NoError(t, requestErr)
 	assert.Equal(t, http.StatusInternalServerError, response.Code)
-	assert.Empty(t, response.Body.String())
+	assert.JSONEq(
+		t,
+		`{"type": "about:blank", "title": "Internal Server Error", "status": 500}`,
+		response.Body.String(),
+	)
 }
 
 func TestHandle_UseCaseNotFound(t *testing.T) {
@@ -148,7 +208,9 @@ func TestHandle_UseCaseNotFound(t *testing.T) {
 
 	var saleOrderID uint64 = 123
 
-	useCaseMock.EXPECT().Handle(ctx, saleOrderID).Return(nil, nil)
+	useCaseMock.EXPECT().
+		Handle(ctx, saleOrderID).
+		Return(nil, domainerrors.NewErrNotFound("sale order not found: 123", nil))
 
 	bodyReader := bytes.NewReader([]byte(``))
 	response := httptest.NewRecorder()
@@ -160,5 +222,9 @@ func TestHandle_UseCaseNotFound(t *testing.T) {
 	// assert
 	assert.NoError(t, requestErr)
 	assert.Equal(t, http.StatusNotFound, response.Code)
-	assert.Equal(t, "sale order not found", response.Body.String())
+	assert.JSONEq(
+		t,
+		`{"type": "about:blank", "title": "Not Found", "status": 404, "detail": "sale order not found: 123"}`,
+		response.Body.String(),
+	)
 }
@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+)
+
+func SaleOrderListToSaleOrderListDto(saleOrders *document.SaleOrderList) SaleOrderList {
+	saleOrderListDTO := SaleOrderList{
+		Items:      make([]SaleOrder, 0, len(saleOrders.Items)),
+		NextCursor: saleOrders.NextCursor,
+	}
+	for _, saleOrder := range saleOrders.Items {
+		saleOrderListDTO.Items = append(saleOrderListDTO.Items, SaleOrder{
+			ID:     saleOrder.ID,
+			Number: saleOrder.Number,
+			Date:   saleOrder.Date.Format(time.RFC3339),
+			Status: saleOrder.Status.String(),
+			Customer: Customer{
+				ID:   saleOrder.Customer.ID,
+				Name: saleOrder.Customer.Name,
+			},
+		})
+	}
+	return saleOrderListDTO
+}
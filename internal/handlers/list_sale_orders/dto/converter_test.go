@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+)
+
+func TestSaleOrderListToSaleOrderListDto(t *testing.T) {
+	date := time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC)
+
+	type args struct {
+		saleOrders *document.SaleOrderList
+	}
+	tests := []struct {
+		name string
+		args args
+		want SaleOrderList
+	}{
+		{
+			name: "with items",
+			args: args{
+				saleOrders: &document.SaleOrderList{
+					Items: []*document.SaleOrder{
+						{
+							Document: document.Document{
+								ID:     1,
+								Number: "0001",
+								Date:   date,
+								Status: document.StatusPosted,
+							},
+							Customer: reference.Customer{
+								Reference: reference.Reference{
+									ID:   2,
+									Name: "Customer",
+								},
+							},
+						},
+					},
+					NextCursor: "cursor",
+				},
+			},
+			want: SaleOrderList{
+				Items: []SaleOrder{
+					{
+						ID:     1,
+						Number: "0001",
+						Date:   "2024-05-01T10:20:30Z",
+						Status: "posted",
+						Customer: Customer{
+							ID:   2,
+							Name: "Customer",
+						},
+					},
+				},
+				NextCursor: "cursor",
+			},
+		},
+		{
+			name: "empty",
+			args: args{
+				saleOrders: &document.SaleOrderList{},
+			},
+			want: SaleOrderList{
+				Items: []SaleOrder{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SaleOrderListToSaleOrderListDto(tt.args.saleOrders)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SaleOrderListToSaleOrderListDto() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package dto
+
+type SaleOrderList struct {
+	Items      []SaleOrder `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+type SaleOrder struct {
+	ID       uint64   `json:"id"`
+	Number   string   `json:"number"`
+	Date     string   `json:"date"`
+	Status   string   `json:"status"`
+	Customer Customer `json:"customer"`
+}
+
+type Customer struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+}
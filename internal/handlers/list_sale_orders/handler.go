@@ -0,0 +1,131 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package list_sale_orders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/list_sale_orders/dto"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/response"
+)
+
+type useCase interface {
+	Handle(
+		ctx context.Context,
+		query document.SaleOrderListQuery,
+	) (saleOrders *document.SaleOrderList, err error)
+}
+
+type Handler struct {
+	useCase useCase
+}
+
+func NewHandler(u useCase) *Handler {
+	return &Handler{
+		useCase: u,
+	}
+}
+
+func (h *Handler) Handle(writer http.ResponseWriter, request *http.Request) {
+	query, err := h.validateAndPrepare(request)
+	if err != nil {
+		response.Error(writer, err)
+		return
+	}
+
+	saleOrders, err := h.useCase.Handle(request.Context(), query)
+	if err != nil {
+		response.Error(writer, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(writer).Encode(dto.SaleOrderListToSaleOrderListDto(saleOrders))
+}
+
+func (h *Handler) validateAndPrepare(request *http.Request) (document.SaleOrderListQuery, error) {
+	params := request.URL.Query()
+	query := document.SaleOrderListQuery{
+		Filter: document.SaleOrderFilter{
+			NumberPrefix: params.Get("number"),
+		},
+		Cursor: params.Get("cursor"),
+	}
+
+	var err error
+
+	if value := params.Get("date_from"); value != "" {
+		query.Filter.DateFrom, err = parseDate(value, false)
+		if err != nil {
+			return query, badParamError("date_from", "must be a date or RFC 3339 timestamp", err)
+		}
+	}
+
+	if value := params.Get("date_to"); value != "" {
+		query.Filter.DateTo, err = parseDate(value, true)
+		if err != nil {
+			return query, badParamError("date_to", "must be a date or RFC 3339 timestamp", err)
+		}
+	}
+
+	if value := params.Get("status"); value != "" {
+		status, ok := document.ParseStatus(value)
+		if !ok {
+			return query, badParamError("status", "must be one of: draft, posted, deleted", nil)
+		}
+		query.Filter.Status = &status
+	}
+
+	if value := params.Get("customer_id"); value != "" {
+		query.Filter.CustomerID, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return query, badParamError("customer_id", "must be a positive integer", err)
+		}
+	}
+
+	if value := params.Get("sort"); value != "" {
+		field, desc := strings.CutPrefix(value, "-")
+		query.Sort = document.SaleOrderSort{
+			Field: document.SaleOrderSortField(field),
+			Desc:  desc,
+		}
+	}
+
+	if value := params.Get("limit"); value != "" {
+		query.Limit, err = strconv.Atoi(value)
+		if err != nil {
+			return query, badParamError("limit", "must be an integer", err)
+		}
+	}
+
+	return query, nil
+}
+
+func badParamError(name string, message string, cause error) error {
+	return domainerrors.NewErrValidation(
+		fmt.Sprintf("bad %s", name),
+		cause,
+		domainerrors.FieldError{Field: name, Message: message},
+	)
+}
+
+// parseDate accepts RFC 3339 timestamps and plain dates, the latter are expanded to the end of the day for the upper
+// bound of the range.
+func parseDate(value string, endOfDay bool) (time.Time, error) {
+	date, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err == nil {
+		if endOfDay {
+			date = date.Add(24*time.Hour - time.Second)
+		}
+		return date, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
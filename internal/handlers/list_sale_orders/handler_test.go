@@ -0,0 +1,240 @@
+package list_sale_orders
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/handlers/list_sale_orders/mocks"
+)
+
+func TestHandle_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	status := document.StatusPosted
+	query := document.SaleOrderListQuery{
+		Filter: document.SaleOrderFilter{
+			DateFrom:     time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local),
+			DateTo:       time.Date(2024, 5, 31, 23, 59, 59, 0, time.Local),
+			Status:       &status,
+			CustomerID:   7,
+			NumberPrefix: "SO-",
+		},
+		Sort: document.SaleOrderSort{
+			Field: document.SaleOrderSortByNumber,
+			Desc:  true,
+		},
+		Limit:  10,
+		Cursor: "abc",
+	}
+
+	saleOrders := &document.SaleOrderList{
+		Items: []*document.SaleOrder{
+			{
+				Document: document.Document{
+					ID:     1,
+					Number: "SO-1",
+					Date:   time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC),
+					Status: document.StatusPosted,
+				},
+			},
+		},
+		NextCursor: "def",
+	}
+
+	useCaseMock.EXPECT().
+		Handle(ctx, query).
+		Return(saleOrders, nil)
+
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(
+		http.MethodGet,
+		"?date_from=2024-05-01&date_to=2024-05-31&status=posted&customer_id=7&number=SO-&sort=-number&limit=10&cursor=abc",
+		nil,
+	)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+	assert.JSONEq(
+		t,
+		`{
+			"items": [
+				{
+					"id": 1,
+					"number": "SO-1",
+					"date": "2024-05-01T10:20:30Z",
+					"status": "posted",
+					"customer": {"id": 0, "name": ""}
+				}
+			],
+			"next_cursor": "def"
+		}`,
+		response.Body.String(),
+	)
+}
+
+func TestHandle_RFC3339Dates(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	query := document.SaleOrderListQuery{
+		Filter: document.SaleOrderFilter{
+			DateFrom: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			DateTo:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	useCaseMock.EXPECT().
+		Handle(ctx, query).
+		Return(&document.SaleOrderList{}, nil)
+
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(
+		http.MethodGet,
+		"?date_from=2024-05-01T10:00:00Z&date_to=2024-05-01T12:00:00Z",
+		nil,
+	)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"items": []}`, response.Body.String())
+}
+
+func TestHandle_validateAndPrepareError(t *testing.T) {
+	tests := []struct {
+		name  string
+		url   string
+		field string
+	}{
+		{
+			name:  "bad date_from",
+			url:   "?date_from=yesterday",
+			field: "date_from",
+		},
+		{
+			name:  "bad date_to",
+			url:   "?date_to=2024-13-01",
+			field: "date_to",
+		},
+		{
+			name:  "bad status",
+			url:   "?status=archived",
+			field: "status",
+		},
+		{
+			name:  "bad customer_id",
+			url:   "?customer_id=-1",
+			field: "customer_id",
+		},
+		{
+			name:  "bad limit",
+			url:   "?limit=ten",
+			field: "limit",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+
+			useCaseMock := mocks.NewMockuseCase(ctrl)
+			handler := NewHandler(useCaseMock)
+
+			response := httptest.NewRecorder()
+			request, requestErr := http.NewRequest(http.MethodGet, tt.url, nil)
+
+			// act
+			handler.Handle(response, request)
+
+			// assert
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+			var problem struct {
+				Errors []struct {
+					Field string `json:"field"`
+				} `json:"errors"`
+			}
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &problem))
+			assert.Len(t, problem.Errors, 1)
+			assert.Equal(t, tt.field, problem.Errors[0].Field)
+		})
+	}
+}
+
+func TestHandle_UseCaseValidationError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	useCaseMock.EXPECT().
+		Handle(ctx, gomock.Any()).
+		Return(nil, domainerrors.NewErrValidation("bad sort field: customer", nil))
+
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodGet, "?sort=customer", nil)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.JSONEq(
+		t,
+		`{"type": "about:blank", "title": "Bad Request", "status": 400, "detail": "bad sort field: customer"}`,
+		response.Body.String(),
+	)
+}
+
+func TestHandle_UseCaseError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	useCaseMock.EXPECT().
+		Handle(ctx, gomock.Any()).
+		Return(nil, errors.New("list error"))
+
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodGet, "", nil)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+)
+
+func DeliveriesToDeliveryListDto(deliveries []*webhook.Delivery) DeliveryList {
+	deliveryListDTO := DeliveryList{
+		Items: make([]Delivery, 0, len(deliveries)),
+	}
+	for _, delivery := range deliveries {
+		deliveryListDTO.Items = append(deliveryListDTO.Items, DeliveryToDeliveryDto(delivery))
+	}
+	return deliveryListDTO
+}
+
+func DeliveryToDeliveryDto(delivery *webhook.Delivery) Delivery {
+	return Delivery{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.Subscription.ID,
+		URL:            delivery.Subscription.URL,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Payload:        delivery.Payload,
+		Status:         delivery.Status.String(),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt.Format(time.RFC3339),
+		LastError:      delivery.LastError,
+		ResponseStatus: delivery.ResponseStatus,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      delivery.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/event"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+)
+
+func TestDeliveriesToDeliveryListDto(t *testing.T) {
+	date := time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC)
+
+	type args struct {
+		deliveries []*webhook.Delivery
+	}
+	tests := []struct {
+		name string
+		args args
+		want DeliveryList
+	}{
+		{
+			name: "with items",
+			args: args{
+				deliveries: []*webhook.Delivery{
+					{
+						ID: 1,
+						Subscription: webhook.Subscription{
+							ID:     2,
+							URL:    "https://erp.example.com/hook",
+							Secret: "secret",
+						},
+						EventID:        3,
+						EventType:      event.TypeSaleOrderPosted,
+						Payload:        []byte(`{"id":3}`),
+						Status:         webhook.DeliveryStatusFailed,
+						Attempts:       8,
+						NextAttemptAt:  date,
+						LastError:      "unexpected response status: 500",
+						ResponseStatus: 500,
+						CreatedAt:      date,
+						UpdatedAt:      date,
+					},
+				},
+			},
+			want: DeliveryList{
+				Items: []Delivery{
+					{
+						ID:             1,
+						SubscriptionID: 2,
+						URL:            "https://erp.example.com/hook",
+						EventID:        3,
+						EventType:      "SaleOrderPosted",
+						Payload:        []byte(`{"id":3}`),
+						Status:         "failed",
+						Attempts:       8,
+						NextAttemptAt:  "2024-05-01T10:20:30Z",
+						LastError:      "unexpected response status: 500",
+						ResponseStatus: 500,
+						CreatedAt:      "2024-05-01T10:20:30Z",
+						UpdatedAt:      "2024-05-01T10:20:30Z",
+					},
+				},
+			},
+		},
+		{
+			name: "empty",
+			args: args{
+				deliveries: []*webhook.Delivery{},
+			},
+			want: DeliveryList{
+				Items: []Delivery{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DeliveriesToDeliveryListDto(tt.args.deliveries)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeliveriesToDeliveryListDto() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package list_webhook_deliveries
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/list_webhook_deliveries/dto"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/response"
+)
+
+type useCase interface {
+	Handle(ctx context.Context, status webhook.DeliveryStatus) ([]*webhook.Delivery, error)
+}
+
+type Handler struct {
+	useCase useCase
+}
+
+func NewHandler(u useCase) *Handler {
+	return &Handler{
+		useCase: u,
+	}
+}
+
+func (h *Handler) Handle(writer http.ResponseWriter, request *http.Request) {
+	status, err := h.validateAndPrepare(request)
+	if err != nil {
+		response.Error(writer, err)
+		return
+	}
+
+	deliveries, err := h.useCase.Handle(request.Context(), status)
+	if err != nil {
+		response.Error(writer, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(writer).Encode(dto.DeliveriesToDeliveryListDto(deliveries))
+}
+
+// validateAndPrepare returns the requested delivery status, failed deliveries are listed by default.
+func (h *Handler) validateAndPrepare(request *http.Request) (webhook.DeliveryStatus, error) {
+	value := request.URL.Query().Get("status")
+	if value == "" {
+		return webhook.DeliveryStatusFailed, nil
+	}
+
+	status, ok := webhook.ParseDeliveryStatus(value)
+	if !ok {
+		return 0, domainerrors.NewErrValidation(
+			"bad status",
+			nil,
+			domainerrors.FieldError{Field: "status", Message: "must be one of: pending, delivered, failed"},
+		)
+	}
+
+	return status, nil
+}
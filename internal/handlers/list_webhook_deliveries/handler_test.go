@@ -0,0 +1,107 @@
+package list_webhook_deliveries
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/handlers/list_webhook_deliveries/mocks"
+)
+
+func TestHandle_Success(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		status webhook.DeliveryStatus
+	}{
+		{
+			name:   "failed by default",
+			target: "/webhook-deliveries",
+			status: webhook.DeliveryStatusFailed,
+		},
+		{
+			name:   "status",
+			target: "/webhook-deliveries?status=pending",
+			status: webhook.DeliveryStatusPending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+
+			useCaseMock := mocks.NewMockuseCase(ctrl)
+			handler := NewHandler(useCaseMock)
+
+			deliveries := []*webhook.Delivery{
+				{
+					ID:      1,
+					Status:  tt.status,
+					Payload: []byte(`{"id":1}`),
+				},
+			}
+
+			useCaseMock.EXPECT().
+				Handle(ctx, tt.status).
+				Return(deliveries, nil)
+
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, tt.target, nil)
+
+			// act
+			handler.Handle(response, request)
+
+			// assert
+			assert.Equal(t, http.StatusOK, response.Code)
+			assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+			assert.Contains(t, response.Body.String(), `"status":"`+tt.status.String()+`"`)
+		})
+	}
+}
+
+func TestHandle_validateAndPrepareError_BadStatus(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/webhook-deliveries?status=lost", nil)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Contains(t, response.Body.String(), `"field":"status"`)
+}
+
+func TestHandle_UseCaseError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	useCaseMock.EXPECT().
+		Handle(ctx, webhook.DeliveryStatusFailed).
+		Return(nil, errors.New("some db error"))
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/webhook-deliveries", nil)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
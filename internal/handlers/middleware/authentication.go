@@ -0,0 +1,69 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/response"
+)
+
+type userRepository interface {
+	GetByAPIKeyHash(ctx context.Context, keyHash string) (*reference.User, error)
+}
+
+// Authenticator resolves bearer API keys to users.
+// Keys are stored only as hex-encoded SHA-256 hashes.
+type Authenticator struct {
+	userRepository userRepository
+}
+
+func NewAuthenticator(ur userRepository) *Authenticator {
+	return &Authenticator{
+		userRepository: ur,
+	}
+}
+
+// HashAPIKey returns the value stored in user_api_key.key_hash for the key.
+func HashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// Authenticate puts the user owning the bearer token into the request context.
+// Requests without a valid token are rejected with 401.
+func (a *Authenticator) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		user, err := a.authenticate(request)
+		if err != nil {
+			writer.Header().Set("WWW-Authenticate", "Bearer")
+			response.Error(writer, err)
+			return
+		}
+
+		next.ServeHTTP(writer, request.WithContext(access.WithUser(request.Context(), user)))
+	})
+}
+
+func (a *Authenticator) authenticate(request *http.Request) (*reference.User, error) {
+	key, ok := strings.CutPrefix(request.Header.Get("Authorization"), "Bearer ")
+	if !ok || key == "" {
+		return nil, domainerrors.NewErrUnauthorized("missing bearer token", nil)
+	}
+
+	user, err := a.userRepository.GetByAPIKeyHash(request.Context(), HashAPIKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domainerrors.NewErrUnauthorized("invalid bearer token", nil)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/access"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/handlers/middleware/mocks"
+)
+
+func TestHashAPIKey(t *testing.T) {
+	assert.Equal(t, "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b", HashAPIKey("secret"))
+}
+
+func TestAuthenticate_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+
+	userRepositoryMock := mocks.NewMockuserRepository(ctrl)
+	authenticator := NewAuthenticator(userRepositoryMock)
+
+	user := &reference.User{
+		Reference: reference.Reference{
+			ID:   1,
+			Name: "User",
+		},
+		Role: reference.RoleManager,
+	}
+
+	userRepositoryMock.EXPECT().
+		GetByAPIKeyHash(gomock.Any(), HashAPIKey("secret")).
+		Return(user, nil)
+
+	var actualUser *reference.User
+	next := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		actualUser = access.UserFromContext(request.Context())
+	})
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/sale-order", nil)
+	request.Header.Set("Authorization", "Bearer secret")
+
+	// act
+	authenticator.Authenticate(next).ServeHTTP(response, request)
+
+	// assert
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, user, actualUser)
+}
+
+func TestAuthenticate_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		user          *reference.User
+		repositoryErr error
+		expectedCode  int
+	}{
+		{
+			name:          "missing header",
+			authorization: "",
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			name:          "not a bearer token",
+			authorization: "Basic dXNlcjpwYXNz",
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			name:          "unknown key",
+			authorization: "Bearer secret",
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			name:          "repository error",
+			authorization: "Bearer secret",
+			repositoryErr: errors.New("database is closed"),
+			expectedCode:  http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+
+			userRepositoryMock := mocks.NewMockuserRepository(ctrl)
+			authenticator := NewAuthenticator(userRepositoryMock)
+
+			if tt.authorization == "Bearer secret" {
+				userRepositoryMock.EXPECT().
+					GetByAPIKeyHash(gomock.Any(), HashAPIKey("secret")).
+					Return(tt.user, tt.repositoryErr)
+			}
+
+			next := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				t.Fatal("next handler must not be called")
+			})
+
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/sale-order", nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+
+			// act
+			authenticator.Authenticate(next).ServeHTTP(response, request)
+
+			// assert
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.Equal(t, "Bearer", response.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: authentication.go
+//
+// Generated by this command:
+//
+//	mockgen -package=middleware -source=authentication.go -destination=mocks/authentication.go
+//
+
+// Package middleware is a generated GoMock package.
+package middleware
+
+import (
+	context "context"
+	reflect "reflect"
+
+	reference "github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuserRepository is a mock of userRepository interface.
+type MockuserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserRepositoryMockRecorder
+}
+
+// MockuserRepositoryMockRecorder is the mock recorder for MockuserRepository.
+type MockuserRepositoryMockRecorder struct {
+	mock *MockuserRepository
+}
+
+// NewMockuserRepository creates a new mock instance.
+func NewMockuserRepository(ctrl *gomock.Controller) *MockuserRepository {
+	mock := &MockuserRepository{ctrl: ctrl}
+	mock.recorder = &MockuserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserRepository) EXPECT() *MockuserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByAPIKeyHash mocks base method.
+func (m *MockuserRepository) GetByAPIKeyHash(ctx context.Context, keyHash string) (*reference.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAPIKeyHash", ctx, keyHash)
+	ret0, _ := ret[0].(*reference.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAPIKeyHash indicates an expected call of GetByAPIKeyHash.
+func (mr *MockuserRepositoryMockRecorder) GetByAPIKeyHash(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAPIKeyHash", reflect.TypeOf((*MockuserRepository)(nil).GetByAPIKeyHash), ctx, keyHash)
+}
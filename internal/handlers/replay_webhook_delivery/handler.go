@@ -0,0 +1,58 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package replay_webhook_delivery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/list_webhook_deliveries/dto"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/response"
+)
+
+type useCase interface {
+	Handle(ctx context.Context, id uint64) (*webhook.Delivery, error)
+}
+
+type Handler struct {
+	useCase useCase
+}
+
+func NewHandler(u useCase) *Handler {
+	return &Handler{
+		useCase: u,
+	}
+}
+
+func (h *Handler) Handle(writer http.ResponseWriter, request *http.Request) {
+	deliveryID, err := h.validateAndPrepare(request)
+	if err != nil {
+		response.Error(writer, err)
+		return
+	}
+
+	delivery, err := h.useCase.Handle(request.Context(), deliveryID)
+	if err != nil {
+		response.Error(writer, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(writer).Encode(dto.DeliveryToDeliveryDto(delivery))
+}
+
+func (h *Handler) validateAndPrepare(request *http.Request) (uint64, error) {
+	id, err := strconv.ParseInt(request.URL.Query().Get("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, domainerrors.NewErrValidation(
+			"bad id",
+			err,
+			domainerrors.FieldError{Field: "id", Message: "must be a positive integer"},
+		)
+	}
+
+	return uint64(id), nil
+}
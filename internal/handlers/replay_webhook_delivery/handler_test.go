@@ -0,0 +1,127 @@
+package replay_webhook_delivery
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/webhook"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/handlers/replay_webhook_delivery/mocks"
+)
+
+func TestHandle_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	delivery := &webhook.Delivery{
+		ID:      123,
+		Status:  webhook.DeliveryStatusPending,
+		Payload: []byte(`{"id":1}`),
+	}
+
+	useCaseMock.EXPECT().
+		Handle(ctx, delivery.ID).
+		Return(delivery, nil)
+
+	bodyReader := bytes.NewReader([]byte(``))
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPost, fmt.Sprintf("?id=%d", delivery.ID), bodyReader)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+	assert.Contains(t, response.Body.String(), `"status":"pending"`)
+	assert.Contains(t, response.Body.String(), `"payload":{"id":1}`)
+}
+
+func TestHandle_validateAndPrepareError_BadID(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	bodyReader := bytes.NewReader([]byte(``))
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPost, "?id=bad", bodyReader)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Contains(t, response.Body.String(), "bad id")
+}
+
+func TestHandle_UseCaseError(t *testing.T) {
+	tests := []struct {
+		name         string
+		useCaseErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "not found",
+			useCaseErr:   domainerrors.NewErrNotFound("webhook delivery not found: 123", nil),
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"type": "about:blank", "title": "Not Found", "status": 404, "detail": "webhook delivery not found: 123"}`,
+		},
+		{
+			name:         "conflict",
+			useCaseErr:   domainerrors.NewErrConflict("webhook delivery 123 cannot be replayed in status: delivered", nil),
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"type": "about:blank", "title": "Conflict", "status": 409, "detail": "webhook delivery 123 cannot be replayed in status: delivered"}`,
+		},
+		{
+			name:         "internal",
+			useCaseErr:   errors.New("some db error"),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type": "about:blank", "title": "Internal Server Error", "status": 500}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+
+			useCaseMock := mocks.NewMockuseCase(ctrl)
+			handler := NewHandler(useCaseMock)
+
+			var deliveryID uint64 = 123
+
+			useCaseMock.EXPECT().
+				Handle(ctx, deliveryID).
+				Return(nil, tt.useCaseErr)
+
+			bodyReader := bytes.NewReader([]byte(``))
+			response := httptest.NewRecorder()
+			request, requestErr := http.NewRequest(http.MethodPost, fmt.Sprintf("?id=%d", deliveryID), bodyReader)
+
+			// act
+			handler.Handle(response, request)
+
+			// assert
+			assert.NoError(t, requestErr)
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.JSONEq(t, tt.expectedBody, response.Body.String())
+		})
+	}
+}
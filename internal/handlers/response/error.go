@@ -0,0 +1,119 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 error response.
+type Problem struct {
+	Type   string         `json:"type"`
+	Title  string         `json:"title"`
+	Status int            `json:"status"`
+	Detail string         `json:"detail,omitempty"`
+	Errors []ProblemField `json:"errors,omitempty"`
+}
+
+// ProblemField is a validation error of a single request field.
+type ProblemField struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ErrorStatus maps domain errors to HTTP status codes, unknown errors are internal.
+func ErrorStatus(err error) int {
+	var (
+		errNotFound             *domainerrors.ErrNotFound
+		errValidation           *domainerrors.ErrValidation
+		errConflict             *domainerrors.ErrConflict
+		errVersionConflict      *domainerrors.ErrVersionConflict
+		errPreconditionRequired *domainerrors.ErrPreconditionRequired
+		errUnprocessable        *domainerrors.ErrUnprocessable
+		errUnauthorized         *domainerrors.ErrUnauthorized
+		errForbidden            *domainerrors.ErrForbidden
+		errUnavailable          *domainerrors.ErrUnavailable
+	)
+	switch {
+	case errors.As(err, &errNotFound):
+		return http.StatusNotFound
+	case errors.As(err, &errValidation):
+		return http.StatusBadRequest
+	case errors.As(err, &errConflict):
+		return http.StatusConflict
+	case errors.As(err, &errVersionConflict):
+		return http.StatusPreconditionFailed
+	case errors.As(err, &errPreconditionRequired):
+		return http.StatusPreconditionRequired
+	case errors.As(err, &errUnprocessable):
+		return http.StatusUnprocessableEntity
+	case errors.As(err, &errUnauthorized):
+		return http.StatusUnauthorized
+	case errors.As(err, &errForbidden):
+		return http.StatusForbidden
+	case errors.As(err, &errUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// NewProblem builds the problem for err.
+// Details of internal errors are not exposed to clients, the detail of domain errors is their reason
+// without the cause, which may be a driver or SQL error.
+func NewProblem(err error) Problem {
+	status := ErrorStatus(err)
+
+	problem := Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	if status == http.StatusInternalServerError {
+		return problem
+	}
+
+	problem.Detail = detail(err)
+
+	var errValidation *domainerrors.ErrValidation
+	if errors.As(err, &errValidation) {
+		for _, field := range errValidation.Fields() {
+			problem.Errors = append(problem.Errors, ProblemField{
+				Field:   field.Field,
+				Message: field.Message,
+			})
+		}
+	}
+
+	return problem
+}
+
+// detail returns the reason of the domain error in the err chain.
+func detail(err error) string {
+	var reasoner interface{ Reason() string }
+	if errors.As(err, &reasoner) {
+		return reasoner.Reason()
+	}
+	return ""
+}
+
+// Error writes err as application/problem+json with the status code of its kind.
+// Internal errors and causes hidden from the client are logged.
+func Error(writer http.ResponseWriter, err error) {
+	problem := NewProblem(err)
+	if problem.Status == http.StatusInternalServerError || problem.Detail != err.Error() {
+		log.Printf("Request error: %s", err)
+	}
+
+	writer.Header().Set("Content-Type", problemContentType)
+	writer.Header().Set("X-Content-Type-Options", "nosniff")
+	writer.WriteHeader(problem.Status)
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(problem)
+}
@@ -0,0 +1,132 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+)
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "not found",
+			err:          domainerrors.NewErrNotFound("sale order not found: 1", nil),
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"type": "about:blank", "title": "Not Found", "status": 404, "detail": "sale order not found: 1"}`,
+		},
+		{
+			name:         "validation",
+			err:          domainerrors.NewErrValidation("bad status: 9", nil),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type": "about:blank", "title": "Bad Request", "status": 400, "detail": "bad status: 9"}`,
+		},
+		{
+			name: "validation with fields",
+			err: fmt.Errorf("create order: %w", domainerrors.NewErrValidation(
+				"bad order data",
+				nil,
+				domainerrors.FieldError{Field: "customer_id", Message: "is required"},
+				domainerrors.FieldError{Field: "products[2].quantity", Message: "must be positive"},
+			)),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"detail": "bad order data",
+				"errors": [
+					{"field": "customer_id", "message": "is required"},
+					{"field": "products[2].quantity", "message": "must be positive"}
+				]
+			}`,
+		},
+		{
+			name:         "conflict",
+			err:          domainerrors.NewErrConflict("sale order was changed", nil),
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"type": "about:blank", "title": "Conflict", "status": 409, "detail": "sale order was changed"}`,
+		},
+		{
+			name:         "version conflict",
+			err:          domainerrors.NewErrVersionConflict("sale order version mismatch", nil),
+			expectedCode: http.StatusPreconditionFailed,
+			expectedBody: `{"type": "about:blank", "title": "Precondition Failed", "status": 412, "detail": "sale order version mismatch"}`,
+		},
+		{
+			name:         "precondition required",
+			err:          domainerrors.NewErrPreconditionRequired("no version", nil),
+			expectedCode: http.StatusPreconditionRequired,
+			expectedBody: `{"type": "about:blank", "title": "Precondition Required", "status": 428, "detail": "no version"}`,
+		},
+		{
+			name:         "unprocessable",
+			err:          domainerrors.NewErrUnprocessable("idempotency key is reused", nil),
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"type": "about:blank", "title": "Unprocessable Entity", "status": 422, "detail": "idempotency key is reused"}`,
+		},
+		{
+			name:         "unauthorized",
+			err:          domainerrors.NewErrUnauthorized("missing bearer token", nil),
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"type": "about:blank", "title": "Unauthorized", "status": 401, "detail": "missing bearer token"}`,
+		},
+		{
+			name:         "forbidden",
+			err:          domainerrors.NewErrForbidden("access denied", nil),
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"type": "about:blank", "title": "Forbidden", "status": 403, "detail": "access denied"}`,
+		},
+		{
+			name:         "unavailable",
+			err:          domainerrors.NewErrUnavailable("db is busy", nil),
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: `{"type": "about:blank", "title": "Service Unavailable", "status": 503, "detail": "db is busy"}`,
+		},
+		{
+			name:         "cause is hidden",
+			err:          domainerrors.NewErrUnavailable("db is busy", errors.New("database is locked")),
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: `{"type": "about:blank", "title": "Service Unavailable", "status": 503, "detail": "db is busy"}`,
+		},
+		{
+			name: "validation cause is hidden",
+			err: domainerrors.NewErrValidation(
+				"bad json",
+				errors.New("invalid character 'x' looking for beginning of value"),
+			),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type": "about:blank", "title": "Bad Request", "status": 400, "detail": "bad json"}`,
+		},
+		{
+			name:         "internal",
+			err:          errors.New("sql: connection is already closed"),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type": "about:blank", "title": "Internal Server Error", "status": 500}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			response := httptest.NewRecorder()
+
+			// act
+			Error(response, tt.err)
+
+			// assert
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.Equal(t, "application/problem+json", response.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.expectedBody, response.Body.String())
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package dto
+
+type Status struct {
+	SchemaVersion       uint `json:"schema_version"`
+	LatestSchemaVersion uint `json:"latest_schema_version"`
+	SchemaDirty         bool `json:"schema_dirty"`
+}
@@ -0,0 +1,51 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package status
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/response"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/status/dto"
+)
+
+type migrator interface {
+	Version() (version uint, dirty bool, err error)
+	LatestVersion() (uint, error)
+}
+
+// Handler reports the service status, it is served without authentication.
+type Handler struct {
+	migrator migrator
+}
+
+func NewHandler(m migrator) *Handler {
+	return &Handler{
+		migrator: m,
+	}
+}
+
+// Handle returns the current database schema version and the version of the last embedded migration.
+func (h *Handler) Handle(writer http.ResponseWriter, _ *http.Request) {
+	version, dirty, err := h.migrator.Version()
+	if err != nil {
+		// the endpoint is public, so the db error is only logged
+		log.Printf("Status error: cannot read schema version: %s", err)
+		response.Error(writer, domainerrors.NewErrUnavailable("service unavailable", nil))
+		return
+	}
+	latestVersion, err := h.migrator.LatestVersion()
+	if err != nil {
+		response.Error(writer, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(writer).Encode(dto.Status{
+		SchemaVersion:       version,
+		LatestSchemaVersion: latestVersion,
+		SchemaDirty:         dirty,
+	})
+}
@@ -0,0 +1,66 @@
+package status
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/handlers/status/mocks"
+)
+
+func TestHandle_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+
+	migratorMock := mocks.NewMockmigrator(ctrl)
+	handler := NewHandler(migratorMock)
+
+	migratorMock.EXPECT().
+		Version().
+		Return(uint(14), false, nil)
+	migratorMock.EXPECT().
+		LatestVersion().
+		Return(uint(15), nil)
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/status", nil)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"schema_version":14,"latest_schema_version":15,"schema_dirty":false}`, response.Body.String())
+}
+
+func TestHandle_VersionError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+
+	migratorMock := mocks.NewMockmigrator(ctrl)
+	handler := NewHandler(migratorMock)
+
+	migratorMock.EXPECT().
+		Version().
+		Return(uint(0), false, errors.New("database is closed"))
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/status", nil)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+	assert.JSONEq(
+		t,
+		`{"type": "about:blank", "title": "Service Unavailable", "status": 503, "detail": "service unavailable"}`,
+		response.Body.String(),
+	)
+	assert.NotContains(t, response.Body.String(), "database is closed")
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -package=status -source=handler.go -destination=mocks/handler.go
+//
+
+// Package status is a generated GoMock package.
+package status
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockmigrator is a mock of migrator interface.
+type Mockmigrator struct {
+	ctrl     *gomock.Controller
+	recorder *MockmigratorMockRecorder
+}
+
+// MockmigratorMockRecorder is the mock recorder for Mockmigrator.
+type MockmigratorMockRecorder struct {
+	mock *Mockmigrator
+}
+
+// NewMockmigrator creates a new mock instance.
+func NewMockmigrator(ctrl *gomock.Controller) *Mockmigrator {
+	mock := &Mockmigrator{ctrl: ctrl}
+	mock.recorder = &MockmigratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockmigrator) EXPECT() *MockmigratorMockRecorder {
+	return m.recorder
+}
+
+// LatestVersion mocks base method.
+func (m *Mockmigrator) LatestVersion() (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestVersion")
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestVersion indicates an expected call of LatestVersion.
+func (mr *MockmigratorMockRecorder) LatestVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestVersion", reflect.TypeOf((*Mockmigrator)(nil).LatestVersion))
+}
+
+// Version mocks base method.
+func (m *Mockmigrator) Version() (uint, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Version")
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockmigratorMockRecorder) Version() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*Mockmigrator)(nil).Version))
+}
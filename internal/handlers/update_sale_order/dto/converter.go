@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"fmt"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/money"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+)
+
+func SaleOrderDtoToSaleOrder(saleOrderDTO SaleOrder) (*document.SaleOrder, error) {
+	saleOrder := document.SaleOrder{
+		Document: document.Document{
+			ID: saleOrderDTO.ID,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID: saleOrderDTO.CustomerID,
+			},
+		},
+		Currency: money.Currency(saleOrderDTO.Currency),
+	}
+	for i, product := range saleOrderDTO.Products {
+		saleOrderProduct := document.SaleOrderProduct{
+			ID: product.ID,
+			Product: reference.Product{
+				Reference: reference.Reference{
+					ID: product.ProductID,
+				},
+			},
+			Quantity: int(product.Quantity),
+		}
+		if product.Price != nil {
+			price, err := money.Parse(product.Price.String(), saleOrder.Currency)
+			if err != nil {
+				return nil, errors.NewErrValidation("bad order data", err, errors.FieldError{
+					Field:   fmt.Sprintf("products[%d].price", i),
+					Message: err.Error(),
+				})
+			}
+			saleOrderProduct.Price = price
+			saleOrderProduct.ManualPrice = true
+		}
+		saleOrder.Products = append(saleOrder.Products, saleOrderProduct)
+	}
+	return &saleOrder, nil
+}
@@ -0,0 +1,110 @@
+package dto
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+)
+
+const (
+	defaultSaleOrderID        = 1
+	defaultCustomerID         = 2
+	defaultSaleOrderProductID = 3
+	defaultProductID          = 4
+	defaultQuantity           = 10
+)
+
+func TestSaleOrderDtoToSaleOrder(t *testing.T) {
+	type args struct {
+		saleOrderDTO SaleOrder
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *document.SaleOrder
+		wantErr bool
+	}{
+		{
+			name: "with existing and new products",
+			args: args{
+				saleOrderDTO: SaleOrder{
+					ID:         defaultSaleOrderID,
+					CustomerID: defaultCustomerID,
+					Products: []SaleOrderProduct{
+						{
+							ID:        defaultSaleOrderProductID,
+							ProductID: defaultProductID,
+							Quantity:  defaultQuantity,
+						},
+						{
+							ProductID: defaultProductID,
+							Quantity:  defaultQuantity,
+						},
+					},
+				},
+			},
+			want: &document.SaleOrder{
+				Document: document.Document{
+					ID: defaultSaleOrderID,
+				},
+				Customer: reference.Customer{
+					Reference: reference.Reference{
+						ID: defaultCustomerID,
+					},
+				},
+				Products: []document.SaleOrderProduct{
+					{
+						ID: defaultSaleOrderProductID,
+						Product: reference.Product{
+							Reference: reference.Reference{
+								ID: defaultProductID,
+							},
+						},
+						Quantity: defaultQuantity,
+					},
+					{
+						Product: reference.Product{
+							Reference: reference.Reference{
+								ID: defaultProductID,
+							},
+						},
+						Quantity: defaultQuantity,
+					},
+				},
+			},
+		},
+		{
+			name: "without products",
+			args: args{
+				saleOrderDTO: SaleOrder{
+					ID:         defaultSaleOrderID,
+					CustomerID: defaultCustomerID,
+				},
+			},
+			want: &document.SaleOrder{
+				Document: document.Document{
+					ID: defaultSaleOrderID,
+				},
+				Customer: reference.Customer{
+					Reference: reference.Reference{
+						ID: defaultCustomerID,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SaleOrderDtoToSaleOrder(tt.args.saleOrderDTO)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SaleOrderDtoToSaleOrder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SaleOrderDtoToSaleOrder() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "encoding/json"
+
+type SaleOrder struct {
+	ID         uint64             `json:"id"`
+	CustomerID uint64             `json:"customer_id"`
+	Currency   string             `json:"currency,omitempty"`
+	Products   []SaleOrderProduct `json:"products"`
+}
+
+type SaleOrderProduct struct {
+	ID        uint64       `json:"id"`
+	ProductID uint64       `json:"product_id"`
+	Quantity  uint64       `json:"quantity"`
+	Price     *json.Number `json:"price,omitempty"`
+}
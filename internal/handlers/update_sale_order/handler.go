@@ -0,0 +1,97 @@
+//go:generate mockgen -package=$GOPACKAGE -source=$GOFILE -destination=mocks/$GOFILE
+package update_sale_order
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/etag"
+	getdto "github.com/kiaplayer/clean-architecture-example/internal/handlers/get_sale_order/dto"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/response"
+	"github.com/kiaplayer/clean-architecture-example/internal/handlers/update_sale_order/dto"
+)
+
+type useCase interface {
+	Handle(context.Context, *document.SaleOrder) (*document.SaleOrder, error)
+}
+
+type Handler struct {
+	useCase useCase
+}
+
+func NewHandler(u useCase) *Handler {
+	return &Handler{
+		useCase: u,
+	}
+}
+
+func (h *Handler) validateAndPrepare(request *http.Request) (*document.SaleOrder, error) {
+	var saleOrderDTO dto.SaleOrder
+	err := json.NewDecoder(request.Body).Decode(&saleOrderDTO)
+	if err != nil {
+		return nil, domainerrors.NewErrValidation("bad json", err)
+	}
+
+	var fieldErrors []domainerrors.FieldError
+	if saleOrderDTO.ID == 0 {
+		fieldErrors = append(fieldErrors, domainerrors.FieldError{Field: "id", Message: "is required"})
+	}
+	if saleOrderDTO.CustomerID == 0 {
+		fieldErrors = append(fieldErrors, domainerrors.FieldError{Field: "customer_id", Message: "is required"})
+	}
+	if len(saleOrderDTO.Products) == 0 {
+		fieldErrors = append(fieldErrors, domainerrors.FieldError{Field: "products", Message: "must not be empty"})
+	}
+	for i, product := range saleOrderDTO.Products {
+		if product.ProductID == 0 {
+			fieldErrors = append(fieldErrors, domainerrors.FieldError{
+				Field:   fmt.Sprintf("products[%d].product_id", i),
+				Message: "is required",
+			})
+		}
+		if product.Quantity == 0 {
+			fieldErrors = append(fieldErrors, domainerrors.FieldError{
+				Field:   fmt.Sprintf("products[%d].quantity", i),
+				Message: "must be positive",
+			})
+		} else if product.Quantity > document.MaxLineQuantity {
+			fieldErrors = append(fieldErrors, domainerrors.FieldError{
+				Field:   fmt.Sprintf("products[%d].quantity", i),
+				Message: fmt.Sprintf("must be at most %d", document.MaxLineQuantity),
+			})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, domainerrors.NewErrValidation("bad order data", nil, fieldErrors...)
+	}
+
+	return dto.SaleOrderDtoToSaleOrder(saleOrderDTO)
+}
+
+func (h *Handler) Handle(writer http.ResponseWriter, request *http.Request) {
+	saleOrder, err := h.validateAndPrepare(request)
+	if err != nil {
+		response.Error(writer, err)
+		return
+	}
+	saleOrder.Version, err = etag.IfMatch(request)
+	if err != nil {
+		response.Error(writer, err)
+		return
+	}
+
+	saleOrder, err = h.useCase.Handle(request.Context(), saleOrder)
+	if err != nil {
+		response.Error(writer, err)
+		return
+	}
+
+	etag.Set(writer, saleOrder.Version)
+	writer.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(writer).Encode(getdto.SaleOrderToSaleOrderDto(saleOrder))
+}
@@ -0,0 +1,208 @@
+package update_sale_order
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/document"
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+	domainerrors "github.com/kiaplayer/clean-architecture-example/internal/domain/errors"
+	mocks "github.com/kiaplayer/clean-architecture-example/internal/handlers/update_sale_order/mocks"
+)
+
+const validRequestBody = `{"id": 1, "customer_id": 1, "products": [{"id": 10, "product_id": 1, "quantity": 2}]}`
+
+func newSaleOrder() *document.SaleOrder {
+	return &document.SaleOrder{
+		Document: document.Document{
+			ID:      1,
+			Version: 1,
+		},
+		Customer: reference.Customer{
+			Reference: reference.Reference{
+				ID: 1,
+			},
+		},
+		Products: []document.SaleOrderProduct{
+			{
+				ID: 10,
+				Product: reference.Product{
+					Reference: reference.Reference{
+						ID: 1,
+					},
+				},
+				Quantity: 2,
+			},
+		},
+	}
+}
+
+func TestHandle_Success(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	saleOrder := newSaleOrder()
+	updatedSaleOrder := newSaleOrder()
+	updatedSaleOrder.Version = 2
+
+	useCaseMock.EXPECT().
+		Handle(ctx, saleOrder).
+		Return(updatedSaleOrder, nil)
+
+	bodyReader := bytes.NewReader([]byte(validRequestBody))
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPut, "", bodyReader)
+	request.Header.Set("If-Match", `"1"`)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+	assert.Equal(t, `"2"`, response.Header().Get("ETag"))
+	assert.Contains(t, response.Body.String(), `"quantity":2`)
+}
+
+func TestHandle_NoIfMatch(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+
+	useCaseMock := mocks.NewMockuseCase(ctrl)
+	handler := NewHandler(useCaseMock)
+
+	bodyReader := bytes.NewReader([]byte(validRequestBody))
+	response := httptest.NewRecorder()
+	request, requestErr := http.NewRequest(http.MethodPut, "", bodyReader)
+
+	// act
+	handler.Handle(response, request)
+
+	// assert
+	assert.NoError(t, requestErr)
+	assert.Equal(t, http.StatusPreconditionRequired, response.Code)
+	assert.Contains(t, response.Body.String(), `"detail":"no version, If-Match header is required"`)
+}
+
+func TestHandle_validateError(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "invalid json",
+			body: `invalid_json`,
+		},
+		{
+			name: "empty request",
+			body: `{}`,
+		},
+		{
+			name: "zero id",
+			body: `{"customer_id": 1, "products": [{"product_id": 1, "quantity": 1}]}`,
+		},
+		{
+			name: "zero quantity",
+			body: `{"id": 1, "customer_id": 1, "products": [{"product_id": 1, "quantity": 0}]}`,
+		},
+		{
+			name: "overflowing quantity",
+			body: `{"id": 1, "customer_id": 1, "products": [{"product_id": 1, "quantity": 18446744073709551615}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+
+			useCaseMock := mocks.NewMockuseCase(ctrl)
+			handler := NewHandler(useCaseMock)
+
+			bodyReader := bytes.NewReader([]byte(tt.body))
+			response := httptest.NewRecorder()
+			request, requestErr := http.NewRequest(http.MethodPut, "", bodyReader)
+
+			// act
+			handler.Handle(response, request)
+
+			// assert
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+	}
+}
+
+func TestHandle_UseCaseError(t *testing.T) {
+	tests := []struct {
+		name         string
+		useCaseErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "not found",
+			useCaseErr:   domainerrors.NewErrNotFound("sale order not found: 1", nil),
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"type": "about:blank", "title": "Not Found", "status": 404, "detail": "sale order not found: 1"}`,
+		},
+		{
+			name:         "validation",
+			useCaseErr:   domainerrors.NewErrValidation("sale order cannot be edited in status: posted", nil),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type": "about:blank", "title": "Bad Request", "status": 400, "detail": "sale order cannot be edited in status: posted"}`,
+		},
+		{
+			name:         "version conflict",
+			useCaseErr:   domainerrors.NewErrVersionConflict("sale order 1 has version 2, not 1", nil),
+			expectedCode: http.StatusPreconditionFailed,
+			expectedBody: `{"type": "about:blank", "title": "Precondition Failed", "status": 412, "detail": "sale order 1 has version 2, not 1"}`,
+		},
+		{
+			name:         "internal",
+			useCaseErr:   errors.New("some db error"),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type": "about:blank", "title": "Internal Server Error", "status": 500}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+
+			useCaseMock := mocks.NewMockuseCase(ctrl)
+			handler := NewHandler(useCaseMock)
+
+			saleOrder := newSaleOrder()
+
+			useCaseMock.EXPECT().
+				Handle(ctx, saleOrder).
+				Return(nil, tt.useCaseErr)
+
+			bodyReader := bytes.NewReader([]byte(validRequestBody))
+			response := httptest.NewRecorder()
+			request, requestErr := http.NewRequest(http.MethodPut, "", bodyReader)
+			request.Header.Set("If-Match", `"1"`)
+
+			// act
+			handler.Handle(response, request)
+
+			// assert
+			assert.NoError(t, requestErr)
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.JSONEq(t, tt.expectedBody, response.Body.String())
+		})
+	}
+}
@@ -1,19 +1,71 @@
 package generators
 
 import (
+	"bytes"
+	"context"
 	"fmt"
-	"math/rand"
+	"text/template"
 	"time"
 
 	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
 )
 
-type NumberGenerator struct{}
+// DefaultNumberFormat produces numbers like SO-2026-000123.
+const DefaultNumberFormat = `SO-{{.Year}}-{{printf "%06d" .Seq}}`
 
-func NewNumberGenerator() *NumberGenerator {
-	return &NumberGenerator{}
+type counter interface {
+	Next(ctx context.Context, documentType string, companyID uint64, year int) (uint64, error)
 }
 
-func (n *NumberGenerator) GenerateNumber(date time.Time, company reference.Company) string {
-	return fmt.Sprintf("%s-%d-%d", date.Format("20060102"), company.ID, rand.Intn(10000))
+// NumberData is passed to the number format template.
+type NumberData struct {
+	Year      int
+	Date      time.Time
+	CompanyID uint64
+	Seq       uint64
+}
+
+// NumberGenerator generates sequential numbers per company and year for one document type.
+type NumberGenerator struct {
+	counter      counter
+	documentType string
+	format       *template.Template
+}
+
+func NewNumberGenerator(c counter, documentType string, format string) (*NumberGenerator, error) {
+	tmpl, err := template.New(documentType).Option("missingkey=error").Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("bad number format: %w", err)
+	}
+
+	err = tmpl.Execute(&bytes.Buffer{}, NumberData{})
+	if err != nil {
+		return nil, fmt.Errorf("bad number format: %w", err)
+	}
+
+	return &NumberGenerator{
+		counter:      c,
+		documentType: documentType,
+		format:       tmpl,
+	}, nil
+}
+
+func (n *NumberGenerator) GenerateNumber(ctx context.Context, date time.Time, company reference.Company) (string, error) {
+	seq, err := n.counter.Next(ctx, n.documentType, company.ID, date.Year())
+	if err != nil {
+		return "", err
+	}
+
+	var number bytes.Buffer
+	err = n.format.Execute(&number, NumberData{
+		Year:      date.Year(),
+		Date:      date,
+		CompanyID: company.ID,
+		Seq:       seq,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return number.String(), nil
 }
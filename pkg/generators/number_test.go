@@ -0,0 +1,109 @@
+package generators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiaplayer/clean-architecture-example/internal/domain/entity/reference"
+)
+
+type counterStub struct {
+	values map[string]uint64
+	err    error
+}
+
+func (c *counterStub) Next(_ context.Context, documentType string, companyID uint64, year int) (uint64, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	key := fmt.Sprintf("%s/%d/%d", documentType, companyID, year)
+	c.values[key]++
+	return c.values[key], nil
+}
+
+func TestGenerateNumber_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	generator, err := NewNumberGenerator(&counterStub{values: map[string]uint64{}}, "sale_order", DefaultNumberFormat)
+	assert.NoError(t, err)
+
+	date := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	company := reference.Company{Reference: reference.Reference{ID: 1}}
+	otherCompany := reference.Company{Reference: reference.Reference{ID: 2}}
+
+	// act
+	first, firstErr := generator.GenerateNumber(ctx, date, company)
+	second, secondErr := generator.GenerateNumber(ctx, date, company)
+	other, otherErr := generator.GenerateNumber(ctx, date, otherCompany)
+
+	// assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.NoError(t, otherErr)
+	assert.Equal(t, "SO-2026-000001", first)
+	assert.Equal(t, "SO-2026-000002", second)
+	assert.Equal(t, "SO-2026-000001", other)
+}
+
+func TestGenerateNumber_CustomFormat(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	generator, err := NewNumberGenerator(
+		&counterStub{values: map[string]uint64{}},
+		"sale_order",
+		`{{.CompanyID}}/{{.Date.Format "0102"}}/{{.Seq}}`,
+	)
+	assert.NoError(t, err)
+
+	date := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	company := reference.Company{Reference: reference.Reference{ID: 7}}
+
+	// act
+	actual, actualErr := generator.GenerateNumber(ctx, date, company)
+
+	// assert
+	assert.NoError(t, actualErr)
+	assert.Equal(t, "7/0301/1", actual)
+}
+
+func TestGenerateNumber_CounterError(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	counterErr := errors.New("counter error")
+
+	generator, err := NewNumberGenerator(&counterStub{err: counterErr}, "sale_order", DefaultNumberFormat)
+	assert.NoError(t, err)
+
+	// act
+	actual, actualErr := generator.GenerateNumber(ctx, time.Now(), reference.Company{})
+
+	// assert
+	assert.Empty(t, actual)
+	assert.ErrorIs(t, actualErr, counterErr)
+}
+
+func TestNewNumberGenerator_BadFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+	}{
+		{name: "syntax error", format: "SO-{{.Year"},
+		{name: "unknown field", format: "SO-{{.Month}}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator, err := NewNumberGenerator(&counterStub{}, "sale_order", tt.format)
+
+			assert.Nil(t, generator)
+			assert.ErrorContains(t, err, "bad number format")
+		})
+	}
+}
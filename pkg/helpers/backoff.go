@@ -0,0 +1,13 @@
+package helpers
+
+import "time"
+
+// Backoff returns the delay before the next attempt after the given number of failed attempts:
+// the base delay doubled for every previous attempt, but not more than maxDelay.
+func Backoff(base time.Duration, maxDelay time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 0; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
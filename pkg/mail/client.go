@@ -0,0 +1,114 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// DefaultTimeout limits sending of a message when Config.Timeout is not set.
+const DefaultTimeout = 30 * time.Second
+
+type Config struct {
+	// Addr is the SMTP server address as host:port.
+	Addr string
+	// Username and Password are used for PLAIN authentication if Username is set.
+	Username string
+	Password string
+	// Timeout limits sending of a single message including the connection, DefaultTimeout if zero.
+	Timeout time.Duration
+}
+
+// Client sends messages through the SMTP server, a new connection is opened for every message.
+type Client struct {
+	config Config
+}
+
+func NewClient(config Config) *Client {
+	return &Client{
+		config: config,
+	}
+}
+
+func (c *Client) Send(ctx context.Context, msg Message) error {
+	data, err := msg.Bytes(time.Now())
+	if err != nil {
+		return err
+	}
+
+	host, _, err := net.SplitHostPort(c.config.Addr)
+	if err != nil {
+		return err
+	}
+
+	timeout := c.config.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.config.Addr)
+	if err != nil {
+		return err
+	}
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer func(client *smtp.Client) {
+		_ = client.Close()
+	}(client)
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err = client.StartTLS(&tls.Config{ServerName: host})
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.config.Username != "" {
+		err = client.Auth(smtp.PlainAuth("", c.config.Username, c.config.Password, host))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.Mail(msg.From)
+	if err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		err = client.Rcpt(to)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(data)
+	if err != nil {
+		return err
+	}
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,101 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiaplayer/clean-architecture-example/pkg/mail/mailtest"
+)
+
+func TestSend_Success(t *testing.T) {
+	// arrange
+	server := mailtest.NewServer()
+	defer server.Close()
+
+	client := NewClient(Config{Addr: server.Addr})
+
+	msg := Message{
+		From:    "shop@example.com",
+		To:      []string{"customer@example.com", "manager@example.com"},
+		Subject: "Заказ 0001",
+		Text:    "Order 0001 is created",
+		HTML:    "<p>Order <b>0001</b> is created</p>",
+	}
+
+	// act
+	err := client.Send(context.Background(), msg)
+
+	// assert
+	assert.NoError(t, err)
+	messages := server.Messages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "shop@example.com", messages[0].From)
+	assert.Equal(t, []string{"customer@example.com", "manager@example.com"}, messages[0].To)
+	assert.Contains(t, messages[0].Data, "Subject: =?utf-8?q?")
+	assert.Contains(t, messages[0].Data, "Content-Type: multipart/alternative")
+	assert.Contains(t, messages[0].Data, "Order 0001 is created")
+	assert.Contains(t, messages[0].Data, "<p>Order <b>0001</b> is created</p>")
+}
+
+func TestSend_RecipientRejected(t *testing.T) {
+	// arrange
+	server := mailtest.NewServer()
+	defer server.Close()
+	server.RejectRcpt = true
+
+	client := NewClient(Config{Addr: server.Addr})
+
+	// act
+	err := client.Send(context.Background(), Message{From: "shop@example.com", To: []string{"nobody@example.com"}})
+
+	// assert
+	assert.ErrorContains(t, err, "550")
+	assert.Empty(t, server.Messages())
+}
+
+func TestSend_ServerUnavailable(t *testing.T) {
+	// arrange
+	server := mailtest.NewServer()
+	server.Close()
+
+	client := NewClient(Config{Addr: server.Addr})
+
+	// act
+	err := client.Send(context.Background(), Message{From: "shop@example.com", To: []string{"customer@example.com"}})
+
+	// assert
+	assert.Error(t, err)
+}
+
+func TestSend_Timeout(t *testing.T) {
+	// arrange
+	listener, listenErr := net.Listen("tcp", "127.0.0.1:0")
+	defer func() {
+		_ = listener.Close()
+	}()
+	// the server accepts the connection and never sends the greeting
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer func() {
+				_ = conn.Close()
+			}()
+			_, _ = conn.Read(make([]byte, 1))
+		}
+	}()
+
+	client := NewClient(Config{Addr: listener.Addr().String(), Timeout: 50 * time.Millisecond})
+
+	// act
+	err := client.Send(context.Background(), Message{From: "shop@example.com", To: []string{"customer@example.com"}})
+
+	// assert
+	assert.NoError(t, listenErr)
+	var netErr net.Error
+	assert.True(t, errors.As(err, &netErr) && netErr.Timeout())
+}
@@ -0,0 +1,150 @@
+// Package mailtest provides an in-process SMTP server for tests.
+package mailtest
+
+import (
+	"io"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// Message is a message accepted by the server.
+type Message struct {
+	From string
+	To   []string
+	Data string
+}
+
+// Server is a minimal SMTP server which keeps accepted messages in memory.
+// It supports neither TLS nor authentication.
+type Server struct {
+	// Addr is the server address as host:port.
+	Addr string
+	// RejectRcpt makes the server reject every recipient.
+	RejectRcpt bool
+
+	listener net.Listener
+	mu       sync.Mutex
+	messages []Message
+	wg       sync.WaitGroup
+}
+
+// NewServer starts the server on a random local port.
+func NewServer() *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic("mailtest: failed to listen: " + err.Error())
+	}
+
+	s := &Server{
+		Addr:     listener.Addr().String(),
+		listener: listener,
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	return s
+}
+
+// Messages returns messages accepted so far.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// Close stops the server and waits for open sessions to finish.
+func (s *Server) Close() {
+	_ = s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(textproto.NewConn(conn))
+		}()
+	}
+}
+
+func (s *Server) handle(conn *textproto.Conn) {
+	defer func(conn *textproto.Conn) {
+		_ = conn.Close()
+	}(conn)
+
+	var msg Message
+
+	reply := func(line string) bool {
+		return conn.PrintfLine("%s", line) == nil
+	}
+
+	if !reply("220 localhost ESMTP mailtest") {
+		return
+	}
+
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		command, arg, _ := strings.Cut(line, " ")
+
+		var ok bool
+		switch strings.ToUpper(command) {
+		case "EHLO", "HELO":
+			ok = reply("250 localhost")
+		case "MAIL":
+			msg = Message{From: address(arg)}
+			ok = reply("250 OK")
+		case "RCPT":
+			if s.RejectRcpt {
+				ok = reply("550 mailbox unavailable")
+				break
+			}
+			msg.To = append(msg.To, address(arg))
+			ok = reply("250 OK")
+		case "DATA":
+			if !reply("354 end data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			data, err := io.ReadAll(conn.DotReader())
+			if err != nil {
+				return
+			}
+			msg.Data = string(data)
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			ok = reply("250 OK")
+		case "RSET":
+			msg = Message{}
+			ok = reply("250 OK")
+		case "NOOP":
+			ok = reply("250 OK")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			ok = reply("502 command not implemented")
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+// address extracts the address from "FROM:<address>" and "TO:<address>" arguments.
+func address(arg string) string {
+	_, value, _ := strings.Cut(arg, ":")
+	value, _, _ = strings.Cut(value, " ")
+	return strings.Trim(value, "<>")
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/kiaplayer/clean-architecture-example/pkg/helpers"
+)
+
+// serializationFailureStates are SQLSTATE codes of transactions aborted by a concurrent one:
+// serialization_failure and deadlock_detected.
+var serializationFailureStates = []string{"40001", "40P01"}
+
+// IsRetryableError tells whether the transaction failed because of concurrent transactions
+// and may succeed when run again: SQLite busy and locked errors and serialization failures of drivers
+// which report SQLSTATE codes.
+func IsRetryableError(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+
+	var stateErr interface{ SQLState() string }
+	if errors.As(err, &stateErr) {
+		state := stateErr.SQLState()
+		for _, serializationFailureState := range serializationFailureStates {
+			if state == serializationFailureState {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// jitteredBackoff returns a random delay between a half and the whole backoff delay after the given number
+// of failed attempts, so concurrent transactions failed together do not retry at the same moment.
+func jitteredBackoff(base time.Duration, maxDelay time.Duration, attempts int) time.Duration {
+	delay := helpers.Backoff(base, maxDelay, attempts)
+	if delay <= 1 {
+		return delay
+	}
+	return delay/2 + rand.N(delay/2)
+}
@@ -3,15 +3,123 @@ package db
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
 )
 
+// TxRunner runs callbacks in transactions, it is implemented by Transactor.
+// Consumers use it through RunInTx to get typed results.
+type TxRunner interface {
+	RunInTx(ctx context.Context, fn func(ctx context.Context) (any, error), opts ...TxOption) (any, error)
+}
+
+// RunInTx runs fn in a transaction of runner like Transactor.RunInTx does and returns the result of fn
+// with its type, so callers need no type assertions.
+func RunInTx[T any](
+	ctx context.Context,
+	runner TxRunner,
+	fn func(ctx context.Context) (T, error),
+	opts ...TxOption,
+) (T, error) {
+	var zero T
+
+	result, err := runner.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		return fn(ctx)
+	}, opts...)
+	if err != nil || result == nil {
+		return zero, err
+	}
+
+	typed, ok := result.(T)
+	if !ok {
+		return zero, fmt.Errorf("unexpected transaction result type: %T", result)
+	}
+
+	return typed, nil
+}
+
+type TransactorConfig struct {
+	// MaxAttempts is the number of attempts of a transaction failing with retryable errors, 1 disables retries.
+	MaxAttempts int
+	// RetryDelay is the delay after the first failed attempt, it doubles after every next one up to MaxRetryDelay.
+	// The actual delay is a random one between a half and the whole of it.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+	// IsRetryable tells whether a failed transaction is run again, IsRetryableError is used if it is nil.
+	IsRetryable func(err error) bool
+	// OnAttempt is called after every attempt of a top level transaction with the attempt number starting from 1
+	// and its error, which is nil if the transaction is committed.
+	OnAttempt func(ctx context.Context, attempt int, err error)
+}
+
+var DefaultTransactorConfig = TransactorConfig{
+	MaxAttempts:   5,
+	RetryDelay:    10 * time.Millisecond,
+	MaxRetryDelay: 500 * time.Millisecond,
+}
+
 type Transactor struct {
-	db *sql.DB
+	db         *sql.DB
+	config     TransactorConfig
+	savepoints atomic.Uint64
+	// sqlite is set for SQLite databases, their driver ignores sql.TxOptions, so the transactor enforces them.
+	sqlite bool
 }
 
-func NewTransactor(db *sql.DB) *Transactor {
+func NewTransactor(db *sql.DB, config TransactorConfig) *Transactor {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+	if config.IsRetryable == nil {
+		config.IsRetryable = IsRetryableError
+	}
+
+	_, sqlite := db.Driver().(*sqlite3.SQLiteDriver)
+
 	return &Transactor{
-		db: db,
+		db:     db,
+		config: config,
+		sqlite: sqlite,
+	}
+}
+
+type txConfig struct {
+	savepoint bool
+	txOptions *sql.TxOptions
+}
+
+// TxOption changes how RunInTx runs the callback.
+type TxOption func(config *txConfig)
+
+// WithSavepoint makes a nested RunInTx call run the callback in a savepoint of the outer transaction,
+// so an error of the callback rolls back only the changes made by it. Top level calls are not affected.
+func WithSavepoint() TxOption {
+	return func(config *txConfig) {
+		config.savepoint = true
+	}
+}
+
+// WithTxOptions sets the isolation level and the read-only mode of a top level transaction.
+// Nested calls join the outer transaction as it is.
+// SQLite transactions are always serializable, so any level up to sql.LevelSerializable is honoured
+// and stronger ones are rejected, the read-only mode is enforced with PRAGMA query_only.
+func WithTxOptions(opts sql.TxOptions) TxOption {
+	return func(config *txConfig) {
+		config.txOptions = &opts
+	}
+}
+
+// ReadOnly starts a read-only top level transaction.
+func ReadOnly() TxOption {
+	return func(config *txConfig) {
+		if config.txOptions == nil {
+			config.txOptions = &sql.TxOptions{}
+		}
+		config.txOptions.ReadOnly = true
 	}
 }
 
@@ -28,25 +136,146 @@ func extractTx(ctx context.Context) *sql.Tx {
 	return nil
 }
 
-func (t *Transactor) RunInTx(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
-	tx, err := t.db.BeginTx(ctx, nil)
+// RunInTx runs fn in a transaction which is committed when fn succeeds and rolled back otherwise.
+// When the transaction fails with a retryable error, it is rolled back and fn is run again in a new one,
+// so fn must not have side effects outside of the database.
+// If ctx already carries a transaction, fn joins it: the outer call decides whether it is committed or retried,
+// and an error of fn is returned to the outer callback as is, unless WithSavepoint is used.
+func (t *Transactor) RunInTx(
+	ctx context.Context,
+	fn func(ctx context.Context) (any, error),
+	opts ...TxOption,
+) (any, error) {
+	var config txConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if tx := extractTx(ctx); tx != nil {
+		if config.savepoint {
+			return t.runInSavepoint(ctx, tx, fn)
+		}
+		return fn(ctx)
+	}
+
+	for attempt := 1; ; attempt++ {
+		result, err := t.runInNewTx(ctx, fn, config.txOptions)
+		if t.config.OnAttempt != nil {
+			t.config.OnAttempt(ctx, attempt, err)
+		}
+		if err == nil || attempt >= t.config.MaxAttempts || !t.config.IsRetryable(err) {
+			return result, err
+		}
+
+		timer := time.NewTimer(jitteredBackoff(t.config.RetryDelay, t.config.MaxRetryDelay, attempt-1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// runInNewTx runs fn in a new transaction.
+func (t *Transactor) runInNewTx(
+	ctx context.Context,
+	fn func(ctx context.Context) (any, error),
+	txOptions *sql.TxOptions,
+) (any, error) {
+	var queryOnly bool
+	if t.sqlite && txOptions != nil {
+		if txOptions.Isolation > sql.LevelSerializable {
+			return nil, fmt.Errorf("isolation level %s is not supported by SQLite", txOptions.Isolation)
+		}
+		queryOnly = txOptions.ReadOnly
+	}
+
+	// the transaction runs on a dedicated connection, so the read-only mode can be reset
+	// on it after the transaction has ended, even if ctx is cancelled
+	conn, err := t.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func(conn *sql.Conn) {
+		_ = conn.Close()
+	}(conn)
+
+	tx, err := conn.BeginTx(ctx, txOptions)
 	if err != nil {
 		return nil, err
 	}
 
 	var done bool
 
+	if queryOnly {
+		defer resetQueryOnly(ctx, conn)
+	}
 	defer func() {
 		if !done {
 			_ = tx.Rollback()
 		}
 	}()
 
+	if queryOnly {
+		_, err = tx.ExecContext(ctx, "PRAGMA query_only = ON")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	result, err := fn(injectTx(ctx, tx))
 	if err != nil {
 		return nil, err
 	}
 
 	done = true
-	return result, tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// resetQueryOnly turns the read-only mode of the connection off before it goes back to the pool,
+// the connection is discarded if that fails.
+func resetQueryOnly(ctx context.Context, conn *sql.Conn) {
+	_, err := conn.ExecContext(context.WithoutCancel(ctx), "PRAGMA query_only = OFF")
+	if err != nil {
+		_ = conn.Raw(func(any) error {
+			return driver.ErrBadConn
+		})
+	}
+}
+
+// runInSavepoint runs fn in a new savepoint of tx, the savepoint is rolled back when fn fails.
+func (t *Transactor) runInSavepoint(
+	ctx context.Context,
+	tx *sql.Tx,
+	fn func(ctx context.Context) (any, error),
+) (any, error) {
+	name := fmt.Sprintf("sp_%d", t.savepoints.Add(1))
+
+	_, err := tx.ExecContext(ctx, "SAVEPOINT "+name)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := fn(ctx)
+	if err != nil {
+		_, rollbackErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("%w (rollback to savepoint: %v)", err, rollbackErr)
+		}
+		_, _ = tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
@@ -0,0 +1,285 @@
+//go:build integration
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+)
+
+const testDBFilePath = "sqlite_test.db"
+
+type TestTransactorSuite struct {
+	suite.Suite
+	db *sql.DB
+}
+
+func TestTransactorByTestSuite(t *testing.T) {
+	suite.Run(t, new(TestTransactorSuite))
+}
+
+func (ts *TestTransactorSuite) SetupSuite() {
+	_ = os.Remove(testDBFilePath)
+
+	// no busy timeout, so a locked database fails the transaction right away
+	dbConn, err := sql.Open("sqlite3", testDBFilePath+"?_busy_timeout=0")
+	if err != nil {
+		ts.Failf("cannot open db connection before tests: %s", err.Error())
+	}
+
+	_, err = dbConn.Exec("CREATE TABLE item (name TEXT NOT NULL)")
+	if err != nil {
+		ts.Failf("cannot create table before tests: %s", err.Error())
+	}
+
+	ts.db = dbConn
+}
+
+func (ts *TestTransactorSuite) TearDownSuite() {
+	err := ts.db.Close()
+	if err != nil {
+		ts.Failf("tear down suite: %s", err.Error())
+	}
+	_ = os.Remove(testDBFilePath)
+}
+
+func (ts *TestTransactorSuite) SetupTest() {
+	_, err := ts.db.Exec("DELETE FROM item")
+	ts.NoError(err)
+}
+
+func (ts *TestTransactorSuite) insert(ctx context.Context, repository *TransactionalRepository, name string) error {
+	_, err := repository.DB(ctx).ExecContext(ctx, "INSERT INTO item (name) VALUES (?)", name)
+	return err
+}
+
+func (ts *TestTransactorSuite) names() []string {
+	rows, err := ts.db.Query("SELECT name FROM item ORDER BY name")
+	ts.NoError(err)
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		ts.NoError(rows.Scan(&name))
+		names = append(names, name)
+	}
+	return names
+}
+
+func (ts *TestTransactorSuite) TestNestedCallsShareTransaction() {
+	// arrange
+	ctx := context.Background()
+	transactor := NewTransactor(ts.db, DefaultTransactorConfig)
+	repository := NewTransactionalRepository(ts.db)
+	fnErr := errors.New("outer error")
+
+	// act
+	_, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		_, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+			return nil, ts.insert(ctx, repository, "inner")
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, fnErr
+	})
+
+	// assert
+	ts.ErrorIs(err, fnErr)
+	ts.Empty(ts.names())
+}
+
+func (ts *TestTransactorSuite) TestSavepointRollsBackInnerChanges() {
+	// arrange
+	ctx := context.Background()
+	transactor := NewTransactor(ts.db, DefaultTransactorConfig)
+	repository := NewTransactionalRepository(ts.db)
+	innerErr := errors.New("inner error")
+
+	// act
+	_, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		err := ts.insert(ctx, repository, "outer")
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+			err := ts.insert(ctx, repository, "inner-failed")
+			if err != nil {
+				return nil, err
+			}
+			return nil, innerErr
+		}, WithSavepoint())
+		ts.ErrorIs(err, innerErr)
+
+		return transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+			return nil, ts.insert(ctx, repository, "inner-saved")
+		}, WithSavepoint())
+	})
+
+	// assert
+	ts.NoError(err)
+	ts.Equal([]string{"inner-saved", "outer"}, ts.names())
+}
+
+func (ts *TestTransactorSuite) TestNestedSavepoints() {
+	// arrange
+	ctx := context.Background()
+	transactor := NewTransactor(ts.db, DefaultTransactorConfig)
+	repository := NewTransactionalRepository(ts.db)
+	innerErr := errors.New("inner error")
+
+	// act
+	_, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		return transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+			err := ts.insert(ctx, repository, "level-1")
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+				err := ts.insert(ctx, repository, "level-2")
+				if err != nil {
+					return nil, err
+				}
+				return nil, innerErr
+			}, WithSavepoint())
+			ts.ErrorIs(err, innerErr)
+
+			return nil, nil
+		}, WithSavepoint())
+	})
+
+	// assert
+	ts.NoError(err)
+	ts.Equal([]string{"level-1"}, ts.names())
+}
+
+func (ts *TestTransactorSuite) TestRetryWhenDatabaseIsLocked() {
+	// arrange
+	ctx := context.Background()
+	repository := NewTransactionalRepository(ts.db)
+
+	lockingTx, err := ts.db.BeginTx(ctx, nil)
+	ts.NoError(err)
+	_, err = lockingTx.ExecContext(ctx, "INSERT INTO item (name) VALUES ('locking')")
+	ts.NoError(err)
+
+	var attemptErrs []error
+	transactor := NewTransactor(ts.db, TransactorConfig{
+		MaxAttempts:   3,
+		RetryDelay:    time.Millisecond,
+		MaxRetryDelay: time.Millisecond,
+		OnAttempt: func(ctx context.Context, attempt int, err error) {
+			attemptErrs = append(attemptErrs, err)
+			if attempt == 1 {
+				ts.NoError(lockingTx.Commit())
+			}
+		},
+	})
+
+	// act
+	_, err = transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		return nil, ts.insert(ctx, repository, "retried")
+	})
+
+	// assert
+	ts.NoError(err)
+	ts.Len(attemptErrs, 2)
+	ts.True(IsRetryableError(attemptErrs[0]))
+	ts.NoError(attemptErrs[1])
+	ts.Equal([]string{"locking", "retried"}, ts.names())
+}
+
+func (ts *TestTransactorSuite) TestReadOnlyRejectsWrites() {
+	// arrange
+	ctx := context.Background()
+	repository := NewTransactionalRepository(ts.db)
+	transactor := NewTransactor(ts.db, DefaultTransactorConfig)
+
+	// act
+	_, readOnlyErr := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		return nil, ts.insert(ctx, repository, "read-only")
+	}, ReadOnly())
+	names, readErr := RunInTx(ctx, transactor, func(ctx context.Context) ([]string, error) {
+		return ts.names(), nil
+	}, ReadOnly())
+
+	// assert
+	ts.ErrorContains(readOnlyErr, "attempt to write a readonly database")
+	ts.NoError(readErr)
+	ts.Empty(names)
+}
+
+func (ts *TestTransactorSuite) TestReadOnlyModeIsResetAfterTransaction() {
+	// arrange
+	ctx := context.Background()
+	repository := NewTransactionalRepository(ts.db)
+	transactor := NewTransactor(ts.db, DefaultTransactorConfig)
+
+	// a single connection, so the next transaction runs on the one the read-only transaction has used
+	ts.db.SetMaxOpenConns(1)
+	defer ts.db.SetMaxOpenConns(0)
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+
+	// act
+	_, readOnlyErr := transactor.RunInTx(cancelledCtx, func(ctx context.Context) (any, error) {
+		cancel()
+		return nil, ctx.Err()
+	}, ReadOnly())
+	_, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		return nil, ts.insert(ctx, repository, "read-write")
+	})
+
+	// assert
+	ts.ErrorIs(readOnlyErr, context.Canceled)
+	ts.NoError(err)
+	ts.Equal([]string{"read-write"}, ts.names())
+}
+
+func (ts *TestTransactorSuite) TestIsolationLevels() {
+	tests := []struct {
+		isolation   sql.IsolationLevel
+		expectedErr string
+	}{
+		{isolation: sql.LevelDefault},
+		{isolation: sql.LevelReadCommitted},
+		{isolation: sql.LevelSerializable},
+		{isolation: sql.LevelLinearizable, expectedErr: "isolation level Linearizable is not supported by SQLite"},
+	}
+
+	for _, tt := range tests {
+		ts.Run(tt.isolation.String(), func() {
+			// arrange
+			ctx := context.Background()
+			transactor := NewTransactor(ts.db, DefaultTransactorConfig)
+			called := false
+
+			// act
+			_, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+				called = true
+				return nil, nil
+			}, WithTxOptions(sql.TxOptions{Isolation: tt.isolation}))
+
+			// assert
+			if tt.expectedErr != "" {
+				ts.EqualError(err, tt.expectedErr)
+				ts.False(called)
+				return
+			}
+			ts.NoError(err)
+			ts.True(called)
+		})
+	}
+}
@@ -0,0 +1,416 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunInTx_Commit(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	transactor := NewTransactor(db, DefaultTransactorConfig)
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	// act
+	result, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		return 1, nil
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunInTx_Rollback(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	transactor := NewTransactor(db, DefaultTransactorConfig)
+
+	expectedErr := errors.New("fn error")
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	// act
+	result, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		return nil, expectedErr
+	})
+
+	// assert
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Nil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunInTx_NestedJoinsOuterTx(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	transactor := NewTransactor(db, DefaultTransactorConfig)
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	// act
+	result, err := transactor.RunInTx(ctx, func(outerCtx context.Context) (any, error) {
+		return transactor.RunInTx(outerCtx, func(innerCtx context.Context) (any, error) {
+			assert.Same(t, extractTx(outerCtx), extractTx(innerCtx))
+			return 2, nil
+		})
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunInTx_SavepointRollback(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	transactor := NewTransactor(db, DefaultTransactorConfig)
+
+	innerErr := errors.New("inner error")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	// act
+	result, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		_, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+			return nil, innerErr
+		}, WithSavepoint())
+		assert.ErrorIs(t, err, innerErr)
+		return 3, nil
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunInTx_SavepointRelease(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	transactor := NewTransactor(db, DefaultTransactorConfig)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	// act
+	result, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		return transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+			return 4, nil
+		}, WithSavepoint())
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunInTx_RetryRetryableError(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	var attempts []error
+	transactor := NewTransactor(db, TransactorConfig{
+		MaxAttempts: 3,
+		OnAttempt: func(ctx context.Context, attempt int, err error) {
+			attempts = append(attempts, err)
+		},
+	})
+
+	busyErr := sqlite3.Error{Code: sqlite3.ErrBusy}
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	calls := 0
+
+	// act
+	result, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		calls++
+		if calls == 1 {
+			return nil, busyErr
+		}
+		return calls, nil
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result)
+	assert.Equal(t, []error{busyErr, nil}, attempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunInTx_RetryLimit(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	transactor := NewTransactor(db, TransactorConfig{MaxAttempts: 2})
+
+	lockedErr := sqlite3.Error{Code: sqlite3.ErrLocked}
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	calls := 0
+
+	// act
+	result, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		calls++
+		return nil, lockedErr
+	})
+
+	// assert
+	assert.ErrorIs(t, err, lockedErr)
+	assert.Nil(t, result)
+	assert.Equal(t, 2, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunInTx_NoRetryOfOtherErrors(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	transactor := NewTransactor(db, TransactorConfig{MaxAttempts: 3})
+
+	expectedErr := errors.New("fn error")
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	calls := 0
+
+	// act
+	_, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		calls++
+		return nil, expectedErr
+	})
+
+	// assert
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunInTx_NoRetryOfNestedCalls(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	transactor := NewTransactor(db, TransactorConfig{MaxAttempts: 3})
+
+	busyErr := sqlite3.Error{Code: sqlite3.ErrBusy}
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	innerCalls := 0
+
+	// act
+	_, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		return transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+			innerCalls++
+			if innerCalls == 1 {
+				return nil, busyErr
+			}
+			return nil, nil
+		})
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, innerCalls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunInTx_TxOptions(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	transactor := NewTransactor(db, DefaultTransactorConfig)
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	// act
+	_, err := transactor.RunInTx(ctx, func(ctx context.Context) (any, error) {
+		return nil, nil
+	}, WithTxOptions(sql.TxOptions{Isolation: sql.LevelSerializable}), ReadOnly())
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+type stateError string
+
+func (e stateError) Error() string {
+	return "state " + string(e)
+}
+
+func (e stateError) SQLState() string {
+	return string(e)
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "sqlite busy", err: sqlite3.Error{Code: sqlite3.ErrBusy}, expected: true},
+		{name: "sqlite locked", err: sqlite3.Error{Code: sqlite3.ErrLocked}, expected: true},
+		{name: "wrapped sqlite busy", err: fmt.Errorf("insert: %w", sqlite3.Error{Code: sqlite3.ErrBusy}), expected: true},
+		{name: "sqlite constraint", err: sqlite3.Error{Code: sqlite3.ErrConstraint}, expected: false},
+		{name: "serialization failure", err: stateError("40001"), expected: true},
+		{name: "deadlock", err: stateError("40P01"), expected: true},
+		{name: "unique violation", err: stateError("23505"), expected: false},
+		{name: "other", err: errors.New("database is down"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			actual := IsRetryableError(tt.err)
+
+			// assert
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+type txRunnerFunc func(ctx context.Context, fn func(ctx context.Context) (any, error), opts ...TxOption) (any, error)
+
+func (f txRunnerFunc) RunInTx(
+	ctx context.Context,
+	fn func(ctx context.Context) (any, error),
+	opts ...TxOption,
+) (any, error) {
+	return f(ctx, fn, opts...)
+}
+
+func TestRunInTxGeneric_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	db, mock, _ := sqlmock.New()
+
+	transactor := NewTransactor(db, DefaultTransactorConfig)
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	// act
+	result, err := RunInTx(ctx, transactor, func(ctx context.Context) (*TransactorConfig, error) {
+		return &TransactorConfig{MaxAttempts: 7}, nil
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 7, result.MaxAttempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunInTxGeneric_Error(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	expectedErr := errors.New("fn error")
+	runner := txRunnerFunc(func(ctx context.Context, fn func(ctx context.Context) (any, error), _ ...TxOption) (any, error) {
+		return fn(ctx)
+	})
+
+	// act
+	result, err := RunInTx(ctx, runner, func(ctx context.Context) (int, error) {
+		return 1, expectedErr
+	})
+
+	// assert
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Zero(t, result)
+}
+
+func TestRunInTxGeneric_NilResult(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	runner := txRunnerFunc(func(ctx context.Context, fn func(ctx context.Context) (any, error), _ ...TxOption) (any, error) {
+		return nil, nil
+	})
+
+	// act
+	result, err := RunInTx(ctx, runner, func(ctx context.Context) (*TransactorConfig, error) {
+		return &TransactorConfig{}, nil
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestRunInTxGeneric_UnexpectedResultType(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	runner := txRunnerFunc(func(ctx context.Context, fn func(ctx context.Context) (any, error), _ ...TxOption) (any, error) {
+		return "result", nil
+	})
+
+	// act
+	result, err := RunInTx(ctx, runner, func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+
+	// assert
+	assert.ErrorContains(t, err, "unexpected transaction result type: string")
+	assert.Zero(t, result)
+}
@@ -0,0 +1,93 @@
+package migrator
+
+import (
+	"database/sql"
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// Migrator applies golang-migrate migrations from a file system, e.g. an embed.FS, to a SQLite database.
+type Migrator struct {
+	migrate *migrate.Migrate
+	source  source.Driver
+}
+
+// NewMigrator reads migrations from the root of fsys. The db connection is not closed by the migrator.
+func NewMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	sourceDriver, err := iofs.New(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	databaseDriver, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", sourceDriver, "sqlite3", databaseDriver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		migrate: m,
+		source:  sourceDriver,
+	}, nil
+}
+
+// Up applies all pending migrations, it is a no-op when the schema is up to date.
+func (m *Migrator) Up() error {
+	return ignoreNoChange(m.migrate.Up())
+}
+
+// Down rolls back all applied migrations.
+func (m *Migrator) Down() error {
+	return ignoreNoChange(m.migrate.Down())
+}
+
+// Steps applies n pending migrations when n is positive and rolls back -n applied migrations when it is negative.
+func (m *Migrator) Steps(n int) error {
+	return ignoreNoChange(m.migrate.Steps(n))
+}
+
+// Version returns the schema version, it is 0 when no migration is applied.
+// A dirty version is left by a failed migration and must be fixed manually.
+func (m *Migrator) Version() (version uint, dirty bool, err error) {
+	version, dirty, err = m.migrate.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// LatestVersion returns the version of the last available migration, it is 0 when there are none.
+func (m *Migrator) LatestVersion() (uint, error) {
+	version, err := m.source.First()
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+
+	for err == nil {
+		var next uint
+		next, err = m.source.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		version = next
+	}
+
+	return 0, err
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
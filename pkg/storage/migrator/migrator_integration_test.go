@@ -0,0 +1,133 @@
+//go:build integration
+
+package migrator
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/kiaplayer/clean-architecture-example/db/migrations"
+)
+
+const testDBFilePath = "sqlite_test.db"
+
+type TestMigratorSuite struct {
+	suite.Suite
+	db *sql.DB
+}
+
+func TestMigratorByTestSuite(t *testing.T) {
+	suite.Run(t, new(TestMigratorSuite))
+}
+
+func (mts *TestMigratorSuite) SetupTest() {
+	_ = os.Remove(testDBFilePath)
+
+	dbConn, err := sql.Open("sqlite3", testDBFilePath)
+	if err != nil {
+		mts.Failf("cannot open db connection before test: %s", err.Error())
+	}
+
+	mts.db = dbConn
+}
+
+func (mts *TestMigratorSuite) TearDownTest() {
+	err := mts.db.Close()
+	if err != nil {
+		mts.Failf("tear down test: %s", err.Error())
+	}
+	_ = os.Remove(testDBFilePath)
+}
+
+func (mts *TestMigratorSuite) TestUp_EmbeddedMigrations() {
+	// arrange
+	m, err := NewMigrator(mts.db, migrations.FS)
+	mts.Require().NoError(err)
+
+	latestVersion, latestErr := m.LatestVersion()
+	mts.Require().NoError(latestErr)
+
+	// act
+	initialVersion, initialDirty, initialErr := m.Version()
+	upErr := m.Up()
+	repeatedUpErr := m.Up()
+	version, dirty, versionErr := m.Version()
+
+	// assert
+	mts.NoError(initialErr)
+	mts.Equal(uint(0), initialVersion)
+	mts.False(initialDirty)
+	mts.NoError(upErr)
+	mts.NoError(repeatedUpErr)
+	mts.NoError(versionErr)
+	mts.Equal(latestVersion, version)
+	mts.GreaterOrEqual(version, uint(15))
+	mts.False(dirty)
+
+	var count int
+	mts.NoError(mts.db.QueryRow("SELECT COUNT(*) FROM sale_order").Scan(&count))
+}
+
+func (mts *TestMigratorSuite) TestStepsAndDown() {
+	// arrange
+	m, err := NewMigrator(mts.db, migrations.FS)
+	mts.Require().NoError(err)
+	mts.Require().NoError(m.Up())
+
+	latestVersion, latestErr := m.LatestVersion()
+	mts.Require().NoError(latestErr)
+
+	// act
+	stepsErr := m.Steps(-1)
+	stepVersion, _, _ := m.Version()
+	downErr := m.Down()
+	downVersion, _, downVersionErr := m.Version()
+
+	// assert
+	mts.NoError(stepsErr)
+	mts.Equal(latestVersion-1, stepVersion)
+	mts.NoError(downErr)
+	mts.NoError(downVersionErr)
+	mts.Equal(uint(0), downVersion)
+}
+
+func (mts *TestMigratorSuite) TestUp_DirtySchema() {
+	// arrange
+	m, err := NewMigrator(mts.db, fstest.MapFS{
+		"000001_create_item.up.sql":   {Data: []byte("CREATE TABLE item (id INTEGER PRIMARY KEY);")},
+		"000001_create_item.down.sql": {Data: []byte("DROP TABLE item;")},
+		"000002_broken.up.sql":        {Data: []byte("CREATE TABLE broken (;")},
+		"000002_broken.down.sql":      {Data: []byte("SELECT 1;")},
+	})
+	mts.Require().NoError(err)
+
+	// act
+	upErr := m.Up()
+	version, dirty, versionErr := m.Version()
+	repeatedUpErr := m.Up()
+
+	// assert
+	mts.Error(upErr)
+	mts.NoError(versionErr)
+	mts.Equal(uint(2), version)
+	mts.True(dirty)
+	mts.Error(repeatedUpErr)
+}
+
+func (mts *TestMigratorSuite) TestLatestVersion_NoMigrations() {
+	// arrange
+	m, err := NewMigrator(mts.db, fstest.MapFS{"README.md": {Data: []byte("no migrations")}})
+	mts.Require().NoError(err)
+
+	// act
+	version, versionErr := m.LatestVersion()
+
+	// assert
+	mts.NoError(versionErr)
+	mts.Equal(uint(0), version)
+}
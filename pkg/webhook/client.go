@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Request is a signed webhook call.
+type Request struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID uint64
+	Body       []byte
+}
+
+// Client posts webhook requests, responses with 2xx statuses are successful.
+type Client struct {
+	httpClient *http.Client
+}
+
+func NewClient(httpClient *http.Client) *Client {
+	return &Client{
+		httpClient: httpClient,
+	}
+}
+
+// Send posts the request and returns the response status, which is 0 if no response is received.
+func (c *Client) Send(ctx context.Context, req Request) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set(HeaderEvent, req.Event)
+	httpRequest.Header.Set(HeaderDelivery, strconv.FormatUint(req.DeliveryID, 10))
+	httpRequest.Header.Set(HeaderTimestamp, timestamp)
+	httpRequest.Header.Set(HeaderSignature, "sha256="+Sign(req.Secret, timestamp, req.Body))
+
+	httpResponse, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return 0, err
+	}
+	defer func(body io.ReadCloser) {
+		_, _ = io.Copy(io.Discard, body)
+		_ = body.Close()
+	}(httpResponse.Body)
+
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		return httpResponse.StatusCode, fmt.Errorf("unexpected response status: %d", httpResponse.StatusCode)
+	}
+
+	return httpResponse.StatusCode, nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of "timestamp.body" with the secret.
+// Receivers compute it the same way and compare with the signature header.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSend_Success(t *testing.T) {
+	// arrange
+	var received *http.Request
+	var receivedBody []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		received = request
+		receivedBody, _ = io.ReadAll(request.Body)
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	client := NewClient(receiver.Client())
+
+	req := Request{
+		URL:        receiver.URL,
+		Secret:     "secret",
+		Event:      "SaleOrderCreated",
+		DeliveryID: 5,
+		Body:       []byte(`{"id":1}`),
+	}
+
+	// act
+	status, err := client.Send(context.Background(), req)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, "SaleOrderCreated", received.Header.Get(HeaderEvent))
+	assert.Equal(t, "5", received.Header.Get(HeaderDelivery))
+	assert.Equal(t, req.Body, receivedBody)
+	assert.Equal(
+		t,
+		"sha256="+Sign("secret", received.Header.Get(HeaderTimestamp), receivedBody),
+		received.Header.Get(HeaderSignature),
+	)
+}
+
+func TestSend_ErrorStatus(t *testing.T) {
+	// arrange
+	receiver := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	client := NewClient(receiver.Client())
+
+	// act
+	status, err := client.Send(context.Background(), Request{URL: receiver.URL, Body: []byte(`{}`)})
+
+	// assert
+	assert.ErrorContains(t, err, "unexpected response status: 500")
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func TestSend_ReceiverUnavailable(t *testing.T) {
+	// arrange
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	receiver.Close()
+
+	client := NewClient(http.DefaultClient)
+
+	// act
+	status, err := client.Send(context.Background(), Request{URL: receiver.URL, Body: []byte(`{}`)})
+
+	// assert
+	assert.Error(t, err)
+	assert.Equal(t, 0, status)
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{"id":1}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(
+		t,
+		"3dd1b9aef568d75f6790a84bd2e5dfa1f44409eef3cbdbd3f10b837376100c11",
+		Sign("secret", "1700000000", []byte(`{"id":1}`)),
+	)
+}